@@ -13,9 +13,9 @@ type GetAccountService struct {
 // Do send request
 func (s *GetAccountService) Do(ctx context.Context, opts ...RequestOption) (res *Account, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/account",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/account",
+		SecType:  secTypeSigned,
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -95,20 +95,20 @@ func (s *GetAccountSnapshotService) Limit(limit int) *GetAccountSnapshotService
 // Do send request
 func (s *GetAccountSnapshotService) Do(ctx context.Context, opts ...RequestOption) (res *Snapshot, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/accountSnapshot",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/accountSnapshot",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("type", s.accountType)
+	r.SetParam("type", s.accountType)
 
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -190,9 +190,9 @@ type GetAPIKeyPermission struct {
 // Do send request
 func (s *GetAPIKeyPermission) Do(ctx context.Context, opts ...RequestOption) (res *APIKeyPermission, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/account/apiRestrictions",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/account/apiRestrictions",
+		SecType:  secTypeSigned,
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -139,7 +139,7 @@ func (s *accountServiceTestSuite) TestGetAccountSnapshot() {
 	endTime := int64(1498793709156)
 	limit := 1
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"type":      accountType,
 			"startTime": startTime,
 			"endTime":   endTime,
@@ -22,12 +22,12 @@ func (s *GetAssetDetailService) Asset(asset string) *GetAssetDetailService {
 // Do sends the request.
 func (s *GetAssetDetailService) Do(ctx context.Context) (res map[string]AssetDetail, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/asset/assetDetail",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/asset/assetDetail",
+		SecType:  secTypeSigned,
 	}
 	if s.asset != nil {
-		r.setParam("asset", *s.asset)
+		r.SetParam("asset", *s.asset)
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
@@ -49,9 +49,9 @@ type GetAllCoinsInfoService struct {
 // Do send request
 func (s *GetAllCoinsInfoService) Do(ctx context.Context) (res []*CoinInfo, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/capital/config/getall",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/capital/config/getall",
+		SecType:  secTypeSigned,
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
@@ -143,15 +143,15 @@ type UserAssetRecord struct {
 
 func (s *GetUserAssetService) Do(ctx context.Context) (res []UserAssetRecord, err error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: "/sapi/v3/asset/getUserAsset",
-		secType:  secTypeSigned,
+		Method:   http.MethodPost,
+		Endpoint: "/sapi/v3/asset/getUserAsset",
+		SecType:  secTypeSigned,
 	}
 	if s.asset != nil {
-		r.setParam("asset", *s.asset)
+		r.SetParam("asset", *s.asset)
 	}
 	if s.needBtcValuation {
-		r.setParam("needBtcValuation", s.needBtcValuation)
+		r.SetParam("needBtcValuation", s.needBtcValuation)
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
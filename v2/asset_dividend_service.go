@@ -43,23 +43,23 @@ func (s *AssetDividendService) EndTime(endTime int64) *AssetDividendService {
 // Do sends the request.
 func (s *AssetDividendService) Do(ctx context.Context) (*DividendResponseWrapper, error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/asset/assetDividend",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/asset/assetDividend",
+		SecType:  secTypeSigned,
 	}
 	if s.asset != nil {
-		r.setParam("asset", *s.asset)
+		r.SetParam("asset", *s.asset)
 	}
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	} else {
-		r.setParam("limit", 20)
+		r.SetParam("limit", 20)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
@@ -46,7 +46,7 @@ func (s *assetDividendServiceTestSuite) TestListAssetDividend() {
 	startTime := int64(1508198532000)
 	endTime := int64(1508198532001)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			`asset`:     asset,
 			`limit`:     2,
 			`startTime`: startTime,
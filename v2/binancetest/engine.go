@@ -0,0 +1,593 @@
+package binancetest
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Order sides, types, time in force and statuses handled by the engine
+const (
+	sideBuy  = "BUY"
+	sideSell = "SELL"
+
+	orderTypeLimit      = "LIMIT"
+	orderTypeMarket     = "MARKET"
+	orderTypeLimitMaker = "LIMIT_MAKER"
+
+	timeInForceGTC = "GTC"
+	timeInForceIOC = "IOC"
+	timeInForceFOK = "FOK"
+	timeInForceGTX = "GTX"
+
+	statusNew             = "NEW"
+	statusPartiallyFilled = "PARTIALLY_FILLED"
+	statusFilled          = "FILLED"
+	statusCanceled        = "CANCELED"
+	statusExpired         = "EXPIRED"
+
+	executionNew      = "NEW"
+	executionTrade    = "TRADE"
+	executionCanceled = "CANCELED"
+	executionExpired  = "EXPIRED"
+)
+
+const decimalScale = 100000000
+
+// decimal is a fixed point number with 8 decimal places, as used by Binance
+type decimal int64
+
+func parseDecimal(s string) (decimal, bool) {
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if intPart == "" || strings.HasPrefix(intPart, "-") || len(fracPart) > 8 {
+		return 0, false
+	}
+	i, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	var f int64
+	if fracPart != "" {
+		f, err = strconv.ParseInt(fracPart+strings.Repeat("0", 8-len(fracPart)), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+	}
+	return decimal(i*decimalScale + f), true
+}
+
+func (d decimal) String() string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	return fmt.Sprintf("%s%d.%08d", sign, int64(d)/decimalScale, int64(d)%decimalScale)
+}
+
+// mul returns d*x rounded down
+func (d decimal) mul(x decimal) decimal {
+	r := new(big.Int).Mul(big.NewInt(int64(d)), big.NewInt(int64(x)))
+	return decimal(r.Quo(r, big.NewInt(decimalScale)).Int64())
+}
+
+// div returns d/x rounded down
+func (d decimal) div(x decimal) decimal {
+	r := new(big.Int).Mul(big.NewInt(int64(d)), big.NewInt(decimalScale))
+	return decimal(r.Quo(r, big.NewInt(int64(x))).Int64())
+}
+
+func minDecimal(a, b decimal) decimal {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+type balance struct {
+	free   decimal
+	locked decimal
+}
+
+type order struct {
+	id            int64
+	clientOrderID string
+	symbol        string
+	side          string
+	orderType     string
+	timeInForce   string
+	price         decimal
+	origQty       decimal
+	executedQty   decimal
+	cumQuote      decimal
+	status        string
+	time          int64
+	updateTime    int64
+	// locked is the balance still locked by a resting spot order
+	locked decimal
+	// external orders are placed with Server.PlaceOrder on behalf of another
+	// trader, they don't change the balances and send no user data events
+	external bool
+}
+
+func (o *order) remaining() decimal {
+	return o.origQty - o.executedQty
+}
+
+func (o *order) avgPrice() decimal {
+	if o.executedQty == 0 {
+		return 0
+	}
+	return o.cumQuote.div(o.executedQty)
+}
+
+// crosses reports whether o can be matched with a resting order at price
+func (o *order) crosses(price decimal) bool {
+	switch {
+	case o.orderType == orderTypeMarket:
+		return true
+	case o.side == sideBuy:
+		return o.price >= price
+	default:
+		return o.price <= price
+	}
+}
+
+type fill struct {
+	tradeID int64
+	price   decimal
+	qty     decimal
+	taker   *order
+	maker   *order
+}
+
+type symbol struct {
+	name      string
+	base      string
+	quote     string
+	bids      []*order
+	asks      []*order
+	orders    []*order
+	lastPrice decimal
+	updateID  int64
+}
+
+// level returns the total quantity resting at the price on the side
+func (sym *symbol) level(side string, price decimal) decimal {
+	book := sym.bids
+	if side == sideSell {
+		book = sym.asks
+	}
+	var qty decimal
+	for _, o := range book {
+		if o.price == price {
+			qty += o.remaining()
+		}
+	}
+	return qty
+}
+
+// depth returns up to limit price levels of the side, best first
+func (sym *symbol) depth(side string, limit int) [][2]string {
+	book := sym.bids
+	if side == sideSell {
+		book = sym.asks
+	}
+	var levels [][2]string
+	for i := 0; i < len(book); {
+		price := book[i].price
+		var qty decimal
+		for ; i < len(book) && book[i].price == price; i++ {
+			qty += book[i].remaining()
+		}
+		if len(levels) == limit {
+			break
+		}
+		levels = append(levels, [2]string{price.String(), qty.String()})
+	}
+	return levels
+}
+
+func (sym *symbol) rest(o *order) {
+	if o.side == sideBuy {
+		sym.bids = append(sym.bids, o)
+		sort.SliceStable(sym.bids, func(i, j int) bool { return sym.bids[i].price > sym.bids[j].price })
+	} else {
+		sym.asks = append(sym.asks, o)
+		sort.SliceStable(sym.asks, func(i, j int) bool { return sym.asks[i].price < sym.asks[j].price })
+	}
+}
+
+func (sym *symbol) remove(o *order) {
+	book := &sym.bids
+	if o.side == sideSell {
+		book = &sym.asks
+	}
+	for i, r := range *book {
+		if r == o {
+			*book = append((*book)[:i], (*book)[i+1:]...)
+			return
+		}
+	}
+}
+
+func (sym *symbol) order(id int64, clientOrderID string) *order {
+	for _, o := range sym.orders {
+		if (id != 0 && o.id == id) || (id == 0 && clientOrderID != "" && o.clientOrderID == clientOrderID) {
+			return o
+		}
+	}
+	return nil
+}
+
+// market is the state of a market, protected by the server mutex
+type market struct {
+	name     Market
+	symbols  map[string]*symbol
+	balances map[string]*balance
+}
+
+func newMarket(name Market) *market {
+	return &market{
+		name:     name,
+		symbols:  make(map[string]*symbol),
+		balances: make(map[string]*balance),
+	}
+}
+
+func (m *market) balance(asset string) *balance {
+	b, ok := m.balances[asset]
+	if !ok {
+		b = &balance{}
+		m.balances[asset] = b
+	}
+	return b
+}
+
+// execution tracks the changes made by an operation on a symbol, to send the
+// stream events once it is done
+type execution struct {
+	m       *market
+	sym     *symbol
+	levels  map[string]map[decimal]bool
+	assets  map[string]bool
+	updates []orderUpdate
+	fills   []*fill
+}
+
+type orderUpdate struct {
+	order         *order
+	executionType string
+	fill          *fill
+}
+
+func newExecution(m *market, sym *symbol) *execution {
+	return &execution{
+		m:      m,
+		sym:    sym,
+		levels: map[string]map[decimal]bool{sideBuy: {}, sideSell: {}},
+		assets: make(map[string]bool),
+	}
+}
+
+func (e *execution) update(o *order, executionType string, f *fill) {
+	if !o.external {
+		e.updates = append(e.updates, orderUpdate{order: o, executionType: executionType, fill: f})
+	}
+}
+
+// AddSymbol lists a symbol on the market, replacing any existing one
+func (s *Server) AddSymbol(m Market, name, baseAsset, quoteAsset string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.markets[m].symbols[name] = &symbol{name: name, base: baseAsset, quote: quoteAsset}
+}
+
+// SetBalance sets the free balance of the asset on the market
+func (s *Server) SetBalance(m Market, asset, free string) {
+	d, ok := parseDecimal(free)
+	if !ok {
+		panic(fmt.Sprintf("binancetest: invalid balance %q", free))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.markets[m].balance(asset).free = d
+}
+
+// Balance returns the free and locked balance of the asset on the market
+func (s *Server) Balance(m Market, asset string) (free, locked string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.markets[m].balance(asset)
+	return b.free.String(), b.locked.String()
+}
+
+// PlaceOrder places a GTC limit order on behalf of another trader, and returns
+// its order ID. It is matched with the resting orders like any order, which
+// allows to fill the orders of the client, and rests in the book otherwise.
+func (s *Server) PlaceOrder(m Market, symbolName, side, price, quantity string) (int64, error) {
+	p, ok := parseDecimal(price)
+	if !ok || p == 0 {
+		return 0, fmt.Errorf("binancetest: invalid price %q", price)
+	}
+	q, ok := parseDecimal(quantity)
+	if !ok || q == 0 {
+		return 0, fmt.Errorf("binancetest: invalid quantity %q", quantity)
+	}
+	if side != sideBuy && side != sideSell {
+		return 0, fmt.Errorf("binancetest: invalid side %q", side)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mk := s.markets[m]
+	sym, ok := mk.symbols[symbolName]
+	if !ok {
+		return 0, fmt.Errorf("binancetest: unknown symbol %q", symbolName)
+	}
+	o := &order{
+		symbol:      sym.name,
+		side:        side,
+		orderType:   orderTypeLimit,
+		timeInForce: timeInForceGTC,
+		price:       p,
+		origQty:     q,
+		external:    true,
+	}
+	s.register(sym, o)
+	e := newExecution(mk, sym)
+	s.execute(e, o, 0)
+	s.publish(e)
+	return o.id, nil
+}
+
+// CancelOrder cancels a resting order of the market, placed by the client or
+// with PlaceOrder
+func (s *Server) CancelOrder(m Market, symbolName string, orderID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mk := s.markets[m]
+	sym, ok := mk.symbols[symbolName]
+	if !ok {
+		return fmt.Errorf("binancetest: unknown symbol %q", symbolName)
+	}
+	o := sym.order(orderID, "")
+	if o == nil || !isOpen(o) {
+		return fmt.Errorf("binancetest: unknown order %d", orderID)
+	}
+	e := newExecution(mk, sym)
+	s.cancel(e, o)
+	s.publish(e)
+	return nil
+}
+
+func isOpen(o *order) bool {
+	return o.status == statusNew || o.status == statusPartiallyFilled
+}
+
+// register assigns an ID to the order and adds it to the orders of the symbol
+func (s *Server) register(sym *symbol, o *order) {
+	s.nextOrderID++
+	o.id = s.nextOrderID
+	if o.clientOrderID == "" {
+		o.clientOrderID = fmt.Sprintf("binancetest%d", o.id)
+	}
+	o.status = statusNew
+	o.time = s.nowMillis()
+	o.updateTime = o.time
+	sym.orders = append(sym.orders, o)
+}
+
+// placeOrder validates a client order, checks the balance, registers and
+// executes it. quoteQty is the quote quantity of a market order, 0 when the
+// quantity is set.
+func (s *Server) placeOrder(m *market, sym *symbol, o *order, quoteQty decimal) (*execution, *apiError) {
+	switch o.orderType {
+	case orderTypeLimit, orderTypeLimitMaker, orderTypeMarket:
+	default:
+		return nil, &apiError{status: http.StatusBadRequest, Code: -1116, Message: "Invalid orderType."}
+	}
+	wouldMatch := false
+	if book := sym.asks; o.side == sideBuy && len(book) > 0 {
+		wouldMatch = o.crosses(book[0].price)
+	} else if book := sym.bids; o.side == sideSell && len(book) > 0 {
+		wouldMatch = o.crosses(book[0].price)
+	}
+	if wouldMatch && (o.orderType == orderTypeLimitMaker || o.timeInForce == timeInForceGTX) {
+		if m.name == Spot {
+			return nil, &apiError{status: http.StatusBadRequest, Code: -2010, Message: "Order would immediately match and take."}
+		}
+		return nil, &apiError{status: http.StatusBadRequest, Code: -5022, Message: "Due to the order could not be executed as maker, the Post Only order will be rejected."}
+	}
+	if m.name == Spot && !s.hasBalance(m, sym, o, quoteQty) {
+		return nil, errInsufficientBalance()
+	}
+	s.register(sym, o)
+	e := newExecution(m, sym)
+	e.update(o, executionNew, nil)
+	s.execute(e, o, quoteQty)
+	return e, nil
+}
+
+// hasBalance reports whether the spot balance is enough for the order
+func (s *Server) hasBalance(m *market, sym *symbol, o *order, quoteQty decimal) bool {
+	if o.side == sideSell {
+		return m.balance(sym.base).free >= o.origQty
+	}
+	if o.orderType != orderTypeMarket {
+		return m.balance(sym.quote).free >= o.origQty.mul(o.price)
+	}
+	if quoteQty > 0 {
+		return m.balance(sym.quote).free >= quoteQty
+	}
+	// cost of a market buy walking the book
+	var cost decimal
+	remaining := o.origQty
+	for _, r := range sym.asks {
+		qty := minDecimal(remaining, r.remaining())
+		cost += qty.mul(r.price)
+		if remaining -= qty; remaining == 0 {
+			break
+		}
+	}
+	return m.balance(sym.quote).free >= cost
+}
+
+// execute matches o with the resting orders, then rests or expires the remaining quantity
+func (s *Server) execute(e *execution, o *order, quoteQty decimal) {
+	sym := e.sym
+	if o.timeInForce == timeInForceFOK && s.available(sym, o) < o.origQty {
+		o.status = statusExpired
+		e.update(o, executionExpired, nil)
+		return
+	}
+	if quoteQty > 0 {
+		// the quantity of a quote market order is known once executed
+		o.origQty = 1 << 62
+	}
+	for o.remaining() > 0 {
+		book := sym.asks
+		if o.side == sideSell {
+			book = sym.bids
+		}
+		if len(book) == 0 || !o.crosses(book[0].price) {
+			break
+		}
+		maker := book[0]
+		qty := minDecimal(o.remaining(), maker.remaining())
+		if quoteQty > 0 {
+			qty = minDecimal(qty, (quoteQty - o.cumQuote).div(maker.price))
+			if qty == 0 {
+				break
+			}
+		}
+		s.nextTradeID++
+		f := &fill{tradeID: s.nextTradeID, price: maker.price, qty: qty, taker: o, maker: maker}
+		e.fills = append(e.fills, f)
+		e.levels[maker.side][maker.price] = true
+		sym.lastPrice = maker.price
+		for _, x := range []*order{o, maker} {
+			x.executedQty += qty
+			x.cumQuote += qty.mul(f.price)
+			x.updateTime = s.nowMillis()
+			x.status = statusPartiallyFilled
+			if x.remaining() == 0 || (x == o && quoteQty > 0 && (quoteQty-o.cumQuote).div(f.price) == 0) {
+				x.status = statusFilled
+			}
+			s.settle(e, x, f)
+			e.update(x, executionTrade, f)
+		}
+		if maker.status == statusFilled {
+			sym.remove(maker)
+		}
+	}
+	if quoteQty > 0 {
+		o.origQty = o.executedQty
+		if o.status != statusFilled {
+			o.status = statusExpired
+			e.update(o, executionExpired, nil)
+		}
+		return
+	}
+	if o.remaining() == 0 {
+		return
+	}
+	if o.orderType == orderTypeMarket || o.timeInForce == timeInForceIOC {
+		o.status = statusExpired
+		e.update(o, executionExpired, nil)
+		return
+	}
+	if e.m.name == Spot && !o.external {
+		o.locked = o.remaining()
+		asset := sym.base
+		if o.side == sideBuy {
+			o.locked = o.remaining().mul(o.price)
+			asset = sym.quote
+		}
+		b := e.m.balance(asset)
+		b.free -= o.locked
+		b.locked += o.locked
+		e.assets[asset] = true
+	}
+	sym.rest(o)
+	e.levels[o.side][o.price] = true
+}
+
+// available returns the quantity o can be matched with
+func (s *Server) available(sym *symbol, o *order) decimal {
+	book := sym.asks
+	if o.side == sideSell {
+		book = sym.bids
+	}
+	var qty decimal
+	for _, r := range book {
+		if !o.crosses(r.price) {
+			break
+		}
+		qty += r.remaining()
+	}
+	return qty
+}
+
+// settle moves the spot balances of a fill of o
+func (s *Server) settle(e *execution, o *order, f *fill) {
+	if e.m.name != Spot || o.external {
+		return
+	}
+	base, quote := e.m.balance(e.sym.base), e.m.balance(e.sym.quote)
+	cost := f.qty.mul(f.price)
+	resting := o != f.taker
+	if o.side == sideBuy {
+		base.free += f.qty
+		if resting {
+			spent := minDecimal(cost, o.locked)
+			o.locked -= spent
+			quote.locked -= spent
+			quote.free -= cost - spent
+		} else {
+			quote.free -= cost
+		}
+	} else {
+		quote.free += cost
+		if resting {
+			o.locked -= f.qty
+			base.locked -= f.qty
+		} else {
+			base.free -= f.qty
+		}
+	}
+	if o.status == statusFilled {
+		s.unlock(e, o)
+	}
+	e.assets[e.sym.base] = true
+	e.assets[e.sym.quote] = true
+}
+
+// unlock releases the balance still locked by a spot order
+func (s *Server) unlock(e *execution, o *order) {
+	if o.locked == 0 {
+		return
+	}
+	asset := e.sym.base
+	if o.side == sideBuy {
+		asset = e.sym.quote
+	}
+	b := e.m.balance(asset)
+	b.locked -= o.locked
+	b.free += o.locked
+	o.locked = 0
+	e.assets[asset] = true
+}
+
+func (s *Server) cancel(e *execution, o *order) {
+	e.sym.remove(o)
+	e.levels[o.side][o.price] = true
+	o.status = statusCanceled
+	o.updateTime = s.nowMillis()
+	s.unlock(e, o)
+	e.update(o, executionCanceled, nil)
+}
@@ -0,0 +1,137 @@
+package binancetest
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// registerFuturesRoutes registers the routes of the USDⓈ-M futures API with
+// the /fapi prefix, or of the COIN-M futures API with the /dapi prefix
+func (s *Server) registerFuturesRoutes(prefix string) {
+	accountVersion := "/v2"
+	if prefix == "/dapi" {
+		accountVersion = "/v1"
+	}
+	s.handle(http.MethodGet, prefix+"/v1/ping", secTypeNone, s.ping)
+	s.handle(http.MethodGet, prefix+"/v1/time", secTypeNone, s.serverTime)
+	s.handle(http.MethodGet, prefix+"/v1/exchangeInfo", secTypeNone, s.exchangeInfo)
+	s.handle(http.MethodGet, prefix+"/v1/depth", secTypeNone, s.depth)
+	s.handle(http.MethodGet, prefix+"/v1/ticker/price", secTypeNone, s.tickerPrice)
+	s.handle(http.MethodGet, prefix+accountVersion+"/balance", secTypeSigned, s.futuresBalance)
+	s.handle(http.MethodGet, prefix+accountVersion+"/account", secTypeSigned, s.futuresAccount)
+	s.handle(http.MethodPost, prefix+"/v1/order", secTypeSigned, s.createOrder)
+	s.handle(http.MethodGet, prefix+"/v1/order", secTypeSigned, s.getOrder)
+	s.handle(http.MethodDelete, prefix+"/v1/order", secTypeSigned, s.cancelOrder)
+	s.handle(http.MethodGet, prefix+"/v1/openOrders", secTypeSigned, s.openOrders)
+	s.handle(http.MethodDelete, prefix+"/v1/allOpenOrders", secTypeSigned, s.cancelOpenOrders)
+	s.handle(http.MethodGet, prefix+"/v1/allOrders", secTypeSigned, s.allOrders)
+	s.handle(http.MethodPost, prefix+"/v1/listenKey", secTypeAPIKey, s.startUserStream)
+	s.handle(http.MethodPut, prefix+"/v1/listenKey", secTypeAPIKey, s.keepaliveUserStream)
+	s.handle(http.MethodDelete, prefix+"/v1/listenKey", secTypeAPIKey, s.closeUserStream)
+}
+
+func (s *Server) futuresAssets(m *market) []string {
+	assets := make([]string, 0, len(m.balances))
+	for asset := range m.balances {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+	return assets
+}
+
+func (s *Server) futuresBalance(m *market, params url.Values) (interface{}, *apiError) {
+	var res []map[string]interface{}
+	for _, asset := range s.futuresAssets(m) {
+		b := m.balances[asset]
+		res = append(res, map[string]interface{}{
+			"accountAlias":       "binancetest",
+			"asset":              asset,
+			"balance":            b.free.String(),
+			"crossWalletBalance": b.free.String(),
+			"crossUnPnl":         decimal(0).String(),
+			"availableBalance":   b.free.String(),
+			"maxWithdrawAmount":  b.free.String(),
+			"withdrawAvailable":  b.free.String(),
+			"updateTime":         s.nowMillis(),
+		})
+	}
+	if res == nil {
+		res = []map[string]interface{}{}
+	}
+	return res, nil
+}
+
+func (s *Server) futuresAccount(m *market, params url.Values) (interface{}, *apiError) {
+	assets := []map[string]interface{}{}
+	var total decimal
+	for _, asset := range s.futuresAssets(m) {
+		b := m.balances[asset]
+		total += b.free
+		assets = append(assets, map[string]interface{}{
+			"asset":                  asset,
+			"walletBalance":          b.free.String(),
+			"unrealizedProfit":       decimal(0).String(),
+			"marginBalance":          b.free.String(),
+			"maintMargin":            decimal(0).String(),
+			"initialMargin":          decimal(0).String(),
+			"positionInitialMargin":  decimal(0).String(),
+			"openOrderInitialMargin": decimal(0).String(),
+			"crossWalletBalance":     b.free.String(),
+			"crossUnPnl":             decimal(0).String(),
+			"availableBalance":       b.free.String(),
+			"maxWithdrawAmount":      b.free.String(),
+			"marginAvailable":        true,
+			"updateTime":             s.nowMillis(),
+		})
+	}
+	res := map[string]interface{}{
+		"feeTier":     0,
+		"canTrade":    true,
+		"canDeposit":  true,
+		"canWithdraw": true,
+		"updateTime":  s.nowMillis(),
+		"assets":      assets,
+		"positions":   []interface{}{},
+	}
+	if m.name == Futures {
+		res["totalWalletBalance"] = total.String()
+		res["totalMarginBalance"] = total.String()
+		res["availableBalance"] = total.String()
+		res["maxWithdrawAmount"] = total.String()
+	}
+	return res, nil
+}
+
+func futuresOrderJSON(o *order) map[string]interface{} {
+	timeInForce := o.timeInForce
+	if timeInForce == "" {
+		timeInForce = timeInForceGTC
+	}
+	return map[string]interface{}{
+		"symbol":        o.symbol,
+		"pair":          o.symbol,
+		"orderId":       o.id,
+		"clientOrderId": o.clientOrderID,
+		"price":         o.price.String(),
+		"avgPrice":      o.avgPrice().String(),
+		"origQty":       o.origQty.String(),
+		"executedQty":   o.executedQty.String(),
+		"cumQty":        o.executedQty.String(),
+		"cumQuote":      o.cumQuote.String(),
+		"cumBase":       o.cumQuote.String(),
+		"status":        o.status,
+		"timeInForce":   timeInForce,
+		"type":          o.orderType,
+		"origType":      o.orderType,
+		"side":          o.side,
+		"positionSide":  "BOTH",
+		"stopPrice":     decimal(0).String(),
+		"workingType":   "CONTRACT_PRICE",
+		"reduceOnly":    false,
+		"closePosition": false,
+		"priceProtect":  false,
+		"time":          o.time,
+		"updateTime":    o.updateTime,
+	}
+}
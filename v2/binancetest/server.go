@@ -0,0 +1,323 @@
+// Package binancetest provides a fake Binance server for offline integration
+// tests.
+//
+// The server emulates the main spot, USDⓈ-M futures and COIN-M futures REST
+// endpoints and the websocket streams, backed by an in-memory matching engine.
+// SIGNED requests are checked against the API key, the secret key, or Signer
+// for RSA and Ed25519 keys, and the recvWindow like on Binance, so clients only
+// need their base URLs to point at the server:
+//
+//	srv := binancetest.NewServer()
+//	defer srv.Close()
+//
+//	client := binance.NewClientWithEnvironment(srv.APIKey, srv.SecretKey, &binance.Environment{
+//		APIURL:      srv.URL,
+//		WsURL:       srv.WsURL(binancetest.Spot),
+//		CombinedURL: srv.CombinedURL(binancetest.Spot),
+//	})
+package binancetest
+
+import (
+	"crypto/hmac"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/common"
+)
+
+// Market define a market emulated by the server
+type Market string
+
+// Markets emulated by the server
+const (
+	Spot     Market = "spot"
+	Futures  Market = "futures"
+	Delivery Market = "delivery"
+)
+
+// Default credentials of the server
+const (
+	DefaultAPIKey    = "binancetest-api-key"
+	DefaultSecretKey = "binancetest-secret-key"
+)
+
+// apiPrefixes are the path prefixes of the REST API of every market
+var apiPrefixes = map[string]Market{
+	"/api/":  Spot,
+	"/fapi/": Futures,
+	"/dapi/": Delivery,
+}
+
+type secType int
+
+const (
+	secTypeNone secType = iota
+	secTypeAPIKey
+	secTypeSigned
+)
+
+type handlerFunc func(m *market, params url.Values) (interface{}, *apiError)
+
+type route struct {
+	secType secType
+	handler handlerFunc
+}
+
+// Server is a fake Binance server. Its state is safe for concurrent use by
+// the tests and the clients.
+type Server struct {
+	*httptest.Server
+
+	// APIKey and SecretKey are the credentials accepted by the server
+	APIKey    string
+	SecretKey string
+	// Signer checks the signatures of the SIGNED requests by signing their
+	// payload again, HMAC-SHA256 with SecretKey is used when nil. Set it to the
+	// signer of the client for RSA or Ed25519 keys, whose signatures are
+	// deterministic.
+	Signer common.Signer
+	// ClockOffset is added to the server time, to emulate a client clock skew
+	ClockOffset time.Duration
+	// MaxStreams is the maximum number of streams of a websocket connection,
+	// 1024 when 0 as on Binance
+	MaxStreams int
+	// MessageRate is the maximum number of requests received per second on a
+	// websocket connection, the connections sending more are closed like
+	// Binance does. 0 disables the limit.
+	MessageRate int
+
+	mu          sync.Mutex
+	markets     map[Market]*market
+	routes      map[string]route
+	listenKeys  map[string]Market
+	nextOrderID int64
+	nextTradeID int64
+	streams     *hub
+}
+
+// NewServer starts a server with the default credentials. The BTCUSDT and
+// ETHUSDT symbols are listed on the spot and futures markets, and BTCUSD_PERP
+// on the delivery market. The caller must call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:     DefaultAPIKey,
+		SecretKey:  DefaultSecretKey,
+		markets:    make(map[Market]*market),
+		routes:     make(map[string]route),
+		listenKeys: make(map[string]Market),
+		streams:    newHub(),
+	}
+	for _, m := range []Market{Spot, Futures, Delivery} {
+		s.markets[m] = newMarket(m)
+	}
+	s.AddSymbol(Spot, "BTCUSDT", "BTC", "USDT")
+	s.AddSymbol(Spot, "ETHUSDT", "ETH", "USDT")
+	s.AddSymbol(Futures, "BTCUSDT", "BTC", "USDT")
+	s.AddSymbol(Futures, "ETHUSDT", "ETH", "USDT")
+	s.AddSymbol(Delivery, "BTCUSD_PERP", "BTC", "USD")
+	s.registerSpotRoutes()
+	s.registerFuturesRoutes("/fapi")
+	s.registerFuturesRoutes("/dapi")
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Close closes the websocket connections and shuts down the server
+func (s *Server) Close() {
+	s.streams.closeAll()
+	s.Server.Close()
+}
+
+// WsURL returns the base URL of the raw websocket streams of the market
+func (s *Server) WsURL(m Market) string {
+	return fmt.Sprintf("ws%s/%s/ws", strings.TrimPrefix(s.URL, "http"), m)
+}
+
+// CombinedURL returns the base URL of the combined websocket streams of the market
+func (s *Server) CombinedURL(m Market) string {
+	return fmt.Sprintf("ws%s/%s/stream?streams=", strings.TrimPrefix(s.URL, "http"), m)
+}
+
+// WsAPIURL returns the URL of the WebSocket API of the market
+func (s *Server) WsAPIURL(m Market) string {
+	return fmt.Sprintf("ws%s/%s/ws-api", strings.TrimPrefix(s.URL, "http"), m)
+}
+
+// now returns the server time
+func (s *Server) now() time.Time {
+	return time.Now().Add(s.ClockOffset)
+}
+
+func (s *Server) nowMillis() int64 {
+	return s.now().UnixNano() / int64(time.Millisecond)
+}
+
+func (s *Server) handle(method, endpoint string, sec secType, h handlerFunc) {
+	s.routes[method+" "+endpoint] = route{secType: sec, handler: h}
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for _, m := range []Market{Spot, Futures, Delivery} {
+		if r.URL.Path == "/"+string(m)+"/ws-api" {
+			s.serveWsAPI(w, r, m)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/"+string(m)+"/ws") || strings.HasPrefix(r.URL.Path, "/"+string(m)+"/stream") {
+			s.serveWs(w, r, m)
+			return
+		}
+	}
+	var m Market
+	for prefix, market := range apiPrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			m = market
+		}
+	}
+	rt, ok := s.routes[r.Method+" "+r.URL.Path]
+	if m == "" || !ok {
+		writeError(w, &apiError{status: http.StatusNotFound, Code: -1000, Message: "Unknown endpoint."})
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, errMalformed("body"))
+		return
+	}
+	params, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		writeError(w, errMalformed("query"))
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		writeError(w, errMalformed("body"))
+		return
+	}
+	for k, v := range form {
+		params[k] = append(params[k], v...)
+	}
+	if apiErr := s.authenticate(r, rt.secType, string(body), params); apiErr != nil {
+		writeError(w, apiErr)
+		return
+	}
+
+	s.mu.Lock()
+	res, apiErr := rt.handler(s.markets[m], params)
+	s.mu.Unlock()
+	if apiErr != nil {
+		writeError(w, apiErr)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// authenticate checks the API key, the timestamp and the signature of the request
+func (s *Server) authenticate(r *http.Request, sec secType, body string, params url.Values) *apiError {
+	if sec == secTypeNone {
+		return nil
+	}
+	if r.Header.Get("X-MBX-APIKEY") != s.APIKey {
+		return &apiError{status: http.StatusUnauthorized, Code: -2015, Message: "Invalid API-key, IP, or permissions for action."}
+	}
+	if sec != secTypeSigned {
+		return nil
+	}
+	if apiErr := s.checkTimestamp(params); apiErr != nil {
+		return apiErr
+	}
+
+	// the signature is the last parameter of the query string, and signs the
+	// query string followed by the body
+	query := r.URL.RawQuery
+	i := strings.LastIndex(query, "signature=")
+	if i < 0 {
+		return errMandatory("signature")
+	}
+	signature, err := url.QueryUnescape(query[i+len("signature="):])
+	if err != nil {
+		return errMalformed("signature")
+	}
+	query = strings.TrimSuffix(query[:i], "&")
+	return s.checkSignature(query+body, signature)
+}
+
+// checkTimestamp checks that the timestamp of a SIGNED request is within its recvWindow
+func (s *Server) checkTimestamp(params url.Values) *apiError {
+	timestamp, err := strconv.ParseInt(params.Get("timestamp"), 10, 64)
+	if err != nil {
+		return errMandatory("timestamp")
+	}
+	recvWindow := int64(5000)
+	if v := params.Get("recvWindow"); v != "" {
+		recvWindow, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || recvWindow <= 0 || recvWindow > 60000 {
+			return &apiError{status: http.StatusBadRequest, Code: -1131, Message: "recvWindow must be less than 60000"}
+		}
+	}
+	now := s.nowMillis()
+	if timestamp > now+1000 || now-timestamp > recvWindow {
+		return &apiError{status: http.StatusBadRequest, Code: -1021, Message: "Timestamp for this request is outside of the recvWindow."}
+	}
+	return nil
+}
+
+// checkSignature checks the signature of the payload with the Signer
+func (s *Server) checkSignature(payload, signature string) *apiError {
+	signer := s.Signer
+	if signer == nil {
+		signer = common.NewHMACSigner(s.SecretKey)
+	}
+	expected, err := signer.Sign([]byte(payload))
+	if err != nil || !hmac.Equal([]byte(signature), []byte(expected)) {
+		return &apiError{status: http.StatusBadRequest, Code: -1022, Message: "Signature for this request is not valid."}
+	}
+	return nil
+}
+
+// apiError is an error response of the server
+type apiError struct {
+	status  int
+	Code    int64  `json:"code"`
+	Message string `json:"msg"`
+}
+
+func writeError(w http.ResponseWriter, e *apiError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.status)
+	json.NewEncoder(w).Encode(e)
+}
+
+func errMandatory(param string) *apiError {
+	return &apiError{
+		status:  http.StatusBadRequest,
+		Code:    -1102,
+		Message: fmt.Sprintf("Mandatory parameter '%s' was not sent, was empty/null, or malformed.", param),
+	}
+}
+
+func errMalformed(param string) *apiError {
+	return &apiError{status: http.StatusBadRequest, Code: -1100, Message: fmt.Sprintf("Illegal characters found in parameter '%s'.", param)}
+}
+
+func errInvalidSymbol() *apiError {
+	return &apiError{status: http.StatusBadRequest, Code: -1121, Message: "Invalid symbol."}
+}
+
+func errUnknownOrder() *apiError {
+	return &apiError{status: http.StatusBadRequest, Code: -2013, Message: "Order does not exist."}
+}
+
+func errInsufficientBalance() *apiError {
+	return &apiError{status: http.StatusBadRequest, Code: -2010, Message: "Account has insufficient balance for requested action."}
+}
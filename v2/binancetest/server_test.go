@@ -0,0 +1,303 @@
+package binancetest
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pooyakn/go-binance/v2"
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/pooyakn/go-binance/v2/futures"
+	"github.com/stretchr/testify/suite"
+)
+
+type serverTestSuite struct {
+	suite.Suite
+	srv     *Server
+	env     *binance.Environment
+	client  *binance.Client
+	futures *futures.Client
+}
+
+func TestServer(t *testing.T) {
+	suite.Run(t, new(serverTestSuite))
+}
+
+func (s *serverTestSuite) SetupTest() {
+	s.srv = NewServer()
+	s.env = &binance.Environment{
+		APIURL:      s.srv.URL,
+		WsURL:       s.srv.WsURL(Spot),
+		CombinedURL: s.srv.CombinedURL(Spot),
+	}
+	s.client = binance.NewClientWithEnvironment(s.srv.APIKey, s.srv.SecretKey, s.env)
+	s.futures = futures.NewClientWithEnvironment(s.srv.APIKey, s.srv.SecretKey, &futures.Environment{
+		APIURL:      s.srv.URL,
+		WsURL:       s.srv.WsURL(Futures),
+		CombinedURL: s.srv.CombinedURL(Futures),
+	})
+}
+
+func (s *serverTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+// waitSubscribers waits until a client subscribed to the stream
+func (s *serverTestSuite) waitSubscribers(m Market, stream string) {
+	s.Require().Eventually(func() bool {
+		return s.srv.Subscribers(m, stream) > 0
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (s *serverTestSuite) TestPublicEndpoints() {
+	ctx := context.Background()
+	s.Require().NoError(s.client.NewPingService().Do(ctx))
+	serverTime, err := s.client.NewServerTimeService().Do(ctx)
+	s.Require().NoError(err)
+	s.InDelta(time.Now().UnixNano()/int64(time.Millisecond), serverTime, 1000)
+
+	info, err := s.client.NewExchangeInfoService().Do(ctx)
+	s.Require().NoError(err)
+	s.Len(info.Symbols, 2)
+	s.Equal("BTC", info.Symbols[0].BaseAsset)
+
+	_, err = s.client.NewDepthService().Symbol("BTCBTC").Do(ctx)
+	s.True(errors.Is(err, common.ErrInvalidSymbol))
+}
+
+func (s *serverTestSuite) TestAuthentication() {
+	ctx := context.Background()
+	client := binance.NewClientWithEnvironment(s.srv.APIKey, "wrong", s.env)
+	_, err := client.NewGetAccountService().Do(ctx)
+	s.True(errors.Is(err, common.ErrInvalidSignature), err)
+
+	client = binance.NewClientWithEnvironment("wrong", s.srv.SecretKey, s.env)
+	_, err = client.NewGetAccountService().Do(ctx)
+	s.True(errors.Is(err, common.ErrInvalidAPIKey), err)
+
+	s.srv.ClockOffset = time.Minute
+	_, err = s.client.NewGetAccountService().Do(ctx)
+	s.True(errors.Is(err, common.ErrInvalidTimestamp), err)
+}
+
+func (s *serverTestSuite) TestSignerAuthentication() {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	s.Require().NoError(err)
+	rsaSigner := common.NewRSASigner(rsaKey)
+	ed25519Signer := common.NewEd25519Signer(ed25519Key)
+
+	ctx := context.Background()
+	for _, signer := range []common.Signer{rsaSigner, ed25519Signer} {
+		s.srv.Signer = signer
+		client := binance.NewClientWithEnvironment(s.srv.APIKey, "", s.env)
+		client.Signer = signer
+		_, err = client.NewGetAccountService().Do(ctx)
+		s.NoError(err)
+
+		client.Signer = common.NewHMACSigner(s.srv.SecretKey)
+		_, err = client.NewGetAccountService().Do(ctx)
+		s.True(errors.Is(err, common.ErrInvalidSignature), err)
+	}
+}
+
+func (s *serverTestSuite) TestSpotOrders() {
+	ctx := context.Background()
+	s.srv.SetBalance(Spot, "USDT", "1000")
+	_, err := s.srv.PlaceOrder(Spot, "BTCUSDT", "SELL", "100", "1")
+	s.Require().NoError(err)
+
+	res, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).
+		Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceTypeGTC).
+		Quantity("2").Price("101").Do(ctx)
+	s.Require().NoError(err)
+	s.Equal(binance.OrderStatusTypePartiallyFilled, res.Status)
+	s.Equal("1.00000000", res.ExecutedQuantity)
+	s.Require().Len(res.Fills, 1)
+	s.Equal("100.00000000", res.Fills[0].Price)
+
+	// the remaining quantity rests in the book, locking its quote amount
+	free, locked := s.srv.Balance(Spot, "USDT")
+	s.Equal("799.00000000", free)
+	s.Equal("101.00000000", locked)
+	free, _ = s.srv.Balance(Spot, "BTC")
+	s.Equal("1.00000000", free)
+
+	depth, err := s.client.NewDepthService().Symbol("BTCUSDT").Do(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(depth.Bids, 1)
+	s.Equal("101.00000000", depth.Bids[0].Price)
+	s.Empty(depth.Asks)
+
+	orders, err := s.client.NewListOpenOrdersService().Symbol("BTCUSDT").Do(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(orders, 1)
+	s.Equal(res.OrderID, orders[0].OrderID)
+
+	_, err = s.client.NewCancelOrderService().Symbol("BTCUSDT").OrderID(res.OrderID).Do(ctx)
+	s.Require().NoError(err)
+	free, locked = s.srv.Balance(Spot, "USDT")
+	s.Equal("900.00000000", free)
+	s.Equal("0.00000000", locked)
+
+	_, err = s.client.NewCancelOrderService().Symbol("BTCUSDT").OrderID(res.OrderID).Do(ctx)
+	s.True(errors.Is(err, common.ErrUnknownOrder), err)
+
+	_, err = s.client.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeSell).
+		Type(binance.OrderTypeMarket).Quantity("2").Do(ctx)
+	s.True(errors.Is(err, common.ErrInsufficientBalance), err)
+
+	account, err := s.client.NewGetAccountService().Do(ctx)
+	s.Require().NoError(err)
+	s.Equal([]binance.Balance{
+		{Asset: "BTC", Free: "1.00000000", Locked: "0.00000000"},
+		{Asset: "USDT", Free: "900.00000000", Locked: "0.00000000"},
+	}, account.Balances)
+}
+
+func (s *serverTestSuite) TestSpotQuoteMarketOrder() {
+	s.srv.SetBalance(Spot, "USDT", "1000")
+	_, err := s.srv.PlaceOrder(Spot, "BTCUSDT", "SELL", "100", "1")
+	s.Require().NoError(err)
+	_, err = s.srv.PlaceOrder(Spot, "BTCUSDT", "SELL", "200", "1")
+	s.Require().NoError(err)
+
+	res, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).
+		Type(binance.OrderTypeMarket).QuoteOrderQty("150").Do(context.Background())
+	s.Require().NoError(err)
+	s.Equal(binance.OrderStatusTypeFilled, res.Status)
+	s.Equal("1.25000000", res.ExecutedQuantity)
+	s.Equal("150.00000000", res.CummulativeQuoteQuantity)
+	s.Len(res.Fills, 2)
+}
+
+func (s *serverTestSuite) TestUserDataStream() {
+	ctx := context.Background()
+	s.srv.SetBalance(Spot, "BTC", "1")
+	listenKey, err := s.client.NewStartUserStreamService().Do(ctx)
+	s.Require().NoError(err)
+	s.Len(listenKey, 60)
+
+	events := make(chan *binance.WsUserDataEvent, 10)
+	doneC, stopC, err := s.client.Environment.WsUserDataServe(listenKey, func(event *binance.WsUserDataEvent) {
+		events <- event
+	}, func(err error) {})
+	s.Require().NoError(err)
+	s.waitSubscribers(Spot, listenKey)
+
+	res, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeSell).
+		Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceTypeGTC).
+		Quantity("1").Price("100").Do(ctx)
+	s.Require().NoError(err)
+	_, err = s.srv.PlaceOrder(Spot, "BTCUSDT", "BUY", "100", "1")
+	s.Require().NoError(err)
+
+	var updates []binance.WsOrderUpdate
+	for len(updates) < 2 {
+		select {
+		case event := <-events:
+			if event.Event == binance.UserDataEventTypeExecutionReport {
+				updates = append(updates, event.OrderUpdate)
+			}
+		case <-time.After(time.Second):
+			s.FailNow("timeout waiting for the execution reports")
+		}
+	}
+	s.Equal("NEW", updates[0].ExecutionType)
+	s.Equal(res.OrderID, updates[1].Id)
+	s.Equal("TRADE", updates[1].ExecutionType)
+	s.Equal("FILLED", updates[1].Status)
+	s.Equal("100.00000000", updates[1].LatestPrice)
+	s.True(updates[1].IsMaker)
+
+	s.Require().NoError(s.client.NewCloseUserStreamService().ListenKey(listenKey).Do(ctx))
+	select {
+	case <-doneC:
+	case <-time.After(time.Second):
+		close(stopC)
+		s.Fail("stream not closed with the listen key")
+	}
+}
+
+func (s *serverTestSuite) TestMarketStreams() {
+	trades := make(chan *binance.WsTradeEvent, 1)
+	_, stopC, err := s.client.Environment.WsTradeServe("BTCUSDT", func(event *binance.WsTradeEvent) {
+		trades <- event
+	}, func(err error) {})
+	s.Require().NoError(err)
+	defer close(stopC)
+	depths := make(chan *binance.WsDepthEvent, 2)
+	_, stopC2, err := s.client.Environment.WsCombinedDepthServe([]string{"BTCUSDT"}, func(event *binance.WsDepthEvent) {
+		depths <- event
+	}, func(err error) {})
+	s.Require().NoError(err)
+	defer close(stopC2)
+	s.waitSubscribers(Spot, "btcusdt@trade")
+	s.waitSubscribers(Spot, "btcusdt@depth")
+
+	_, err = s.srv.PlaceOrder(Spot, "BTCUSDT", "SELL", "100", "1")
+	s.Require().NoError(err)
+	_, err = s.srv.PlaceOrder(Spot, "BTCUSDT", "BUY", "100", "0.4")
+	s.Require().NoError(err)
+
+	trade := <-trades
+	s.Equal("100.00000000", trade.Price)
+	s.Equal("0.40000000", trade.Quantity)
+	first, second := <-depths, <-depths
+	s.Equal(int64(1), first.LastUpdateID)
+	s.Equal([]binance.Ask{{Price: "100.00000000", Quantity: "1.00000000"}}, first.Asks)
+	s.Equal(int64(2), second.FirstUpdateID)
+	s.Equal([]binance.Ask{{Price: "100.00000000", Quantity: "0.60000000"}}, second.Asks)
+}
+
+func (s *serverTestSuite) TestSubscribe() {
+	conn, _, err := websocket.DefaultDialer.Dial(s.srv.WsURL(Futures), nil)
+	s.Require().NoError(err)
+	defer conn.Close()
+	s.Require().NoError(conn.WriteJSON(map[string]interface{}{
+		"method": "SUBSCRIBE",
+		"params": []string{"btcusdt@markPrice"},
+		"id":     1,
+	}))
+	var res map[string]interface{}
+	s.Require().NoError(conn.ReadJSON(&res))
+	s.Equal(map[string]interface{}{"result": nil, "id": float64(1)}, res)
+
+	n, err := s.srv.Publish(Futures, "btcusdt@markPrice", map[string]interface{}{"e": "markPriceUpdate", "s": "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Equal(1, n)
+	s.Require().NoError(conn.ReadJSON(&res))
+	s.Equal("markPriceUpdate", res["e"])
+}
+
+func (s *serverTestSuite) TestFuturesOrders() {
+	ctx := context.Background()
+	s.srv.SetBalance(Futures, "USDT", "1000")
+	_, err := s.srv.PlaceOrder(Futures, "BTCUSDT", "BUY", "100", "1")
+	s.Require().NoError(err)
+
+	res, err := s.futures.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeSell).
+		Type(futures.OrderTypeMarket).Quantity("0.5").Do(ctx)
+	s.Require().NoError(err)
+	s.Equal(futures.OrderStatusTypeFilled, res.Status)
+	s.Equal("100.00000000", res.AvgPrice)
+
+	order, err := s.futures.NewGetOrderService().Symbol("BTCUSDT").OrderID(res.OrderID).Do(ctx)
+	s.Require().NoError(err)
+	s.Equal("0.50000000", order.ExecutedQuantity)
+
+	balances, err := s.futures.NewGetBalanceService().Do(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(balances, 1)
+	s.Equal("1000.00000000", balances[0].Balance)
+
+	listenKey, err := s.futures.NewStartUserStreamService().Do(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(s.futures.NewKeepaliveUserStreamService().ListenKey(listenKey).Do(ctx))
+}
@@ -0,0 +1,487 @@
+package binancetest
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func (s *Server) registerSpotRoutes() {
+	s.handle(http.MethodGet, "/api/v3/ping", secTypeNone, s.ping)
+	s.handle(http.MethodGet, "/api/v3/time", secTypeNone, s.serverTime)
+	s.handle(http.MethodGet, "/api/v3/exchangeInfo", secTypeNone, s.exchangeInfo)
+	s.handle(http.MethodGet, "/api/v3/depth", secTypeNone, s.depth)
+	s.handle(http.MethodGet, "/api/v3/ticker/price", secTypeNone, s.tickerPrice)
+	s.handle(http.MethodGet, "/api/v3/account", secTypeSigned, s.spotAccount)
+	s.handle(http.MethodPost, "/api/v3/order", secTypeSigned, s.createOrder)
+	s.handle(http.MethodPost, "/api/v3/order/test", secTypeSigned, s.testOrder)
+	s.handle(http.MethodGet, "/api/v3/order", secTypeSigned, s.getOrder)
+	s.handle(http.MethodDelete, "/api/v3/order", secTypeSigned, s.cancelOrder)
+	s.handle(http.MethodGet, "/api/v3/openOrders", secTypeSigned, s.openOrders)
+	s.handle(http.MethodDelete, "/api/v3/openOrders", secTypeSigned, s.cancelOpenOrders)
+	s.handle(http.MethodGet, "/api/v3/allOrders", secTypeSigned, s.allOrders)
+	s.handle(http.MethodPost, "/api/v3/userDataStream", secTypeAPIKey, s.startUserStream)
+	s.handle(http.MethodPut, "/api/v3/userDataStream", secTypeAPIKey, s.keepaliveUserStream)
+	s.handle(http.MethodDelete, "/api/v3/userDataStream", secTypeAPIKey, s.closeUserStream)
+}
+
+func (s *Server) ping(m *market, params url.Values) (interface{}, *apiError) {
+	return struct{}{}, nil
+}
+
+func (s *Server) serverTime(m *market, params url.Values) (interface{}, *apiError) {
+	return map[string]interface{}{"serverTime": s.nowMillis()}, nil
+}
+
+func (s *Server) exchangeInfo(m *market, params url.Values) (interface{}, *apiError) {
+	names := make([]string, 0, len(m.symbols))
+	for name := range m.symbols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	symbols := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		sym := m.symbols[name]
+		info := map[string]interface{}{
+			"symbol":     sym.name,
+			"status":     "TRADING",
+			"baseAsset":  sym.base,
+			"quoteAsset": sym.quote,
+			"filters":    []interface{}{},
+		}
+		if m.name == Spot {
+			info["orderTypes"] = []string{orderTypeLimit, orderTypeLimitMaker, orderTypeMarket}
+			info["quoteOrderQtyMarketAllowed"] = true
+			info["isSpotTradingAllowed"] = true
+			info["baseAssetPrecision"] = 8
+			info["quoteAssetPrecision"] = 8
+		} else {
+			info["pair"] = strings.Split(sym.name, "_")[0]
+			info["contractType"] = "PERPETUAL"
+			info["marginAsset"] = sym.quote
+			if m.name == Delivery {
+				info["marginAsset"] = sym.base
+			}
+			info["orderType"] = []string{orderTypeLimit, orderTypeMarket}
+			info["timeInForce"] = []string{timeInForceGTC, timeInForceIOC, timeInForceFOK, timeInForceGTX}
+			info["pricePrecision"] = 8
+			info["quantityPrecision"] = 8
+		}
+		symbols = append(symbols, info)
+	}
+	return map[string]interface{}{
+		"timezone":        "UTC",
+		"serverTime":      s.nowMillis(),
+		"rateLimits":      []interface{}{},
+		"exchangeFilters": []interface{}{},
+		"symbols":         symbols,
+	}, nil
+}
+
+func (s *Server) depth(m *market, params url.Values) (interface{}, *apiError) {
+	sym, apiErr := symbolParam(m, params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	limit := 100
+	if v := params.Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 {
+			return nil, errMalformed("limit")
+		}
+	}
+	res := map[string]interface{}{
+		"lastUpdateId": sym.updateID,
+		"bids":         nonNil(sym.depth(sideBuy, limit)),
+		"asks":         nonNil(sym.depth(sideSell, limit)),
+	}
+	if m.name != Spot {
+		res["E"] = s.nowMillis()
+		res["T"] = s.nowMillis()
+	}
+	return res, nil
+}
+
+func nonNil(levels [][2]string) [][2]string {
+	if levels == nil {
+		return [][2]string{}
+	}
+	return levels
+}
+
+func (s *Server) tickerPrice(m *market, params url.Values) (interface{}, *apiError) {
+	price := func(sym *symbol) map[string]interface{} {
+		return map[string]interface{}{"symbol": sym.name, "price": sym.lastPrice.String(), "time": s.nowMillis()}
+	}
+	if params.Get("symbol") != "" {
+		sym, apiErr := symbolParam(m, params)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		return price(sym), nil
+	}
+	names := make([]string, 0, len(m.symbols))
+	for name := range m.symbols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	res := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		res = append(res, price(m.symbols[name]))
+	}
+	return res, nil
+}
+
+func (s *Server) spotAccount(m *market, params url.Values) (interface{}, *apiError) {
+	assets := make([]string, 0, len(m.balances))
+	for asset := range m.balances {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+	balances := make([]map[string]interface{}, 0, len(assets))
+	for _, asset := range assets {
+		b := m.balances[asset]
+		balances = append(balances, map[string]interface{}{
+			"asset":  asset,
+			"free":   b.free.String(),
+			"locked": b.locked.String(),
+		})
+	}
+	return map[string]interface{}{
+		"makerCommission":  0,
+		"takerCommission":  0,
+		"buyerCommission":  0,
+		"sellerCommission": 0,
+		"canTrade":         true,
+		"canWithdraw":      true,
+		"canDeposit":       true,
+		"updateTime":       s.nowMillis(),
+		"accountType":      "SPOT",
+		"balances":         balances,
+		"permissions":      []string{"SPOT"},
+	}, nil
+}
+
+// orderParams parses the parameters of a new order, and returns the order and
+// the quote quantity of a market order
+func (s *Server) orderParams(m *market, params url.Values) (*symbol, *order, decimal, *apiError) {
+	sym, apiErr := symbolParam(m, params)
+	if apiErr != nil {
+		return nil, nil, 0, apiErr
+	}
+	side := params.Get("side")
+	if side != sideBuy && side != sideSell {
+		return nil, nil, 0, errMandatory("side")
+	}
+	orderType := params.Get("type")
+	if orderType == "" {
+		return nil, nil, 0, errMandatory("type")
+	}
+	timeInForce := params.Get("timeInForce")
+	if orderType == orderTypeLimit && timeInForce == "" {
+		return nil, nil, 0, errMandatory("timeInForce")
+	}
+	var price decimal
+	if orderType != orderTypeMarket {
+		var ok bool
+		if price, ok = parseDecimal(params.Get("price")); !ok || price == 0 {
+			return nil, nil, 0, errMandatory("price")
+		}
+	}
+	var qty, quoteQty decimal
+	if v := params.Get("quoteOrderQty"); v != "" && orderType == orderTypeMarket && m.name == Spot {
+		var ok bool
+		if quoteQty, ok = parseDecimal(v); !ok || quoteQty == 0 {
+			return nil, nil, 0, errMandatory("quoteOrderQty")
+		}
+	} else {
+		var ok bool
+		if qty, ok = parseDecimal(params.Get("quantity")); !ok || qty == 0 {
+			return nil, nil, 0, errMandatory("quantity")
+		}
+	}
+	clientOrderID := params.Get("newClientOrderId")
+	if clientOrderID != "" && sym.order(0, clientOrderID) != nil {
+		return nil, nil, 0, &apiError{status: http.StatusBadRequest, Code: -2010, Message: "Duplicate order sent."}
+	}
+	o := &order{
+		clientOrderID: clientOrderID,
+		symbol:        sym.name,
+		side:          side,
+		orderType:     orderType,
+		timeInForce:   timeInForce,
+		price:         price,
+		origQty:       qty,
+	}
+	return sym, o, quoteQty, nil
+}
+
+func (s *Server) createOrder(m *market, params url.Values) (interface{}, *apiError) {
+	sym, o, quoteQty, apiErr := s.orderParams(m, params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	e, apiErr := s.placeOrder(m, sym, o, quoteQty)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	s.publish(e)
+	if m.name != Spot {
+		return futuresOrderJSON(o), nil
+	}
+
+	res := spotOrderJSON(o)
+	res["transactTime"] = o.updateTime
+	respType := params.Get("newOrderRespType")
+	if respType == "" {
+		respType = "ACK"
+		if o.orderType == orderTypeLimit || o.orderType == orderTypeMarket {
+			respType = "FULL"
+		}
+	}
+	switch respType {
+	case "ACK":
+		return map[string]interface{}{
+			"symbol":        o.symbol,
+			"orderId":       o.id,
+			"orderListId":   -1,
+			"clientOrderId": o.clientOrderID,
+			"transactTime":  o.updateTime,
+		}, nil
+	case "FULL":
+		fills := make([]map[string]interface{}, 0, len(e.fills))
+		for _, f := range e.fills {
+			commissionAsset := sym.quote
+			if o.side == sideBuy {
+				commissionAsset = sym.base
+			}
+			fills = append(fills, map[string]interface{}{
+				"tradeId":         f.tradeID,
+				"price":           f.price.String(),
+				"qty":             f.qty.String(),
+				"commission":      decimal(0).String(),
+				"commissionAsset": commissionAsset,
+			})
+		}
+		res["fills"] = fills
+	}
+	return res, nil
+}
+
+func (s *Server) testOrder(m *market, params url.Values) (interface{}, *apiError) {
+	if _, _, _, apiErr := s.orderParams(m, params); apiErr != nil {
+		return nil, apiErr
+	}
+	return struct{}{}, nil
+}
+
+// findOrder returns the order of the orderId or origClientOrderId parameter
+func findOrder(m *market, params url.Values) (*symbol, *order, *apiError) {
+	sym, apiErr := symbolParam(m, params)
+	if apiErr != nil {
+		return nil, nil, apiErr
+	}
+	var id int64
+	if v := params.Get("orderId"); v != "" {
+		var err error
+		if id, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, nil, errMalformed("orderId")
+		}
+	}
+	clientOrderID := params.Get("origClientOrderId")
+	if id == 0 && clientOrderID == "" {
+		return nil, nil, &apiError{status: http.StatusBadRequest, Code: -1102, Message: "Param 'origClientOrderId' or 'orderId' must be sent, but both were empty/null!"}
+	}
+	o := sym.order(id, clientOrderID)
+	if o == nil || o.external {
+		return nil, nil, errUnknownOrder()
+	}
+	return sym, o, nil
+}
+
+func (s *Server) getOrder(m *market, params url.Values) (interface{}, *apiError) {
+	_, o, apiErr := findOrder(m, params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if m.name != Spot {
+		return futuresOrderJSON(o), nil
+	}
+	return spotOrderJSON(o), nil
+}
+
+func (s *Server) cancelOrder(m *market, params url.Values) (interface{}, *apiError) {
+	sym, o, apiErr := findOrder(m, params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if !isOpen(o) {
+		return nil, &apiError{status: http.StatusBadRequest, Code: -2011, Message: "Unknown order sent."}
+	}
+	e := newExecution(m, sym)
+	s.cancel(e, o)
+	s.publish(e)
+	if m.name != Spot {
+		return futuresOrderJSON(o), nil
+	}
+	res := spotOrderJSON(o)
+	res["origClientOrderId"] = o.clientOrderID
+	res["transactTime"] = o.updateTime
+	return res, nil
+}
+
+// listOrders returns the orders of the client, only the open ones unless all
+// is set, of the symbol parameter or of all symbols
+func (s *Server) listOrders(m *market, params url.Values, all bool) ([]*order, *apiError) {
+	var symbols []*symbol
+	if params.Get("symbol") != "" || all {
+		sym, apiErr := symbolParam(m, params)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		symbols = append(symbols, sym)
+	} else {
+		for _, sym := range m.symbols {
+			symbols = append(symbols, sym)
+		}
+	}
+	var orders []*order
+	for _, sym := range symbols {
+		for _, o := range sym.orders {
+			if !o.external && (all || isOpen(o)) {
+				orders = append(orders, o)
+			}
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].id < orders[j].id })
+	return orders, nil
+}
+
+func (s *Server) openOrders(m *market, params url.Values) (interface{}, *apiError) {
+	orders, apiErr := s.listOrders(m, params, false)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return ordersJSON(m, orders), nil
+}
+
+func (s *Server) allOrders(m *market, params url.Values) (interface{}, *apiError) {
+	orders, apiErr := s.listOrders(m, params, true)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return ordersJSON(m, orders), nil
+}
+
+func (s *Server) cancelOpenOrders(m *market, params url.Values) (interface{}, *apiError) {
+	sym, apiErr := symbolParam(m, params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	orders, _ := s.listOrders(m, params, false)
+	e := newExecution(m, sym)
+	for _, o := range orders {
+		s.cancel(e, o)
+	}
+	s.publish(e)
+	if m.name != Spot {
+		return map[string]interface{}{"code": 200, "msg": "The operation of cancel all open order is done."}, nil
+	}
+	return ordersJSON(m, orders), nil
+}
+
+func ordersJSON(m *market, orders []*order) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(orders))
+	for _, o := range orders {
+		if m.name != Spot {
+			res = append(res, futuresOrderJSON(o))
+		} else {
+			res = append(res, spotOrderJSON(o))
+		}
+	}
+	return res
+}
+
+func spotOrderJSON(o *order) map[string]interface{} {
+	return map[string]interface{}{
+		"symbol":              o.symbol,
+		"orderId":             o.id,
+		"orderListId":         -1,
+		"clientOrderId":       o.clientOrderID,
+		"price":               o.price.String(),
+		"origQty":             o.origQty.String(),
+		"executedQty":         o.executedQty.String(),
+		"cummulativeQuoteQty": o.cumQuote.String(),
+		"status":              o.status,
+		"timeInForce":         o.timeInForce,
+		"type":                o.orderType,
+		"side":                o.side,
+		"stopPrice":           decimal(0).String(),
+		"icebergQty":          decimal(0).String(),
+		"time":                o.time,
+		"updateTime":          o.updateTime,
+		"isWorking":           true,
+		"origQuoteOrderQty":   decimal(0).String(),
+	}
+}
+
+func (s *Server) startUserStream(m *market, params url.Values) (interface{}, *apiError) {
+	listenKey := randomListenKey()
+	s.listenKeys[listenKey] = m.name
+	return map[string]interface{}{"listenKey": listenKey}, nil
+}
+
+// listenKeyParam returns the listenKey parameter, futures listen keys may be
+// omitted as there is a single one per account
+func (s *Server) listenKeyParam(m *market, params url.Values) (string, *apiError) {
+	listenKey := params.Get("listenKey")
+	if listenKey == "" && m.name != Spot {
+		for k, market := range s.listenKeys {
+			if market == m.name {
+				listenKey = k
+			}
+		}
+	}
+	if listenKey == "" {
+		return "", errMandatory("listenKey")
+	}
+	if s.listenKeys[listenKey] != m.name {
+		return "", &apiError{status: http.StatusBadRequest, Code: -1125, Message: "This listenKey does not exist."}
+	}
+	return listenKey, nil
+}
+
+func (s *Server) keepaliveUserStream(m *market, params url.Values) (interface{}, *apiError) {
+	listenKey, apiErr := s.listenKeyParam(m, params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if m.name != Spot {
+		return map[string]interface{}{"listenKey": listenKey}, nil
+	}
+	return struct{}{}, nil
+}
+
+func (s *Server) closeUserStream(m *market, params url.Values) (interface{}, *apiError) {
+	listenKey, apiErr := s.listenKeyParam(m, params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	delete(s.listenKeys, listenKey)
+	s.streams.closeStream(m.name, listenKey)
+	return struct{}{}, nil
+}
+
+func symbolParam(m *market, params url.Values) (*symbol, *apiError) {
+	name := params.Get("symbol")
+	if name == "" {
+		return nil, errMandatory("symbol")
+	}
+	sym, ok := m.symbols[name]
+	if !ok {
+		return nil, errInvalidSymbol()
+	}
+	return sym, nil
+}
@@ -0,0 +1,618 @@
+package binancetest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin:       func(r *http.Request) bool { return true },
+	EnableCompression: true,
+}
+
+// sendBufferSize is the number of messages buffered per connection, slower
+// connections are closed like Binance does
+const sendBufferSize = 1024
+
+type wsConn struct {
+	conn     *websocket.Conn
+	market   Market
+	combined bool
+	send     chan []byte
+	done     chan struct{}
+	once     sync.Once
+	streams  map[string]bool
+}
+
+func (c *wsConn) close() {
+	c.once.Do(func() {
+		close(c.done)
+		c.conn.Close()
+	})
+}
+
+// writeLoop writes the messages sent to the connection until it is closed
+func (c *wsConn) writeLoop() {
+	for {
+		select {
+		case <-c.done:
+			return
+		case msg := <-c.send:
+			if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				c.close()
+				return
+			}
+		}
+	}
+}
+
+// hub dispatches the stream events to the websocket connections
+type hub struct {
+	mu    sync.Mutex
+	conns map[*wsConn]bool
+}
+
+func newHub() *hub {
+	return &hub{conns: make(map[*wsConn]bool)}
+}
+
+func (h *hub) add(c *wsConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.conns[c] = true
+}
+
+func (h *hub) remove(c *wsConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.conns, c)
+	c.close()
+}
+
+// broadcast sends the event to the connections subscribed to the stream
+func (h *hub) broadcast(m Market, stream string, data []byte) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := 0
+	for c := range h.conns {
+		if c.market != m || !c.streams[stream] {
+			continue
+		}
+		msg := data
+		if c.combined {
+			msg, _ = json.Marshal(struct {
+				Stream string          `json:"stream"`
+				Data   json.RawMessage `json:"data"`
+			}{stream, data})
+		}
+		select {
+		case c.send <- msg:
+			n++
+		default:
+			c.close()
+		}
+	}
+	return n
+}
+
+func (h *hub) subscribe(c *wsConn, streams []string, subscribe bool, maxStreams int) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if subscribe {
+		n := len(c.streams)
+		for _, stream := range streams {
+			if !c.streams[stream] {
+				n++
+			}
+		}
+		if n > maxStreams {
+			return fmt.Errorf("Invalid request: too many streams, at most %d are allowed", maxStreams)
+		}
+	}
+	for _, stream := range streams {
+		if subscribe {
+			c.streams[stream] = true
+		} else {
+			delete(c.streams, stream)
+		}
+	}
+	return nil
+}
+
+func (h *hub) setCombined(c *wsConn, combined bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	c.combined = combined
+}
+
+func (h *hub) isCombined(c *wsConn) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return c.combined
+}
+
+func (h *hub) subscriptions(c *wsConn) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	streams := make([]string, 0, len(c.streams))
+	for stream := range c.streams {
+		streams = append(streams, stream)
+	}
+	sort.Strings(streams)
+	return streams
+}
+
+func (h *hub) subscribers(m Market, stream string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := 0
+	for c := range h.conns {
+		if c.market == m && c.streams[stream] {
+			n++
+		}
+	}
+	return n
+}
+
+// closeStream closes the connections subscribed to the stream
+func (h *hub) closeStream(m Market, stream string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.conns {
+		if c.market == m && c.streams[stream] {
+			c.close()
+		}
+	}
+}
+
+func (h *hub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.conns {
+		c.close()
+	}
+}
+
+// serveWs serves the raw streams on /<market>/ws/<stream> and the combined
+// streams on /<market>/stream?streams=<stream>/<stream>. Streams can also be
+// subscribed with SUBSCRIBE requests once connected.
+func (s *Server) serveWs(w http.ResponseWriter, r *http.Request, m Market) {
+	var streams []string
+	combined := strings.HasPrefix(r.URL.Path, "/"+string(m)+"/stream")
+	if combined {
+		streams = strings.Split(r.URL.Query().Get("streams"), "/")
+	} else {
+		streams = strings.Split(strings.TrimPrefix(r.URL.Path, "/"+string(m)+"/ws"), "/")
+	}
+	n := 0
+	for _, stream := range streams {
+		if stream != "" {
+			n++
+		}
+	}
+	if n > s.maxStreams() {
+		http.Error(w, "too many streams", http.StatusBadRequest)
+		return
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	c := &wsConn{
+		conn:     conn,
+		market:   m,
+		combined: combined,
+		send:     make(chan []byte, sendBufferSize),
+		done:     make(chan struct{}),
+		streams:  make(map[string]bool),
+	}
+	for _, stream := range streams {
+		if stream != "" {
+			c.streams[stream] = true
+		}
+	}
+	s.streams.add(c)
+	defer s.streams.remove(c)
+
+	go c.writeLoop()
+
+	var received []time.Time
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if s.MessageRate > 0 {
+			// the requests of the last second
+			now := time.Now()
+			for len(received) > 0 && now.Sub(received[0]) >= time.Second {
+				received = received[1:]
+			}
+			received = append(received, now)
+			if len(received) > s.MessageRate {
+				return
+			}
+		}
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+			ID     json.RawMessage   `json:"id"`
+		}
+		res := map[string]interface{}{"result": nil}
+		if err := json.Unmarshal(message, &req); err != nil {
+			res = map[string]interface{}{"error": map[string]interface{}{"code": 3, "msg": "Invalid JSON"}}
+		} else if err := s.wsRequest(c, req.Method, req.Params, res); err != nil {
+			res = map[string]interface{}{"error": map[string]interface{}{"code": 2, "msg": err.Error()}}
+		}
+		if req.ID != nil {
+			res["id"] = req.ID
+		}
+		data, _ := json.Marshal(res)
+		select {
+		case c.send <- data:
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (s *Server) maxStreams() int {
+	if s.MaxStreams == 0 {
+		return 1024
+	}
+	return s.MaxStreams
+}
+
+// wsRequest handles a request sent on a stream connection, setting the result in res
+func (s *Server) wsRequest(c *wsConn, method string, params []json.RawMessage, res map[string]interface{}) error {
+	var streams []string
+	var property string
+	switch method {
+	case "SUBSCRIBE", "UNSUBSCRIBE":
+		for _, p := range params {
+			var stream string
+			if err := json.Unmarshal(p, &stream); err != nil {
+				return fmt.Errorf("Invalid request: invalid stream name %s", p)
+			}
+			streams = append(streams, stream)
+		}
+	case "SET_PROPERTY", "GET_PROPERTY":
+		if len(params) == 0 || json.Unmarshal(params[0], &property) != nil || property != "combined" {
+			return fmt.Errorf("Invalid request: unknown property")
+		}
+	}
+	switch method {
+	case "SUBSCRIBE":
+		return s.streams.subscribe(c, streams, true, s.maxStreams())
+	case "UNSUBSCRIBE":
+		return s.streams.subscribe(c, streams, false, s.maxStreams())
+	case "LIST_SUBSCRIPTIONS":
+		res["result"] = s.streams.subscriptions(c)
+	case "SET_PROPERTY":
+		var combined bool
+		if len(params) != 2 || json.Unmarshal(params[1], &combined) != nil {
+			return fmt.Errorf("Invalid request: property value must be a boolean")
+		}
+		s.streams.setCombined(c, combined)
+	case "GET_PROPERTY":
+		res["result"] = s.streams.isCombined(c)
+	default:
+		return fmt.Errorf("Invalid request: unknown method %q", method)
+	}
+	return nil
+}
+
+// Publish sends an event to the clients subscribed to the stream of the
+// market, e.g. "btcusdt@kline_1m", and returns the number of clients. The
+// event is marshaled to JSON, raw events can be passed as json.RawMessage.
+func (s *Server) Publish(m Market, stream string, event interface{}) (int, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+	return s.streams.broadcast(m, stream, data), nil
+}
+
+// Subscribers returns the number of clients subscribed to the stream of the market
+func (s *Server) Subscribers(m Market, stream string) int {
+	return s.streams.subscribers(m, stream)
+}
+
+// DisconnectStreams closes every websocket connection, as Binance does every
+// 24 hours, to test reconnections
+func (s *Server) DisconnectStreams() {
+	s.streams.closeAll()
+}
+
+// ExpireListenKeys expires the listen keys of the market, as Binance does
+// after 60 minutes without keepalive: a listenKeyExpired event is sent to
+// their streams, which are no longer updated, and the keys no longer exist.
+// It returns the number of expired keys.
+func (s *Server) ExpireListenKeys(m Market) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for listenKey, market := range s.listenKeys {
+		if market != m {
+			continue
+		}
+		data, _ := json.Marshal(map[string]interface{}{
+			"e":         "listenKeyExpired",
+			"E":         s.nowMillis(),
+			"listenKey": listenKey,
+		})
+		s.streams.broadcast(m, listenKey, data)
+		delete(s.listenKeys, listenKey)
+		n++
+	}
+	return n
+}
+
+const listenKeyAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+func randomListenKey() string {
+	b := make([]byte, 60)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(listenKeyAlphabet))))
+		if err != nil {
+			panic(err)
+		}
+		b[i] = listenKeyAlphabet[n.Int64()]
+	}
+	return string(b)
+}
+
+// publish sends the stream events of an execution
+func (s *Server) publish(e *execution) {
+	now := s.nowMillis()
+	m, sym := e.m.name, e.sym
+	stream := strings.ToLower(sym.name)
+
+	if len(e.levels[sideBuy])+len(e.levels[sideSell]) > 0 {
+		sym.updateID++
+		levels := func(side string) [][2]string {
+			prices := make([]decimal, 0, len(e.levels[side]))
+			for price := range e.levels[side] {
+				prices = append(prices, price)
+			}
+			sort.Slice(prices, func(i, j int) bool {
+				if side == sideBuy {
+					return prices[i] > prices[j]
+				}
+				return prices[i] < prices[j]
+			})
+			res := make([][2]string, 0, len(prices))
+			for _, price := range prices {
+				res = append(res, [2]string{price.String(), sym.level(side, price).String()})
+			}
+			return res
+		}
+		diff := map[string]interface{}{
+			"e": "depthUpdate",
+			"E": now,
+			"s": sym.name,
+			"U": sym.updateID,
+			"u": sym.updateID,
+			"b": levels(sideBuy),
+			"a": levels(sideSell),
+		}
+		speeds := []string{"", "@100ms"}
+		if m != Spot {
+			diff["T"] = now
+			diff["pu"] = sym.updateID - 1
+			speeds = append(speeds, "@250ms", "@500ms")
+		}
+		if m == Delivery {
+			diff["ps"] = strings.Split(sym.name, "_")[0]
+		}
+		data, _ := json.Marshal(diff)
+		for _, speed := range speeds {
+			s.streams.broadcast(m, stream+"@depth"+speed, data)
+		}
+		for _, n := range []int{5, 10, 20} {
+			partial := map[string]interface{}{
+				"lastUpdateId": sym.updateID,
+				"bids":         nonNil(sym.depth(sideBuy, n)),
+				"asks":         nonNil(sym.depth(sideSell, n)),
+			}
+			if m != Spot {
+				partial = map[string]interface{}{}
+				for k, v := range diff {
+					partial[k] = v
+				}
+				partial["b"] = nonNil(sym.depth(sideBuy, n))
+				partial["a"] = nonNil(sym.depth(sideSell, n))
+			}
+			data, _ := json.Marshal(partial)
+			for _, speed := range speeds {
+				s.streams.broadcast(m, fmt.Sprintf("%s@depth%d%s", stream, n, speed), data)
+			}
+		}
+	}
+
+	for _, f := range e.fills {
+		buyer, seller := f.taker, f.maker
+		if f.taker.side == sideSell {
+			buyer, seller = f.maker, f.taker
+		}
+		aggTrade := map[string]interface{}{
+			"e": "aggTrade",
+			"E": now,
+			"s": sym.name,
+			"a": f.tradeID,
+			"p": f.price.String(),
+			"q": f.qty.String(),
+			"f": f.tradeID,
+			"l": f.tradeID,
+			"T": now,
+			"m": buyer == f.maker,
+		}
+		if m == Spot {
+			aggTrade["M"] = true
+			data, _ := json.Marshal(map[string]interface{}{
+				"e": "trade",
+				"E": now,
+				"s": sym.name,
+				"t": f.tradeID,
+				"p": f.price.String(),
+				"q": f.qty.String(),
+				"b": buyer.id,
+				"a": seller.id,
+				"T": now,
+				"m": buyer == f.maker,
+				"M": true,
+			})
+			s.streams.broadcast(m, stream+"@trade", data)
+		}
+		data, _ := json.Marshal(aggTrade)
+		s.streams.broadcast(m, stream+"@aggTrade", data)
+	}
+
+	var events []interface{}
+	for _, u := range e.updates {
+		if m == Spot {
+			events = append(events, spotExecutionReport(sym, u, now))
+		} else {
+			events = append(events, futuresOrderTradeUpdate(sym, u, now))
+		}
+	}
+	if m == Spot && len(e.assets) > 0 {
+		assets := make([]string, 0, len(e.assets))
+		for asset := range e.assets {
+			assets = append(assets, asset)
+		}
+		sort.Strings(assets)
+		balances := make([]map[string]interface{}, 0, len(assets))
+		for _, asset := range assets {
+			b := e.m.balance(asset)
+			balances = append(balances, map[string]interface{}{"a": asset, "f": b.free.String(), "l": b.locked.String()})
+		}
+		events = append(events, map[string]interface{}{
+			"e": "outboundAccountPosition",
+			"E": now,
+			"u": now,
+			"B": balances,
+		})
+	}
+	for listenKey, market := range s.listenKeys {
+		if market != m {
+			continue
+		}
+		for _, event := range events {
+			data, _ := json.Marshal(event)
+			s.streams.broadcast(m, listenKey, data)
+		}
+	}
+}
+
+func spotExecutionReport(sym *symbol, u orderUpdate, now int64) map[string]interface{} {
+	o := u.order
+	lastQty, lastPrice, tradeID := decimal(0), decimal(0), int64(-1)
+	var commissionAsset interface{}
+	isMaker := false
+	if u.fill != nil {
+		lastQty, lastPrice, tradeID = u.fill.qty, u.fill.price, u.fill.tradeID
+		isMaker = u.fill.maker == o
+		commissionAsset = sym.quote
+		if o.side == sideBuy {
+			commissionAsset = sym.base
+		}
+	}
+	origClientOrderID := ""
+	if u.executionType == executionCanceled {
+		origClientOrderID = o.clientOrderID
+	}
+	return map[string]interface{}{
+		"e": "executionReport",
+		"E": now,
+		"s": o.symbol,
+		"c": o.clientOrderID,
+		"S": o.side,
+		"o": o.orderType,
+		"f": o.timeInForce,
+		"q": o.origQty.String(),
+		"p": o.price.String(),
+		"P": decimal(0).String(),
+		"F": decimal(0).String(),
+		"g": -1,
+		"C": origClientOrderID,
+		"x": u.executionType,
+		"X": o.status,
+		"r": "NONE",
+		"i": o.id,
+		"l": lastQty.String(),
+		"z": o.executedQty.String(),
+		"L": lastPrice.String(),
+		"n": decimal(0).String(),
+		"N": commissionAsset,
+		"T": o.updateTime,
+		"t": tradeID,
+		"w": isOpen(o),
+		"m": isMaker,
+		"M": false,
+		"O": o.time,
+		"Z": o.cumQuote.String(),
+		"Y": lastQty.mul(lastPrice).String(),
+		"Q": decimal(0).String(),
+	}
+}
+
+func futuresOrderTradeUpdate(sym *symbol, u orderUpdate, now int64) map[string]interface{} {
+	o := u.order
+	lastQty, lastPrice, tradeID := decimal(0), decimal(0), int64(0)
+	isMaker := false
+	if u.fill != nil {
+		lastQty, lastPrice, tradeID = u.fill.qty, u.fill.price, u.fill.tradeID
+		isMaker = u.fill.maker == o
+	}
+	timeInForce := o.timeInForce
+	if timeInForce == "" {
+		timeInForce = timeInForceGTC
+	}
+	return map[string]interface{}{
+		"e": "ORDER_TRADE_UPDATE",
+		"E": now,
+		"T": now,
+		"o": map[string]interface{}{
+			"s":  o.symbol,
+			"c":  o.clientOrderID,
+			"S":  o.side,
+			"o":  o.orderType,
+			"f":  timeInForce,
+			"q":  o.origQty.String(),
+			"p":  o.price.String(),
+			"ap": o.avgPrice().String(),
+			"sp": decimal(0).String(),
+			"x":  u.executionType,
+			"X":  o.status,
+			"i":  o.id,
+			"l":  lastQty.String(),
+			"z":  o.executedQty.String(),
+			"L":  lastPrice.String(),
+			"N":  sym.quote,
+			"n":  decimal(0).String(),
+			"T":  o.updateTime,
+			"t":  tradeID,
+			"b":  decimal(0).String(),
+			"a":  decimal(0).String(),
+			"m":  isMaker,
+			"R":  false,
+			"wt": "CONTRACT_PRICE",
+			"ot": o.orderType,
+			"ps": "BOTH",
+			"cp": false,
+			"AP": decimal(0).String(),
+			"cr": decimal(0).String(),
+			"rp": decimal(0).String(),
+		},
+	}
+}
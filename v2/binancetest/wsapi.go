@@ -0,0 +1,210 @@
+package binancetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// wsAPIMethods maps the WebSocket API methods of every market to the routes
+// of the REST endpoints they are equivalent to
+var wsAPIMethods = map[Market]map[string]string{
+	Spot: {
+		"ping":                 "GET /api/v3/ping",
+		"time":                 "GET /api/v3/time",
+		"exchangeInfo":         "GET /api/v3/exchangeInfo",
+		"depth":                "GET /api/v3/depth",
+		"ticker.price":         "GET /api/v3/ticker/price",
+		"order.place":          "POST /api/v3/order",
+		"order.test":           "POST /api/v3/order/test",
+		"order.status":         "GET /api/v3/order",
+		"order.cancel":         "DELETE /api/v3/order",
+		"openOrders.status":    "GET /api/v3/openOrders",
+		"openOrders.cancelAll": "DELETE /api/v3/openOrders",
+		"allOrders":            "GET /api/v3/allOrders",
+		"account.status":       "GET /api/v3/account",
+		"userDataStream.start": "POST /api/v3/userDataStream",
+		"userDataStream.ping":  "PUT /api/v3/userDataStream",
+		"userDataStream.stop":  "DELETE /api/v3/userDataStream",
+	},
+	Futures: {
+		"depth":                "GET /fapi/v1/depth",
+		"ticker.price":         "GET /fapi/v1/ticker/price",
+		"order.place":          "POST /fapi/v1/order",
+		"order.status":         "GET /fapi/v1/order",
+		"order.cancel":         "DELETE /fapi/v1/order",
+		"v2/account.status":    "GET /fapi/v2/account",
+		"v2/account.balance":   "GET /fapi/v2/balance",
+		"userDataStream.start": "POST /fapi/v1/listenKey",
+		"userDataStream.ping":  "PUT /fapi/v1/listenKey",
+		"userDataStream.stop":  "DELETE /fapi/v1/listenKey",
+	},
+}
+
+// wsAPIRequest is a request of the WebSocket API
+type wsAPIRequest struct {
+	ID     json.RawMessage            `json:"id"`
+	Method string                     `json:"method"`
+	Params map[string]json.RawMessage `json:"params"`
+}
+
+// wsAPISession is the state of a WebSocket API connection
+type wsAPISession struct {
+	mu       sync.Mutex
+	loggedOn bool
+	weight   int64
+}
+
+// serveWsAPI serves the WebSocket API of the market on /<market>/ws-api
+func (s *Server) serveWsAPI(w http.ResponseWriter, r *http.Request, m Market) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	// the connection is tracked without any stream, to be closed by DisconnectStreams
+	c := &wsConn{
+		conn:    conn,
+		market:  m,
+		send:    make(chan []byte, sendBufferSize),
+		done:    make(chan struct{}),
+		streams: make(map[string]bool),
+	}
+	s.streams.add(c)
+	defer s.streams.remove(c)
+	go c.writeLoop()
+
+	session := new(wsAPISession)
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req wsAPIRequest
+		var res map[string]interface{}
+		if err := json.Unmarshal(message, &req); err != nil {
+			res = wsAPIErrorResponse(nil, &apiError{status: http.StatusBadRequest, Code: -1102, Message: "Invalid JSON"})
+		} else {
+			res = s.wsAPIRequest(m, session, &req)
+		}
+		data, _ := json.Marshal(res)
+		select {
+		case c.send <- data:
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// wsAPIRequest handles a request and returns its response
+func (s *Server) wsAPIRequest(m Market, session *wsAPISession, req *wsAPIRequest) map[string]interface{} {
+	params := url.Values{}
+	for k, v := range req.Params {
+		var value interface{}
+		d := json.NewDecoder(bytes.NewReader(v))
+		d.UseNumber()
+		if err := d.Decode(&value); err != nil {
+			return wsAPIErrorResponse(req.ID, errMalformed(k))
+		}
+		params.Set(k, fmt.Sprint(value))
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.weight++
+	if req.Method == "session.logon" {
+		if apiErr := s.wsAPIAuthenticate(params, secTypeSigned, false); apiErr != nil {
+			return wsAPIErrorResponse(req.ID, apiErr)
+		}
+		session.loggedOn = true
+		return wsAPIResponse(req.ID, session, map[string]interface{}{"apiKey": s.APIKey, "serverTime": s.nowMillis()})
+	}
+	if req.Method == "session.logout" {
+		session.loggedOn = false
+		return wsAPIResponse(req.ID, session, map[string]interface{}{"apiKey": nil, "serverTime": s.nowMillis()})
+	}
+	key, ok := wsAPIMethods[m][req.Method]
+	rt, found := s.routes[key]
+	if !ok || !found {
+		return wsAPIErrorResponse(req.ID, &apiError{status: http.StatusBadRequest, Code: -1100, Message: fmt.Sprintf("Unknown method '%s'.", req.Method)})
+	}
+	if apiErr := s.wsAPIAuthenticate(params, rt.secType, session.loggedOn); apiErr != nil {
+		return wsAPIErrorResponse(req.ID, apiErr)
+	}
+	params.Del("apiKey")
+	params.Del("signature")
+
+	s.mu.Lock()
+	res, apiErr := rt.handler(s.markets[m], params)
+	s.mu.Unlock()
+	if apiErr != nil {
+		return wsAPIErrorResponse(req.ID, apiErr)
+	}
+	return wsAPIResponse(req.ID, session, res)
+}
+
+// wsAPIAuthenticate checks the API key, the timestamp and the signature of a
+// request, signed by its parameters sorted by name. Authenticated sessions
+// need neither the API key nor the signature.
+func (s *Server) wsAPIAuthenticate(params url.Values, sec secType, loggedOn bool) *apiError {
+	if sec == secTypeNone {
+		return nil
+	}
+	if loggedOn && params.Get("apiKey") == "" {
+		if sec == secTypeSigned {
+			return s.checkTimestamp(params)
+		}
+		return nil
+	}
+	if params.Get("apiKey") != s.APIKey {
+		return &apiError{status: http.StatusUnauthorized, Code: -2015, Message: "Invalid API-key, IP, or permissions for action."}
+	}
+	if sec != secTypeSigned {
+		return nil
+	}
+	if apiErr := s.checkTimestamp(params); apiErr != nil {
+		return apiErr
+	}
+	signature := params.Get("signature")
+	if signature == "" {
+		return errMandatory("signature")
+	}
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		if k != "signature" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + params.Get(k)
+	}
+	return s.checkSignature(strings.Join(pairs, "&"), signature)
+}
+
+func wsAPIResponse(id json.RawMessage, session *wsAPISession, result interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"status": http.StatusOK,
+		"result": result,
+		"rateLimits": []map[string]interface{}{{
+			"rateLimitType": "REQUEST_WEIGHT",
+			"interval":      "MINUTE",
+			"intervalNum":   1,
+			"limit":         6000,
+			"count":         session.weight,
+		}},
+	}
+}
+
+func wsAPIErrorResponse(id json.RawMessage, e *apiError) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"status": e.status,
+		"error":  e,
+	}
+}
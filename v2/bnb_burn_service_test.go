@@ -62,7 +62,7 @@ func (s *toggleBNBBurnServiceTestSuite) TestToggleBNBBurn() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			`spotBNBBurn`:     true,
 			`interestBNBBurn`: false,
 		})
@@ -18,13 +18,13 @@ func (c *CreateSubAccountService) Tag(v string) *CreateSubAccountService {
 
 func (c *CreateSubAccountService) Do(ctx context.Context) (*CreateSubAccountResponse, error) {
 	r := &request{
-		method:   "POST",
-		endpoint: "/sapi/v1/broker/subAccount",
-		secType:  secTypeSigned,
+		Method:   "POST",
+		Endpoint: "/sapi/v1/broker/subAccount",
+		SecType:  secTypeSigned,
 	}
 
 	if c.tag != nil {
-		r.setParam("tag", *c.tag)
+		r.SetParam("tag", *c.tag)
 	}
 
 	data, err := c.c.callAPI(ctx, r)
@@ -81,20 +81,20 @@ func (c *CreateApiKeyForSubAccountService) FuturesTrade(v bool) *CreateApiKeyFor
 
 func (c *CreateApiKeyForSubAccountService) Do(ctx context.Context) (*CreateApiKeyForSubAccountResponse, error) {
 	r := &request{
-		method:   "POST",
-		endpoint: "/sapi/v1/broker/subAccountApi",
-		secType:  secTypeSigned,
+		Method:   "POST",
+		Endpoint: "/sapi/v1/broker/subAccountApi",
+		SecType:  secTypeSigned,
 	}
 
-	r.setParam("subAccountId", c.subAccountID)
-	r.setParam("canTrade", c.canTrade)
+	r.SetParam("subAccountId", c.subAccountID)
+	r.SetParam("canTrade", c.canTrade)
 
 	if c.marginTrade != nil {
-		r.setParam("marginTrade", *c.marginTrade)
+		r.SetParam("marginTrade", *c.marginTrade)
 	}
 
 	if c.futuresTrade != nil {
-		r.setParam("futuresTrade", c.futuresTrade)
+		r.SetParam("futuresTrade", c.futuresTrade)
 	}
 
 	data, err := c.c.callAPI(ctx, r)
@@ -156,24 +156,24 @@ func (s *SubAccountTransferService) Amount(v string) *SubAccountTransferService
 
 func (s *SubAccountTransferService) Do(ctx context.Context) (*SubAccountTransferResponse, error) {
 	r := &request{
-		method:   "POST",
-		endpoint: "/sapi/v1/broker/transfer",
-		secType:  secTypeSigned,
+		Method:   "POST",
+		Endpoint: "/sapi/v1/broker/transfer",
+		SecType:  secTypeSigned,
 	}
 
-	r.setParam("asset", s.asset)
-	r.setParam("amount", s.amount)
+	r.SetParam("asset", s.asset)
+	r.SetParam("amount", s.amount)
 
 	if s.fromID != nil {
-		r.setParam("fromId", *s.fromID)
+		r.SetParam("fromId", *s.fromID)
 	}
 
 	if s.toID != nil {
-		r.setParam("toId", *s.toID)
+		r.SetParam("toId", *s.toID)
 	}
 
 	if s.clientTranId != nil {
-		r.setParam("clientTranId", *s.clientTranId)
+		r.SetParam("clientTranId", *s.clientTranId)
 	}
 
 	data, err := s.c.callAPI(ctx, r)
@@ -243,37 +243,37 @@ func (g *GetSubAccountDepositHistoryService) Offest(v int) *GetSubAccountDeposit
 
 func (g *GetSubAccountDepositHistoryService) Do(ctx context.Context) ([]*GetSubAccountDepositHistoryResponse, error) {
 	r := &request{
-		method:   "GET",
-		endpoint: "/sapi/v1/broker/subAccount/depositHist",
-		secType:  secTypeSigned,
+		Method:   "GET",
+		Endpoint: "/sapi/v1/broker/subAccount/depositHist",
+		SecType:  secTypeSigned,
 	}
 
 	if g.subAccountID != nil {
-		r.setParam("subaccountId", *g.subAccountID)
+		r.SetParam("subaccountId", *g.subAccountID)
 	}
 
 	if g.coin != nil {
-		r.setParam("coin", *g.coin)
+		r.SetParam("coin", *g.coin)
 	}
 
 	if g.status != nil {
-		r.setParam("status", *g.status)
+		r.SetParam("status", *g.status)
 	}
 
 	if g.startTime != nil {
-		r.setParam("startTime", *g.startTime)
+		r.SetParam("startTime", *g.startTime)
 	}
 
 	if g.endTime != nil {
-		r.setParam("endTime", *g.endTime)
+		r.SetParam("endTime", *g.endTime)
 	}
 
 	if g.limit != nil {
-		r.setParam("limit", *g.limit)
+		r.SetParam("limit", *g.limit)
 	}
 
 	if g.offest != nil {
-		r.setParam("offest", *g.offest)
+		r.SetParam("offest", *g.offest)
 	}
 
 	data, err := g.c.callAPI(ctx, r)
@@ -358,41 +358,41 @@ func (g *GetSubAccountTransferHistoryService) Limit(v int) *GetSubAccountTransfe
 
 func (g *GetSubAccountTransferHistoryService) Do(ctx context.Context) ([]*GetSubAccountTransferHistoryResponse, error) {
 	r := &request{
-		method:   "GET",
-		endpoint: "/sapi/v1/broker/transfer",
-		secType:  secTypeSigned,
+		Method:   "GET",
+		Endpoint: "/sapi/v1/broker/transfer",
+		SecType:  secTypeSigned,
 	}
 
 	if g.fromID != nil {
-		r.setParam("fromId", *g.fromID)
+		r.SetParam("fromId", *g.fromID)
 	}
 
 	if g.toID != nil {
-		r.setParam("toId", *g.toID)
+		r.SetParam("toId", *g.toID)
 	}
 
 	if g.clientTranID != nil {
-		r.setParam("clientTranId", *g.clientTranID)
+		r.SetParam("clientTranId", *g.clientTranID)
 	}
 
 	if g.showAllStatus != nil {
-		r.setParam("showAllStatus", *g.showAllStatus)
+		r.SetParam("showAllStatus", *g.showAllStatus)
 	}
 
 	if g.startTime != nil {
-		r.setParam("startTime", *g.startTime)
+		r.SetParam("startTime", *g.startTime)
 	}
 
 	if g.endTime != nil {
-		r.setParam("endTime", *g.endTime)
+		r.SetParam("endTime", *g.endTime)
 	}
 
 	if g.limit != nil {
-		r.setParam("limit", *g.limit)
+		r.SetParam("limit", *g.limit)
 	}
 
 	if g.page != nil {
-		r.setParam("page", *g.page)
+		r.SetParam("page", *g.page)
 	}
 
 	data, err := g.c.callAPI(ctx, r)
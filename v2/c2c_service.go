@@ -48,22 +48,22 @@ func (s *C2CTradeHistoryService) Rows(rows int32) *C2CTradeHistoryService {
 // Do send request
 func (s *C2CTradeHistoryService) Do(ctx context.Context, opts ...RequestOption) (*C2CTradeHistory, error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/c2c/orderMatch/listUserOrderHistory",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/c2c/orderMatch/listUserOrderHistory",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("tradeType", s.tradeType)
+	r.SetParam("tradeType", s.tradeType)
 	if s.startTimestamp != nil {
-		r.setParam("startTimestamp", *s.startTimestamp)
+		r.SetParam("startTimestamp", *s.startTimestamp)
 	}
 	if s.endTimestamp != nil {
-		r.setParam("endTime", *s.endTimestamp)
+		r.SetParam("endTime", *s.endTimestamp)
 	}
 	if s.page != nil {
-		r.setParam("page", *s.page)
+		r.SetParam("page", *s.page)
 	}
 	if s.rows != nil {
-		r.setParam("rows", *s.rows)
+		r.SetParam("rows", *s.rows)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -44,7 +44,7 @@ func (s *c2cServiceTestSuite) TestC2CTradeHistory() {
 
 	tradeType := SideTypeSell
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"tradeType": tradeType,
 		})
 		s.assertRequestEqual(e, r)
@@ -1,13 +1,8 @@
 package binance
 
 import (
-	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
 	"crypto/tls"
-	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"net/url"
@@ -19,6 +14,8 @@ import (
 	"github.com/pooyakn/go-binance/v2/common"
 	"github.com/pooyakn/go-binance/v2/delivery"
 	"github.com/pooyakn/go-binance/v2/futures"
+	"github.com/pooyakn/go-binance/v2/internal/transport"
+	"github.com/pooyakn/go-binance/v2/internal/wsconn"
 )
 
 // SideType define side type of order
@@ -165,6 +162,7 @@ const (
 	UserDataEventTypeBalanceUpdate           UserDataEventType = "balanceUpdate"
 	UserDataEventTypeExecutionReport         UserDataEventType = "executionReport"
 	UserDataEventTypeListStatus              UserDataEventType = "ListStatus"
+	UserDataEventTypeListenKeyExpired        UserDataEventType = "listenKeyExpired"
 
 	MarginTransferTypeToMargin MarginTransferType = 1
 	MarginTransferTypeToMain   MarginTransferType = 2
@@ -260,25 +258,19 @@ func newJSON(data []byte) (j *simplejson.Json, err error) {
 	return j, nil
 }
 
-// getAPIEndpoint return the base endpoint of the Rest API according the UseTestnet flag
-func getAPIEndpoint() string {
-	if UseTestnet {
-		return baseAPITestnetURL
-	}
-	return baseAPIMainURL
-}
-
 // NewClient initialize an API client instance with API key and secret key.
 // You should always call this function before using this SDK.
 // Services will be created by the form client.NewXXXService().
 func NewClient(apiKey, secretKey string) *Client {
+	env := DefaultEnvironment()
 	return &Client{
-		APIKey:     apiKey,
-		SecretKey:  secretKey,
-		BaseURL:    getAPIEndpoint(),
-		UserAgent:  "Binance/golang",
-		HTTPClient: http.DefaultClient,
-		Logger:     log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+		APIKey:      apiKey,
+		SecretKey:   secretKey,
+		BaseURL:     env.APIURL,
+		UserAgent:   "Binance/golang",
+		HTTPClient:  http.DefaultClient,
+		Logger:      log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+		Environment: env,
 	}
 }
 
@@ -286,13 +278,15 @@ func NewClient(apiKey, secretKey string) *Client {
 // You should always call this function before using this SDK.
 // Services will be created by the form client.NewXXXService().
 func NewCustomClient(apiKey, secretKey string, httpClient *http.Client) *Client {
+	env := DefaultEnvironment()
 	return &Client{
-		APIKey:     apiKey,
-		SecretKey:  secretKey,
-		BaseURL:    getAPIEndpoint(),
-		UserAgent:  "Binance/golang",
-		HTTPClient: httpClient,
-		Logger:     log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+		APIKey:      apiKey,
+		SecretKey:   secretKey,
+		BaseURL:     env.APIURL,
+		UserAgent:   "Binance/golang",
+		HTTPClient:  httpClient,
+		Logger:      log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+		Environment: env,
 	}
 }
 
@@ -306,15 +300,18 @@ func NewProxiedClient(apiKey, secretKey, proxyUrl string) *Client {
 		Proxy:           http.ProxyURL(proxy),
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 	}
+	env := DefaultEnvironment()
+	env.Proxy = http.ProxyURL(proxy)
 	return &Client{
 		APIKey:    apiKey,
 		SecretKey: secretKey,
-		BaseURL:   getAPIEndpoint(),
+		BaseURL:   env.APIURL,
 		UserAgent: "Binance/golang",
 		HTTPClient: &http.Client{
 			Transport: tr,
 		},
-		Logger: log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+		Logger:      log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+		Environment: env,
 	}
 }
 
@@ -340,118 +337,57 @@ type Client struct {
 	Debug      bool
 	Logger     *log.Logger
 	TimeOffset int64
-	do         doFunc
-}
-
-func (c *Client) debug(format string, v ...interface{}) {
-	if c.Debug {
-		c.Logger.Printf(format, v...)
+	// StructuredLogger is used instead of Logger when set, e.g. a *slog.Logger
+	StructuredLogger common.Logger
+	// Signer signs SIGNED requests, HMAC-SHA256 with SecretKey is used when nil
+	Signer common.Signer
+	// RateLimiter tracks the used weight and order count, no limit is enforced when nil
+	RateLimiter *common.RateLimiter
+	// RetryPolicy define how failed requests are retried, requests are sent once when nil
+	RetryPolicy *common.RetryPolicy
+	// TimeSync measures the offset used for SIGNED requests instead of TimeOffset when set
+	TimeSync *common.TimeSync
+	// Middlewares intercept every request, BeforeSend hooks are called in order
+	// and AfterReceive hooks in reverse order
+	Middlewares []common.Middleware
+	// Environment is used by the websocket streams started from the client
+	Environment *Environment
+	do          doFunc
+	// wsAPI is the WebSocket API connection of the clients of NewWsAPIClient
+	wsAPI *wsconn.APIConn
+}
+
+// transport returns the shared REST transport configured with the current client settings
+func (c *Client) transport() *transport.Client {
+	return &transport.Client{
+		APIKey:      c.APIKey,
+		SecretKey:   c.SecretKey,
+		BaseURL:     c.BaseURL,
+		UserAgent:   c.UserAgent,
+		HTTPClient:  c.HTTPClient,
+		Debug:       c.Debug,
+		Logger:      c.StructuredLogger,
+		StdLogger:   c.Logger,
+		TimeOffset:  c.TimeOffset,
+		Signer:      c.Signer,
+		RateLimiter: c.RateLimiter,
+		RetryPolicy: c.RetryPolicy,
+		Middlewares: c.Middlewares,
+		TimeSync:    c.TimeSync,
+		Do:          transport.DoFunc(c.do),
 	}
 }
 
 func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
-	// set request options from user
-	for _, opt := range opts {
-		opt(r)
-	}
-	err = r.validate()
-	if err != nil {
-		return err
-	}
-
-	fullURL := fmt.Sprintf("%s%s", c.BaseURL, r.endpoint)
-	if r.recvWindow > 0 {
-		r.setParam(recvWindowKey, r.recvWindow)
-	}
-	if r.secType == secTypeSigned {
-		r.setParam(timestampKey, currentTimestamp()-c.TimeOffset)
-	}
-	queryString := r.query.Encode()
-	body := &bytes.Buffer{}
-	bodyString := r.form.Encode()
-	header := http.Header{}
-	if r.header != nil {
-		header = r.header.Clone()
-	}
-	if bodyString != "" {
-		header.Set("Content-Type", "application/x-www-form-urlencoded")
-		body = bytes.NewBufferString(bodyString)
-	}
-	if r.secType == secTypeAPIKey || r.secType == secTypeSigned {
-		header.Set("X-MBX-APIKEY", c.APIKey)
-	}
-
-	if r.secType == secTypeSigned {
-		raw := fmt.Sprintf("%s%s", queryString, bodyString)
-		mac := hmac.New(sha256.New, []byte(c.SecretKey))
-		_, err = mac.Write([]byte(raw))
-		if err != nil {
-			return err
-		}
-		v := url.Values{}
-		v.Set(signatureKey, fmt.Sprintf("%x", (mac.Sum(nil))))
-		if queryString == "" {
-			queryString = v.Encode()
-		} else {
-			queryString = fmt.Sprintf("%s&%s", queryString, v.Encode())
-		}
-	}
-	if queryString != "" {
-		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
-	}
-	c.debug("full url: %s, body: %s", fullURL, bodyString)
-
-	r.fullURL = fullURL
-	r.header = header
-	r.body = body
-	return nil
+	return c.transport().ParseRequest(r, opts...)
 }
 
 func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, err error) {
-	err = c.parseRequest(r, opts...)
-	if err != nil {
-		return []byte{}, err
-	}
-	req, err := http.NewRequest(r.method, r.fullURL, r.body)
-	if err != nil {
-		return []byte{}, err
-	}
-	req = req.WithContext(ctx)
-	req.Header = r.header
-	c.debug("request: %#v", req)
-	f := c.do
-	if f == nil {
-		f = c.HTTPClient.Do
-	}
-	res, err := f(req)
-	if err != nil {
-		return []byte{}, err
-	}
-	data, err = io.ReadAll(res.Body)
-	if err != nil {
-		return []byte{}, err
-	}
-	defer func() {
-		cerr := res.Body.Close()
-		// Only overwrite the retured error if the original error was nil and an
-		// error occurred while closing the body.
-		if err == nil && cerr != nil {
-			err = cerr
-		}
-	}()
-	c.debug("response: %#v", res)
-	c.debug("response body: %s", string(data))
-	c.debug("response status code: %d", res.StatusCode)
-
-	if res.StatusCode >= http.StatusBadRequest {
-		apiErr := new(common.APIError)
-		e := json.Unmarshal(data, apiErr)
-		if e != nil {
-			c.debug("failed to unmarshal json: %s", e)
-		}
-		return nil, apiErr
+	if c.wsAPI != nil {
+		return c.transport().CallWsAPI(ctx, c.wsAPI, wsAPIMethods, r, opts...)
 	}
-	return data, nil
+	data, _, err = c.transport().CallAPI(ctx, r, opts...)
+	return data, err
 }
 
 // SetApiEndpoint set api Endpoint
@@ -0,0 +1,143 @@
+package common
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// OverflowPolicy define what a dispatched stream does with a message once its queue is full
+type OverflowPolicy string
+
+// Overflow policies
+const (
+	// OverflowBlock waits for room in the queue, which delays the reads of the connection
+	OverflowBlock OverflowPolicy = "BLOCK"
+	// OverflowDropOldest drops the oldest queued message
+	OverflowDropOldest OverflowPolicy = "DROP_OLDEST"
+	// OverflowDropNewest drops the received message
+	OverflowDropNewest OverflowPolicy = "DROP_NEWEST"
+	// OverflowCoalesce replaces the queued message of the same stream and
+	// symbol by the received one, whether the queue is full or not, and drops
+	// the oldest message once full. It suits the streams whose events
+	// supersede the previous ones, e.g. tickers and partial depths, but not
+	// the diff depth streams.
+	OverflowCoalesce OverflowPolicy = "COALESCE"
+)
+
+// DispatchPolicy define how the messages of a websocket stream are passed to
+// its handler: the read goroutine queues them, and another goroutine calls
+// the handler, so that a slow handler does not delay the reads until Binance
+// drops the connection
+type DispatchPolicy struct {
+	// QueueSize is the maximum number of queued messages of a stream, 1024 when 0
+	QueueSize int
+	// Overflow is the policy once the queue is full, OverflowBlock when empty
+	Overflow OverflowPolicy
+	// CoalesceKey returns the key of a message for OverflowCoalesce, the
+	// symbol of the event by default. Messages without key are not coalesced.
+	CoalesceKey func(message []byte) string
+	// Metrics collects the counters of the streams when set, it may be shared
+	// by several streams
+	Metrics *DispatchMetrics
+}
+
+// DispatchStats are the counters of a dispatched stream
+type DispatchStats struct {
+	// Queued is the number of messages waiting for the handler
+	Queued int
+	// Received is the number of messages received
+	Received int64
+	// Dropped is the number of messages dropped once the queue was full
+	Dropped int64
+	// Coalesced is the number of messages replaced by a newer one
+	Coalesced int64
+	// Handled is the number of messages passed to the handler
+	Handled int64
+	// HandlerLatency is the total time spent in the handler
+	HandlerLatency time.Duration
+	// MaxHandlerLatency is the longest call of the handler
+	MaxHandlerLatency time.Duration
+	// QueueDelay is the time the last handled message waited in the queue,
+	// i.e. how far behind the handler is
+	QueueDelay time.Duration
+}
+
+// AvgHandlerLatency returns the average time spent in the handler
+func (s DispatchStats) AvgHandlerLatency() time.Duration {
+	if s.Handled == 0 {
+		return 0
+	}
+	return s.HandlerLatency / time.Duration(s.Handled)
+}
+
+// DispatchMetrics collects the counters of dispatched streams by stream name,
+// it is safe for concurrent use
+type DispatchMetrics struct {
+	mu      sync.Mutex
+	streams map[string]*DispatchStats
+}
+
+// NewDispatchMetrics init empty dispatch metrics
+func NewDispatchMetrics() *DispatchMetrics {
+	return &DispatchMetrics{streams: make(map[string]*DispatchStats)}
+}
+
+// Update applies f to the counters of the stream, it is called by the
+// dispatched streams
+func (m *DispatchMetrics) Update(stream string, f func(stats *DispatchStats)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats, ok := m.streams[stream]
+	if !ok {
+		stats = new(DispatchStats)
+		m.streams[stream] = stats
+	}
+	f(stats)
+}
+
+// Stream returns the counters of the stream, false when it is unknown
+func (m *DispatchMetrics) Stream(stream string) (DispatchStats, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats, ok := m.streams[stream]
+	if !ok {
+		return DispatchStats{}, false
+	}
+	return *stats, true
+}
+
+// Streams returns the names of the streams, sorted
+func (m *DispatchMetrics) Streams() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	streams := make([]string, 0, len(m.streams))
+	for stream := range m.streams {
+		streams = append(streams, stream)
+	}
+	sort.Strings(streams)
+	return streams
+}
+
+// Total returns the sum of the counters of every stream, with the longest
+// handler latency and queue delay
+func (m *DispatchMetrics) Total() DispatchStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var total DispatchStats
+	for _, s := range m.streams {
+		total.Queued += s.Queued
+		total.Received += s.Received
+		total.Dropped += s.Dropped
+		total.Coalesced += s.Coalesced
+		total.Handled += s.Handled
+		total.HandlerLatency += s.HandlerLatency
+		if s.MaxHandlerLatency > total.MaxHandlerLatency {
+			total.MaxHandlerLatency = s.MaxHandlerLatency
+		}
+		if s.QueueDelay > total.QueueDelay {
+			total.QueueDelay = s.QueueDelay
+		}
+	}
+	return total
+}
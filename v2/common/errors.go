@@ -1,13 +1,23 @@
 package common
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 )
 
 // APIError define API error when response status is 4xx or 5xx
 type APIError struct {
 	Code    int64  `json:"code"`
 	Message string `json:"msg"`
+	// StatusCode is the HTTP status code of the response
+	StatusCode int `json:"-"`
+	// Method and Endpoint identify the request which failed
+	Method   string `json:"-"`
+	Endpoint string `json:"-"`
+	// Header is the header of the response, carrying the rate limit usage
+	Header http.Header `json:"-"`
 }
 
 // Error return error code and message
@@ -15,8 +25,139 @@ func (e APIError) Error() string {
 	return fmt.Sprintf("<APIError> code=%d, msg=%s", e.Code, e.Message)
 }
 
-// IsAPIError check if e is an API error
+// Is reports whether the error belongs to the class of target, an ErrorClass
+// such as ErrInsufficientBalance, or has the same code as target, an *APIError
+func (e *APIError) Is(target error) bool {
+	switch t := target.(type) {
+	case *ErrorClass:
+		return t.match(e)
+	case *APIError:
+		return t.Code == e.Code
+	}
+	return false
+}
+
+// UsedWeight returns the X-MBX-USED-WEIGHT-1M header of the response, empty if missing
+func (e *APIError) UsedWeight() string {
+	return e.Header.Get(usedWeightHeaderPrefix + "1M")
+}
+
+// RetryAfter returns the Retry-After header of the response, empty if missing
+func (e *APIError) RetryAfter() string {
+	return e.Header.Get("Retry-After")
+}
+
+// IsAPIError check if e is an API error, or wraps one
 func IsAPIError(e error) bool {
-	_, ok := e.(*APIError)
-	return ok
+	var apiErr *APIError
+	return errors.As(e, &apiErr)
+}
+
+// ErrorClass define a class of API errors, matched with errors.Is:
+//
+//	if errors.Is(err, common.ErrInsufficientBalance) {
+//		...
+//	}
+//
+// An error belongs to a class when its code is one of the codes of the class,
+// or when it has one of its codes and its message contains one of its
+// messages, or when its HTTP status code is one of the status codes of the class.
+type ErrorClass struct {
+	name        string
+	codes       []int64
+	messages    map[int64][]string
+	statusCodes []int
 }
+
+// Error return the name of the class
+func (c *ErrorClass) Error() string {
+	return c.name
+}
+
+func (c *ErrorClass) match(e *APIError) bool {
+	for _, code := range c.codes {
+		if code == e.Code {
+			return true
+		}
+	}
+	msg := strings.ToLower(e.Message)
+	for _, m := range c.messages[e.Code] {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	for _, statusCode := range c.statusCodes {
+		if statusCode == e.StatusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// Catalogue of the API error classes
+var (
+	ErrUnknown = &ErrorClass{name: "unknown error", codes: []int64{-1000}}
+
+	ErrDisconnected = &ErrorClass{name: "internal error", codes: []int64{-1001}}
+
+	ErrUnauthorized = &ErrorClass{name: "unauthorized", codes: []int64{-1002}}
+
+	ErrTooManyRequests = &ErrorClass{
+		name:        "too many requests",
+		codes:       []int64{-1003},
+		statusCodes: []int{http.StatusTooManyRequests, http.StatusTeapot},
+	}
+
+	ErrIPBanned = &ErrorClass{name: "IP banned", statusCodes: []int{http.StatusTeapot}}
+
+	ErrTimeout = &ErrorClass{name: "timeout waiting for response", codes: []int64{-1007}}
+
+	ErrTooManyOrders = &ErrorClass{name: "too many orders", codes: []int64{-1015}}
+
+	ErrServiceShuttingDown = &ErrorClass{name: "service shutting down", codes: []int64{-1016}}
+
+	ErrInvalidTimestamp = &ErrorClass{name: "invalid timestamp", codes: []int64{-1021}}
+
+	ErrInvalidSignature = &ErrorClass{name: "invalid signature", codes: []int64{-1022}}
+
+	ErrInvalidParameter = &ErrorClass{
+		name:  "invalid parameter",
+		codes: []int64{-1100, -1101, -1102, -1103, -1104, -1105, -1106, -1111, -1112, -1114, -1115, -1116, -1117, -1118, -1119, -1120, -1128, -1130},
+	}
+
+	ErrInvalidSymbol = &ErrorClass{name: "invalid symbol", codes: []int64{-1121}}
+
+	ErrInvalidListenKey = &ErrorClass{name: "listen key does not exist", codes: []int64{-1125}}
+
+	ErrOrderRejected = &ErrorClass{name: "order rejected", codes: []int64{-2010}}
+
+	ErrCancelRejected = &ErrorClass{name: "cancel rejected", codes: []int64{-2011}}
+
+	ErrUnknownOrder = &ErrorClass{
+		name:     "unknown order",
+		codes:    []int64{-2013},
+		messages: map[int64][]string{-2011: {"unknown order"}},
+	}
+
+	ErrInvalidAPIKey = &ErrorClass{name: "invalid API key, IP or permissions", codes: []int64{-2008, -2014, -2015}}
+
+	ErrInsufficientBalance = &ErrorClass{
+		name:     "insufficient balance",
+		codes:    []int64{-2018, -2019},
+		messages: map[int64][]string{-2010: {"insufficient balance"}},
+	}
+
+	ErrWouldImmediatelyTrigger = &ErrorClass{
+		name:     "order would immediately trigger",
+		codes:    []int64{-2021},
+		messages: map[int64][]string{-2010: {"would trigger immediately", "would immediately trigger"}},
+	}
+
+	ErrWouldImmediatelyMatch = &ErrorClass{
+		name:     "order would immediately match and take",
+		codes:    []int64{-5022},
+		messages: map[int64][]string{-2010: {"would immediately match"}},
+	}
+
+	ErrReduceOnlyRejected = &ErrorClass{name: "reduce only order rejected", codes: []int64{-2022}}
+)
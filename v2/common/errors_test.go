@@ -0,0 +1,68 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    *APIError
+		target error
+		is     bool
+	}{
+		{"code", &APIError{Code: -1021}, ErrInvalidTimestamp, true},
+		{"other code", &APIError{Code: -1022}, ErrInvalidTimestamp, false},
+		{"spot insufficient balance", &APIError{Code: -2010, Message: "Account has insufficient balance for requested action."}, ErrInsufficientBalance, true},
+		{"futures insufficient margin", &APIError{Code: -2019, Message: "Margin is insufficient."}, ErrInsufficientBalance, true},
+		{"spot order rejected", &APIError{Code: -2010, Message: "Account has insufficient balance for requested action."}, ErrOrderRejected, true},
+		{"spot would match", &APIError{Code: -2010, Message: "Order would immediately match and take."}, ErrWouldImmediatelyMatch, true},
+		{"spot would trigger", &APIError{Code: -2010, Message: "Stop price would trigger immediately."}, ErrWouldImmediatelyTrigger, true},
+		{"futures would trigger", &APIError{Code: -2021, Message: "Order would immediately trigger."}, ErrWouldImmediatelyTrigger, true},
+		{"message of other code", &APIError{Code: -1013, Message: "insufficient balance"}, ErrInsufficientBalance, false},
+		{"unknown order", &APIError{Code: -2013, Message: "Order does not exist."}, ErrUnknownOrder, true},
+		{"cancel unknown order", &APIError{Code: -2011, Message: "Unknown order sent."}, ErrUnknownOrder, true},
+		{"too many requests", &APIError{Code: -1003, StatusCode: http.StatusTooManyRequests}, ErrTooManyRequests, true},
+		{"banned", &APIError{Code: -1003, StatusCode: http.StatusTeapot}, ErrIPBanned, true},
+		{"not banned", &APIError{Code: -1003, StatusCode: http.StatusTooManyRequests}, ErrIPBanned, false},
+		{"same code", &APIError{Code: -1121, Message: "Invalid symbol."}, &APIError{Code: -1121}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.is, errors.Is(tt.err, tt.target))
+		})
+	}
+}
+
+func TestAPIErrorWrapped(t *testing.T) {
+	err := fmt.Errorf("create order: %w", &APIError{
+		Code:       -2010,
+		Message:    "Account has insufficient balance for requested action.",
+		StatusCode: http.StatusBadRequest,
+		Endpoint:   "/api/v3/order",
+		Header:     http.Header{"X-Mbx-Used-Weight-1m": []string{"42"}},
+	})
+	assert.True(t, IsAPIError(err))
+	assert.True(t, errors.Is(err, ErrInsufficientBalance))
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "/api/v3/order", apiErr.Endpoint)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "42", apiErr.UsedWeight())
+
+	assert.False(t, IsAPIError(errors.New("dummy error")))
+}
+
+func TestRateLimitErrorIs(t *testing.T) {
+	err := &RateLimitError{Until: time.Now(), Banned: true}
+	assert.True(t, errors.Is(err, ErrTooManyRequests))
+	assert.True(t, errors.Is(err, ErrIPBanned))
+	assert.False(t, errors.Is(&RateLimitError{}, ErrIPBanned))
+}
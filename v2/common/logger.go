@@ -0,0 +1,108 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Logger is a leveled, structured logger. args are alternating keys and
+// values, as for the methods of *slog.Logger which implements this interface.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+type stdLogger struct {
+	l *log.Logger
+}
+
+// NewStdLogger adapts a *log.Logger to Logger, printing a line per entry such as:
+//
+//	DEBUG request method=GET url=https://api.binance.com/api/v3/ping
+func NewStdLogger(l *log.Logger) Logger {
+	return &stdLogger{l: l}
+}
+
+func (s *stdLogger) Debug(msg string, args ...interface{}) { s.print("DEBUG", msg, args) }
+func (s *stdLogger) Info(msg string, args ...interface{})  { s.print("INFO", msg, args) }
+func (s *stdLogger) Warn(msg string, args ...interface{})  { s.print("WARN", msg, args) }
+func (s *stdLogger) Error(msg string, args ...interface{}) { s.print("ERROR", msg, args) }
+
+func (s *stdLogger) print(level, msg string, args []interface{}) {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 < len(args) {
+			fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+		} else {
+			fmt.Fprintf(&b, " !BADKEY=%v", args[i])
+		}
+	}
+	s.l.Print(b.String())
+}
+
+type nopLogger struct{}
+
+// NewNopLogger returns a Logger discarding every entry
+func NewNopLogger() Logger {
+	return nopLogger{}
+}
+
+func (nopLogger) Debug(msg string, args ...interface{}) {}
+func (nopLogger) Info(msg string, args ...interface{})  {}
+func (nopLogger) Warn(msg string, args ...interface{})  {}
+func (nopLogger) Error(msg string, args ...interface{}) {}
+
+// Redacted replaces secrets in log entries
+const Redacted = "[REDACTED]"
+
+// secretParams are the query, form and JSON parameters redacted from log entries
+var secretParams = []string{"signature", "listenKey", "apiKey"}
+
+// secretHeaders are the headers redacted from log entries
+var secretHeaders = []string{"X-MBX-APIKEY"}
+
+var (
+	secretJSONRegexp   = regexp.MustCompile(`("(?:` + strings.Join(secretParams, "|") + `)"\s*:\s*)"[^"]*"`)
+	secretParamsRegexp = regexp.MustCompile(`((?:^|[&?])(?:` + strings.Join(secretParams, "|") + `)=)[^&]*`)
+	// listen keys are 60 alphanumeric characters, served as streams on
+	// /ws/<listenKey>, /stream?streams=<listenKey>/... or subscribed by session
+	listenKeyRegexp = regexp.MustCompile(`(^|[^A-Za-z0-9]|%2[Ff])[A-Za-z0-9]{60}\b`)
+)
+
+// RedactURL returns rawURL with the signature and listen key removed
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Redacted
+	}
+	u.RawQuery = RedactBody(u.RawQuery)
+	return listenKeyRegexp.ReplaceAllString(u.String(), "${1}"+Redacted)
+}
+
+// RedactBody returns a form encoded or JSON body with the signature, API key
+// and listen keys removed
+func RedactBody(body string) string {
+	body = secretJSONRegexp.ReplaceAllString(body, `${1}"`+Redacted+`"`)
+	body = secretParamsRegexp.ReplaceAllString(body, "${1}"+Redacted)
+	return listenKeyRegexp.ReplaceAllString(body, "${1}"+Redacted)
+}
+
+// RedactHeader returns a copy of header with the API key removed
+func RedactHeader(header http.Header) http.Header {
+	h := header.Clone()
+	for _, k := range secretHeaders {
+		if h.Get(k) != "" {
+			h.Set(k, Redacted)
+		}
+	}
+	return h
+}
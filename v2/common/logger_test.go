@@ -0,0 +1,80 @@
+package common
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewStdLogger(log.New(&buf, "", 0))
+	l.Debug("request", "method", http.MethodGet, "status", 200)
+	l.Warn("odd", "key")
+	assert.Equal(t, "DEBUG request method=GET status=200\nWARN odd !BADKEY=key\n", buf.String())
+}
+
+func TestRedactURL(t *testing.T) {
+	listenKey := "pqia91ma19a5s61cv6a81va65sdf19v8a65a1a5s61cv6a81va65sdf19v8a65a1"[:60]
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{
+			"https://api.binance.com/api/v3/order?symbol=BTCUSDT&timestamp=1&signature=abcdef",
+			"https://api.binance.com/api/v3/order?symbol=BTCUSDT&timestamp=1&signature=[REDACTED]",
+		},
+		{
+			"https://api.binance.com/api/v3/userDataStream?listenKey=" + listenKey,
+			"https://api.binance.com/api/v3/userDataStream?listenKey=[REDACTED]",
+		},
+		{
+			"wss://stream.binance.com:9443/ws/" + listenKey,
+			"wss://stream.binance.com:9443/ws/[REDACTED]",
+		},
+		{
+			"wss://stream.binance.com:9443/stream?streams=" + listenKey + "/btcusdt@depth",
+			"wss://stream.binance.com:9443/stream?streams=[REDACTED]/btcusdt@depth",
+		},
+		{
+			"wss://stream.binance.com:9443/stream?streams=btcusdt@depth%2F" + listenKey,
+			"wss://stream.binance.com:9443/stream?streams=btcusdt@depth%2F[REDACTED]",
+		},
+		{
+			"wss://stream.binance.com:9443/ws/btcusdt@depth",
+			"wss://stream.binance.com:9443/ws/btcusdt@depth",
+		},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, RedactURL(tt.url))
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	assert.Equal(t, "signature=[REDACTED]&symbol=BTCUSDT", RedactBody("signature=abcdef&symbol=BTCUSDT"))
+	assert.Equal(t, "mysignature=abcdef", RedactBody("mysignature=abcdef"))
+	assert.Equal(t, `{"listenKey": "[REDACTED]","symbol":"BTCUSDT"}`, RedactBody(`{"listenKey": "key","symbol":"BTCUSDT"}`))
+	assert.Equal(t, `{"params":{"apiKey":"[REDACTED]","signature":"[REDACTED]"}}`, RedactBody(`{"params":{"apiKey":"key","signature":"abcdef"}}`))
+}
+
+func TestRedactSubscribeParams(t *testing.T) {
+	listenKey := "pqia91ma19a5s61cv6a81va65sdf19v8a65a1a5s61cv6a81va65sdf19v8a65a1"[:60]
+	assert.Equal(t, `{"method":"SUBSCRIBE","params":["[REDACTED]","btcusdt@depth"],"id":1}`,
+		RedactBody(`{"method":"SUBSCRIBE","params":["`+listenKey+`","btcusdt@depth"],"id":1}`))
+	// API keys and signatures of 64 characters are not mistaken for listen keys
+	apiKey := listenKey + "abcd"
+	assert.Equal(t, "key="+apiKey, RedactBody("key="+apiKey))
+}
+
+func TestRedactHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-MBX-APIKEY", "key")
+	header.Set("Content-Type", "application/json")
+	redacted := RedactHeader(header)
+	assert.Equal(t, Redacted, redacted.Get("X-MBX-APIKEY"))
+	assert.Equal(t, "application/json", redacted.Get("Content-Type"))
+	assert.Equal(t, "key", header.Get("X-MBX-APIKEY"))
+}
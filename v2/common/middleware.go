@@ -0,0 +1,55 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RequestInfo describes a REST request about to be sent
+type RequestInfo struct {
+	Method   string
+	Endpoint string
+	// Query holds the query parameters, without the signature
+	Query url.Values
+	// Header is the header of the request, changes made by BeforeSend are sent
+	Header http.Header
+	Signed bool
+	// Weight is the request weight reserved by the RateLimiter
+	Weight int64
+	// Attempt is the number of the attempt, starting at 1
+	Attempt int
+}
+
+// ResponseInfo describes the outcome of a REST request
+type ResponseInfo struct {
+	// StatusCode is 0 when no response was received
+	StatusCode int
+	Header     http.Header
+	// Latency is the time from sending the request to reading the whole response
+	Latency time.Duration
+	// UsedWeight is the request weight used in the current minute, as reported
+	// by the X-MBX-USED-WEIGHT-1M header, 0 if missing
+	UsedWeight int64
+	// Err is the error of the request, a *APIError for error responses
+	Err error
+}
+
+// Middleware intercepts every REST request sent by a client, for metrics,
+// tracing or audit logging. Either function may be nil.
+type Middleware struct {
+	// BeforeSend is called before each attempt. The returned context is used
+	// to send the request and is passed to AfterReceive. Returning an error
+	// aborts the request with this error.
+	BeforeSend func(ctx context.Context, req *RequestInfo) (context.Context, error)
+	// AfterReceive is called after each attempt with the decoded outcome
+	AfterReceive func(ctx context.Context, req *RequestInfo, res *ResponseInfo)
+}
+
+// UsedWeight parses the X-MBX-USED-WEIGHT-1M header, 0 if missing
+func UsedWeight(header http.Header) int64 {
+	used, _ := strconv.ParseInt(header.Get(usedWeightHeaderPrefix+"1M"), 10, 64)
+	return used
+}
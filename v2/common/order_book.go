@@ -0,0 +1,45 @@
+package common
+
+import "context"
+
+// OrderBook is a local order book maintained from a depth snapshot and a diff
+// depth stream, implemented by the order books of spot, USDⓈ-M futures and
+// COIN-M futures
+type OrderBook interface {
+	// Symbol returns the symbol of the book
+	Symbol() string
+	// Synced reports whether the book is synchronised with the stream
+	Synced() bool
+	// WaitSynced waits until the book is synchronised
+	WaitSynced(ctx context.Context) error
+	// LastUpdateID returns the id of the last update applied to the book
+	LastUpdateID() int64
+	// BestBid returns the highest bid, false when there is none
+	BestBid() (PriceLevel, bool)
+	// BestAsk returns the lowest ask, false when there is none
+	BestAsk() (PriceLevel, bool)
+	// Bids returns the depth highest bids, or every bid when depth is not positive
+	Bids(depth int) []PriceLevel
+	// Asks returns the depth lowest asks, or every ask when depth is not positive
+	Asks(depth int) []PriceLevel
+	// BidQuantity returns the quantity bid at price, "0" when there is none
+	BidQuantity(price string) (string, error)
+	// AskQuantity returns the quantity asked at price, "0" when there is none
+	AskQuantity(price string) (string, error)
+	// Close stops the book
+	Close()
+	// Done returns a channel closed once the book stopped
+	Done() <-chan struct{}
+}
+
+// OrderBookUpdate is a change of a local order book
+type OrderBookUpdate struct {
+	Symbol       string
+	LastUpdateID int64
+	// Snapshot is true when the book was reset from a depth snapshot, Bids
+	// and Asks then hold every level of the book
+	Snapshot bool
+	// Bids and Asks are the changed levels, a zero quantity removes a level
+	Bids []PriceLevel
+	Asks []PriceLevel
+}
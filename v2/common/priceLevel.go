@@ -1,6 +1,10 @@
 package common
 
-import "strconv"
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
 
 // PriceLevel is a common structure for bids and asks in the
 // order book.
@@ -23,3 +27,21 @@ func (p *PriceLevel) Parse() (float64, float64, error) {
 	}
 	return price, quantity, nil
 }
+
+// UnmarshalJSON decodes a price level sent by Binance as a ["price", "quantity"]
+// array, or encoded as an object by json.Marshal
+func (p *PriceLevel) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] != '[' {
+		type priceLevel PriceLevel
+		return json.Unmarshal(data, (*priceLevel)(p))
+	}
+	var level []string
+	if err := json.Unmarshal(data, &level); err != nil {
+		return err
+	}
+	if len(level) < 2 {
+		return fmt.Errorf("invalid price level %s", data)
+	}
+	p.Price, p.Quantity = level[0], level[1]
+	return nil
+}
@@ -0,0 +1,16 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriceLevelUnmarshalJSON(t *testing.T) {
+	var levels []PriceLevel
+	assert.NoError(t, json.Unmarshal([]byte(`[["0.0024","10"],{"Price":"0.0025","Quantity":"2"}]`), &levels))
+	assert.Equal(t, []PriceLevel{{Price: "0.0024", Quantity: "10"}, {Price: "0.0025", Quantity: "2"}}, levels)
+
+	assert.Error(t, json.Unmarshal([]byte(`[["0.0024"]]`), &levels))
+}
@@ -0,0 +1,393 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate limit types and intervals, as listed in the exchange information
+const (
+	RateLimitTypeRequestWeight = "REQUEST_WEIGHT"
+	RateLimitTypeOrders        = "ORDERS"
+	RateLimitTypeRawRequests   = "RAW_REQUESTS"
+
+	RateLimitIntervalSecond = "SECOND"
+	RateLimitIntervalMinute = "MINUTE"
+	RateLimitIntervalHour   = "HOUR"
+	RateLimitIntervalDay    = "DAY"
+)
+
+const (
+	usedWeightHeaderPrefix = "X-MBX-USED-WEIGHT-"
+	orderCountHeaderPrefix = "X-MBX-ORDER-COUNT-"
+)
+
+var bannedUntilRegexp = regexp.MustCompile(`banned until (\d+)`)
+
+// RateLimit define a limit enforced by Binance
+type RateLimit struct {
+	RateLimitType string `json:"rateLimitType"`
+	Interval      string `json:"interval"`
+	IntervalNum   int64  `json:"intervalNum"`
+	Limit         int64  `json:"limit"`
+}
+
+// Duration returns the length of the rate limit interval
+func (l RateLimit) Duration() time.Duration {
+	return time.Duration(l.IntervalNum) * intervalUnit(l.Interval)
+}
+
+// RateLimitUsage is the usage of a rate limit, reported by the responses of
+// the WebSocket API
+type RateLimitUsage struct {
+	RateLimit
+	Count int64 `json:"count"`
+}
+
+// RateLimitError is returned by RateLimiter.Wait when a request can't be sent
+// without exceeding a limit, or while the client is banned
+type RateLimitError struct {
+	// Until is the time at which requests are accepted again
+	Until time.Time
+	// Banned is true when Binance banned the IP with a 418 response
+	Banned bool
+	// RateLimitType is the limit that would be exceeded, empty when blocked by the server
+	RateLimitType string
+}
+
+// Error return the reason and the end of the block
+func (e *RateLimitError) Error() string {
+	switch {
+	case e.Banned:
+		return fmt.Sprintf("<RateLimitError> banned until %s", e.Until.Format(time.RFC3339))
+	case e.RateLimitType != "":
+		return fmt.Sprintf("<RateLimitError> %s limit reached until %s", e.RateLimitType, e.Until.Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("<RateLimitError> too many requests until %s", e.Until.Format(time.RFC3339))
+	}
+}
+
+// Is reports whether target is ErrTooManyRequests, or ErrIPBanned when banned
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrTooManyRequests || (e.Banned && target == ErrIPBanned)
+}
+
+type rateLimitCounter struct {
+	// rateLimit is the limit enforced, its Limit is 0 when only tracked
+	rateLimit     RateLimit
+	rateLimitType string
+	interval      time.Duration
+	limit         int64
+	used          int64
+	window        time.Time
+}
+
+// reset clears the counter when now is past its window
+func (c *rateLimitCounter) reset(now time.Time) {
+	window := now.Truncate(c.interval)
+	if !window.Equal(c.window) {
+		c.window = window
+		c.used = 0
+	}
+}
+
+// RateLimiter tracks the request weight and the order count used per interval
+// from the X-MBX-USED-WEIGHT-* and X-MBX-ORDER-COUNT-* response headers, and
+// holds requests back before they exceed the limits.
+// After a 429 response it waits for the Retry-After delay, and after a 418
+// response it rejects every request until the ban is lifted.
+// A RateLimiter is safe for concurrent use and may be shared by several clients
+// using the same IP and account.
+type RateLimiter struct {
+	// FailFast makes Wait return a *RateLimitError instead of blocking until the limit resets
+	FailFast bool
+
+	mu           sync.Mutex
+	counters     []*rateLimitCounter
+	blockedUntil time.Time
+	banned       bool
+	now          func() time.Time
+}
+
+// NewRateLimiter init a rate limiter enforcing limits, usually the RateLimits
+// of the exchange information. Without limits, the limits are seeded by the
+// first exchange information request of a client using the rate limiter.
+func NewRateLimiter(limits ...RateLimit) *RateLimiter {
+	l := &RateLimiter{now: time.Now}
+	l.SetLimits(limits)
+	return l
+}
+
+// SetLimits replaces the enforced limits, keeping the usage tracked so far
+func (l *RateLimiter) SetLimits(limits []RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.setLimitsLocked(limits)
+}
+
+// SeedLimits sets the enforced limits unless limits are set already, it is
+// called with the RateLimits of the exchange information by the exchange
+// information services
+func (l *RateLimiter) SeedLimits(limits []RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, c := range l.counters {
+		if c.limit > 0 {
+			return
+		}
+	}
+	l.setLimitsLocked(limits)
+}
+
+func (l *RateLimiter) setLimitsLocked(limits []RateLimit) {
+	for _, c := range l.counters {
+		c.limit = 0
+		c.rateLimit.Limit = 0
+	}
+	for _, limit := range limits {
+		d := limit.Duration()
+		if d <= 0 {
+			continue
+		}
+		c := l.counter(limit.RateLimitType, d)
+		c.limit = limit.Limit
+		c.rateLimit = limit
+	}
+}
+
+// Limits returns the enforced limits
+func (l *RateLimiter) Limits() []RateLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var limits []RateLimit
+	for _, c := range l.counters {
+		if c.limit > 0 {
+			limits = append(limits, c.rateLimit)
+		}
+	}
+	return limits
+}
+
+// counter returns the counter for the type and interval, creating it if needed
+func (l *RateLimiter) counter(rateLimitType string, interval time.Duration) *rateLimitCounter {
+	for _, c := range l.counters {
+		if c.rateLimitType == rateLimitType && c.interval == interval {
+			return c
+		}
+	}
+	c := &rateLimitCounter{rateLimitType: rateLimitType, interval: interval}
+	l.counters = append(l.counters, c)
+	return c
+}
+
+// Used returns the usage tracked for the type and interval in the current window
+func (l *RateLimiter) Used(rateLimitType string, interval time.Duration) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c := l.counter(rateLimitType, interval)
+	c.reset(l.now())
+	return c.used
+}
+
+// BlockedUntil returns the time until which requests are held back by a 429 or
+// 418 response, and whether it is an IP ban
+func (l *RateLimiter) BlockedUntil() (until time.Time, banned bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.now().Before(l.blockedUntil) {
+		return time.Time{}, false
+	}
+	return l.blockedUntil, l.banned
+}
+
+// Wait blocks until a request of the weight can be sent without exceeding the
+// limits, then reserves it. order must be true for requests placing orders.
+// It returns a *RateLimitError instead of blocking when FailFast is set or the
+// IP is banned, and the context error if ctx is done first.
+func (l *RateLimiter) Wait(ctx context.Context, weight int64, order bool) error {
+	for {
+		d, err := l.reserve(weight, order)
+		if err != nil || d <= 0 {
+			return err
+		}
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// reserve reserves the weight and returns 0 when the request can be sent now,
+// or else how long to wait before trying again
+func (l *RateLimiter) reserve(weight int64, order bool) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if now.Before(l.blockedUntil) {
+		if l.banned || l.FailFast {
+			return 0, &RateLimitError{Until: l.blockedUntil, Banned: l.banned}
+		}
+		return l.blockedUntil.Sub(now), nil
+	}
+	var reserved []*rateLimitCounter
+	for _, c := range l.counters {
+		var n int64
+		switch c.rateLimitType {
+		case RateLimitTypeRequestWeight:
+			n = weight
+		case RateLimitTypeRawRequests:
+			n = 1
+		case RateLimitTypeOrders:
+			if !order {
+				continue
+			}
+			n = 1
+		default:
+			continue
+		}
+		c.reset(now)
+		// a request heavier than the limit is sent alone in its window
+		if c.limit > 0 && c.used > 0 && c.used+n > c.limit {
+			until := c.window.Add(c.interval)
+			if l.FailFast {
+				return 0, &RateLimitError{Until: until, RateLimitType: c.rateLimitType}
+			}
+			return until.Sub(now), nil
+		}
+		reserved = append(reserved, c)
+	}
+	for _, c := range reserved {
+		if c.rateLimitType == RateLimitTypeRequestWeight {
+			c.used += weight
+		} else {
+			c.used++
+		}
+	}
+	return 0, nil
+}
+
+// Update records the usage reported by the response headers, and blocks
+// requests after a 429 or 418 response
+func (l *RateLimiter) Update(statusCode int, header http.Header, body []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	for name, values := range header {
+		if len(values) == 0 {
+			continue
+		}
+		var rateLimitType string
+		name = strings.ToUpper(name)
+		switch {
+		case strings.HasPrefix(name, usedWeightHeaderPrefix):
+			rateLimitType = RateLimitTypeRequestWeight
+		case strings.HasPrefix(name, orderCountHeaderPrefix):
+			rateLimitType = RateLimitTypeOrders
+		default:
+			continue
+		}
+		interval := parseHeaderInterval(name[strings.LastIndex(name, "-")+1:])
+		used, err := strconv.ParseInt(values[0], 10, 64)
+		if interval <= 0 || err != nil {
+			continue
+		}
+		c := l.counter(rateLimitType, interval)
+		c.reset(now)
+		// keep the local count when higher, it includes requests still in flight
+		if used > c.used {
+			c.used = used
+		}
+	}
+
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusTeapot:
+		until := now.Add(parseRetryAfter(header))
+		if statusCode == http.StatusTeapot {
+			if m := bannedUntilRegexp.FindSubmatch(body); m != nil {
+				if ms, err := strconv.ParseInt(string(m[1]), 10, 64); err == nil {
+					until = time.Unix(0, ms*int64(time.Millisecond))
+				}
+			}
+			l.banned = true
+		} else if !now.Before(l.blockedUntil) {
+			l.banned = false
+		}
+		if until.After(l.blockedUntil) {
+			l.blockedUntil = until
+		}
+	}
+}
+
+// UpdateUsage records the usage reported by a WebSocket API response
+func (l *RateLimiter) UpdateUsage(usages []RateLimitUsage) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	for _, u := range usages {
+		d := u.Duration()
+		if d <= 0 {
+			continue
+		}
+		c := l.counter(u.RateLimitType, d)
+		c.reset(now)
+		// keep the local count when higher, it includes requests still in flight
+		if u.Count > c.used {
+			c.used = u.Count
+		}
+	}
+}
+
+// parseRetryAfter returns the Retry-After delay, or a second when it is missing
+func parseRetryAfter(header http.Header) time.Duration {
+	seconds, err := strconv.ParseInt(header.Get("Retry-After"), 10, 64)
+	if err != nil || seconds <= 0 {
+		return time.Second
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// parseHeaderInterval parses the interval suffix of a header, e.g. 1M or 10S
+func parseHeaderInterval(s string) time.Duration {
+	if len(s) < 2 {
+		return 0
+	}
+	n, err := strconv.ParseInt(s[:len(s)-1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	var unit time.Duration
+	switch s[len(s)-1] {
+	case 'S':
+		unit = time.Second
+	case 'M':
+		unit = time.Minute
+	case 'H':
+		unit = time.Hour
+	case 'D':
+		unit = 24 * time.Hour
+	}
+	return time.Duration(n) * unit
+}
+
+func intervalUnit(interval string) time.Duration {
+	switch interval {
+	case RateLimitIntervalSecond:
+		return time.Second
+	case RateLimitIntervalMinute:
+		return time.Minute
+	case RateLimitIntervalHour:
+		return time.Hour
+	case RateLimitIntervalDay:
+		return 24 * time.Hour
+	}
+	return 0
+}
@@ -0,0 +1,139 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type rateLimiterTestSuite struct {
+	suite.Suite
+	limiter *RateLimiter
+	now     time.Time
+}
+
+func TestRateLimiter(t *testing.T) {
+	suite.Run(t, new(rateLimiterTestSuite))
+}
+
+func (s *rateLimiterTestSuite) SetupTest() {
+	s.now = time.Date(2022, 7, 30, 12, 0, 30, 0, time.UTC)
+	s.limiter = NewRateLimiter(
+		RateLimit{RateLimitType: RateLimitTypeRequestWeight, Interval: RateLimitIntervalMinute, IntervalNum: 1, Limit: 10},
+		RateLimit{RateLimitType: RateLimitTypeOrders, Interval: RateLimitIntervalSecond, IntervalNum: 10, Limit: 2},
+	)
+	s.limiter.FailFast = true
+	s.limiter.now = func() time.Time { return s.now }
+}
+
+func (s *rateLimiterTestSuite) TestSeedLimits() {
+	limits := []RateLimit{
+		{RateLimitType: RateLimitTypeRequestWeight, Interval: RateLimitIntervalMinute, IntervalNum: 1, Limit: 6000},
+	}
+	// the limits set explicitly are kept
+	s.limiter.SeedLimits(limits)
+	s.Len(s.limiter.Limits(), 2)
+
+	l := NewRateLimiter()
+	l.Update(http.StatusOK, http.Header{"X-Mbx-Used-Weight-1m": []string{"7"}}, nil)
+	s.Empty(l.Limits())
+	l.SeedLimits(limits)
+	s.Equal(limits, l.Limits())
+	s.Equal(int64(7), l.Used(RateLimitTypeRequestWeight, time.Minute))
+}
+
+func (s *rateLimiterTestSuite) TestUpdateFromHeaders() {
+	s.limiter.Update(http.StatusOK, http.Header{
+		"X-Mbx-Used-Weight-1m":  []string{"7"},
+		"X-Mbx-Order-Count-10s": []string{"1"},
+		"X-Mbx-Order-Count-1d":  []string{"5"},
+	}, nil)
+	s.Equal(int64(7), s.limiter.Used(RateLimitTypeRequestWeight, time.Minute))
+	s.Equal(int64(1), s.limiter.Used(RateLimitTypeOrders, 10*time.Second))
+	s.Equal(int64(5), s.limiter.Used(RateLimitTypeOrders, 24*time.Hour))
+
+	s.now = s.now.Add(time.Minute)
+	s.Equal(int64(0), s.limiter.Used(RateLimitTypeRequestWeight, time.Minute))
+}
+
+func (s *rateLimiterTestSuite) TestUpdateUsage() {
+	var usages []RateLimitUsage
+	s.Require().NoError(json.Unmarshal([]byte(`[
+		{"rateLimitType":"REQUEST_WEIGHT","interval":"MINUTE","intervalNum":1,"limit":10,"count":6},
+		{"rateLimitType":"ORDERS","interval":"SECOND","intervalNum":10,"limit":2,"count":2}
+	]`), &usages))
+	s.limiter.UpdateUsage(usages)
+	s.Equal(int64(6), s.limiter.Used(RateLimitTypeRequestWeight, time.Minute))
+	s.Error(s.limiter.Wait(context.Background(), 1, true))
+}
+
+func (s *rateLimiterTestSuite) TestWaitFailFast() {
+	s.limiter.Update(http.StatusOK, http.Header{"X-Mbx-Used-Weight-1m": []string{"9"}}, nil)
+	s.NoError(s.limiter.Wait(context.Background(), 1, false))
+
+	err := s.limiter.Wait(context.Background(), 1, false)
+	s.Require().Error(err)
+	rlErr, ok := err.(*RateLimitError)
+	s.Require().True(ok)
+	s.Equal(RateLimitTypeRequestWeight, rlErr.RateLimitType)
+	s.Equal(time.Date(2022, 7, 30, 12, 1, 0, 0, time.UTC), rlErr.Until)
+}
+
+func (s *rateLimiterTestSuite) TestWaitHeavierThanLimit() {
+	// sent alone in its window rather than held back forever
+	s.NoError(s.limiter.Wait(context.Background(), 20, false))
+	s.Equal(int64(20), s.limiter.Used(RateLimitTypeRequestWeight, time.Minute))
+	s.Error(s.limiter.Wait(context.Background(), 1, false))
+	s.now = s.now.Add(time.Minute)
+	s.NoError(s.limiter.Wait(context.Background(), 1, false))
+}
+
+func (s *rateLimiterTestSuite) TestWaitOrders() {
+	s.NoError(s.limiter.Wait(context.Background(), 1, true))
+	s.NoError(s.limiter.Wait(context.Background(), 1, true))
+	s.Error(s.limiter.Wait(context.Background(), 1, true))
+	s.NoError(s.limiter.Wait(context.Background(), 1, false))
+}
+
+func (s *rateLimiterTestSuite) TestWaitBlocks() {
+	s.limiter.FailFast = false
+	s.limiter.now = time.Now
+	s.limiter.SetLimits([]RateLimit{
+		{RateLimitType: RateLimitTypeRequestWeight, Interval: RateLimitIntervalDay, IntervalNum: 1, Limit: 1},
+	})
+	s.NoError(s.limiter.Wait(context.Background(), 1, false))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s.Equal(context.DeadlineExceeded, s.limiter.Wait(ctx, 1, false))
+}
+
+func (s *rateLimiterTestSuite) TestTooManyRequests() {
+	s.limiter.Update(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"3"}}, nil)
+	until, banned := s.limiter.BlockedUntil()
+	s.Equal(s.now.Add(3*time.Second), until)
+	s.False(banned)
+
+	s.now = s.now.Add(3 * time.Second)
+	s.NoError(s.limiter.Wait(context.Background(), 1, false))
+}
+
+func (s *rateLimiterTestSuite) TestBanned() {
+	s.limiter.FailFast = false
+	body := []byte(`{"code":-1003,"msg":"Way too many requests; IP banned until 1659182520000. Please use the websocket for live updates to avoid bans."}`)
+	s.limiter.Update(http.StatusTeapot, http.Header{"Retry-After": []string{"90"}}, body)
+
+	err := s.limiter.Wait(context.Background(), 1, false)
+	s.Require().Error(err)
+	rlErr, ok := err.(*RateLimitError)
+	s.Require().True(ok)
+	s.True(rlErr.Banned)
+	s.Equal(int64(1659182520000), rlErr.Until.UnixNano()/int64(time.Millisecond))
+
+	s.now = rlErr.Until
+	s.NoError(s.limiter.Wait(context.Background(), 1, false))
+}
@@ -0,0 +1,68 @@
+package common
+
+import "time"
+
+// WsState define the connection states of a reconnecting websocket stream
+type WsState string
+
+// Websocket stream states
+const (
+	// WsStateConnected is reported once the stream is connected, initially and after a reconnection
+	WsStateConnected WsState = "CONNECTED"
+	// WsStateReconnecting is reported when the connection is lost, along with the error
+	WsStateReconnecting WsState = "RECONNECTING"
+	// WsStateGaveUp is reported when the stream stops after MaxAttempts failed reconnections
+	WsStateGaveUp WsState = "GAVE_UP"
+)
+
+// WsGap describes an interval during which a stream was disconnected, the
+// events sent in the meantime are missed, e.g. an order book must be
+// synchronized again
+type WsGap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// ReconnectPolicy define how a websocket stream is connected again after its
+// connection is lost. The stream endpoint carries its subscriptions, so they
+// are restored by the new connection.
+type ReconnectPolicy struct {
+	// MaxAttempts is the maximum number of consecutive failed reconnections
+	// before giving up, 0 means retrying forever
+	MaxAttempts int
+	// InitialBackoff is the delay before the first reconnection, doubled on every failure
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two reconnections
+	MaxBackoff time.Duration
+	// Jitter is the fraction of the delay randomly added or removed, between 0 and 1
+	Jitter float64
+	// MaxConnectionAge is the age after which a new connection is opened to
+	// replace the current one, ahead of the 24h limit of Binance. The current
+	// connection is closed once the new one is established, so no event is
+	// missed but some may be received twice. 0 disables it.
+	MaxConnectionAge time.Duration
+	// OnStateChange is called on every state change, err is the cause of the
+	// WsStateReconnecting and WsStateGaveUp states
+	OnStateChange func(state WsState, err error)
+	// OnGap is called after a reconnection with the interval during which
+	// events may have been missed
+	OnGap func(gap WsGap)
+}
+
+// NewReconnectPolicy init a reconnect policy retrying forever with an
+// exponential backoff from 500ms up to 30s with 20% of jitter, and replacing
+// the connections after 23 hours
+func NewReconnectPolicy() *ReconnectPolicy {
+	return &ReconnectPolicy{
+		InitialBackoff:   500 * time.Millisecond,
+		MaxBackoff:       30 * time.Second,
+		Jitter:           0.2,
+		MaxConnectionAge: 23 * time.Hour,
+	}
+}
+
+// Backoff returns the delay to wait before the reconnection attempt, starting at 1
+func (p *ReconnectPolicy) Backoff(attempt int) time.Duration {
+	r := RetryPolicy{InitialBackoff: p.InitialBackoff, MaxBackoff: p.MaxBackoff, Jitter: p.Jitter}
+	return r.Backoff(attempt)
+}
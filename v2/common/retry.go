@@ -0,0 +1,114 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryAttempt describes the outcome of a request attempt
+type RetryAttempt struct {
+	// Attempt is the number of the attempt, starting at 1
+	Attempt int
+	Method  string
+	// Order is true when the request places or modifies orders
+	Order bool
+	// StatusCode is 0 when no response was received
+	StatusCode int
+	Header     http.Header
+	Err        error
+}
+
+// RetryPolicy define when and how often a failed REST request is sent again.
+//
+// By default only GET requests are retried, after a network error, a 5xx
+// response, a 429 response or a -1021 invalid timestamp error.
+// Other requests are only retried after a 429 or -1021 error, when Binance
+// rejected them without processing them; order placement is never re-sent
+// after a network error or a 5xx response as its execution status is unknown.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, doubled on every retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts
+	MaxBackoff time.Duration
+	// Jitter is the fraction of the delay randomly added or removed, between 0 and 1
+	Jitter float64
+	// RetryNonIdempotent also retries requests other than GET after a network
+	// error or a 5xx response, except order placement
+	RetryNonIdempotent bool
+}
+
+// NewRetryPolicy init a retry policy with 3 attempts and an exponential
+// backoff from 200ms up to 5s with 20% of jitter
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Jitter:         0.2,
+	}
+}
+
+// Retry returns how long to wait before sending the request again, and false
+// when it must not be retried
+func (p *RetryPolicy) Retry(a *RetryAttempt) (time.Duration, bool) {
+	if a.Attempt >= p.MaxAttempts || !p.retryable(a) {
+		return 0, false
+	}
+	delay := p.Backoff(a.Attempt)
+	if a.StatusCode == http.StatusTooManyRequests {
+		if retryAfter := parseRetryAfter(a.Header); retryAfter > delay {
+			delay = retryAfter
+		}
+	}
+	return delay, true
+}
+
+func (p *RetryPolicy) retryable(a *RetryAttempt) bool {
+	switch {
+	case a.StatusCode == http.StatusTooManyRequests:
+		return true
+	case errors.Is(a.Err, ErrInvalidTimestamp):
+		// invalid timestamp, the request is signed again with a new timestamp
+		return true
+	case a.StatusCode == http.StatusTeapot:
+		return false
+	case a.StatusCode >= http.StatusInternalServerError:
+	case a.StatusCode == 0 && isNetworkError(a.Err):
+	default:
+		return false
+	}
+	if a.Method == http.MethodGet {
+		return true
+	}
+	return p.RetryNonIdempotent && !a.Order
+}
+
+// Backoff returns the delay to wait after the attempt
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		delay += time.Duration(p.Jitter * (2*rand.Float64() - 1) * float64(delay))
+	}
+	return delay
+}
+
+// isNetworkError reports whether err is a failure to exchange with the server,
+// rather than a cancellation or a client side error
+func isNetworkError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var rateLimitErr *RateLimitError
+	return !errors.As(err, &rateLimitErr)
+}
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyRetry(t *testing.T) {
+	p := NewRetryPolicy()
+	p.Jitter = 0
+	netErr := errors.New("read: connection reset by peer")
+	tests := []struct {
+		name    string
+		attempt *RetryAttempt
+		delay   time.Duration
+		retry   bool
+	}{
+		{"get network error", &RetryAttempt{Attempt: 1, Method: http.MethodGet, Err: netErr}, 200 * time.Millisecond, true},
+		{"get 5xx", &RetryAttempt{Attempt: 2, Method: http.MethodGet, StatusCode: 502, Err: &APIError{}}, 400 * time.Millisecond, true},
+		{"max attempts", &RetryAttempt{Attempt: 3, Method: http.MethodGet, StatusCode: 502, Err: &APIError{}}, 0, false},
+		{"get 4xx", &RetryAttempt{Attempt: 1, Method: http.MethodGet, StatusCode: 400, Err: &APIError{Code: -1121}}, 0, false},
+		{"banned", &RetryAttempt{Attempt: 1, Method: http.MethodGet, StatusCode: 418, Err: &APIError{Code: -1003}}, 0, false},
+		{"canceled", &RetryAttempt{Attempt: 1, Method: http.MethodGet, Err: context.Canceled}, 0, false},
+		{"rate limiter", &RetryAttempt{Attempt: 1, Method: http.MethodGet, Err: &RateLimitError{}}, 0, false},
+		{"order network error", &RetryAttempt{Attempt: 1, Method: http.MethodPost, Order: true, Err: netErr}, 0, false},
+		{"order 5xx", &RetryAttempt{Attempt: 1, Method: http.MethodPost, Order: true, StatusCode: 503, Err: &APIError{}}, 0, false},
+		{"post 5xx", &RetryAttempt{Attempt: 1, Method: http.MethodPost, StatusCode: 503, Err: &APIError{}}, 0, false},
+		{"order invalid timestamp", &RetryAttempt{Attempt: 1, Method: http.MethodPost, Order: true, StatusCode: 400, Err: &APIError{Code: -1021}}, 200 * time.Millisecond, true},
+		{"order retry after", &RetryAttempt{Attempt: 1, Method: http.MethodPost, Order: true, StatusCode: 429, Header: http.Header{"Retry-After": []string{"2"}}, Err: &APIError{Code: -1003}}, 2 * time.Second, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := p.Retry(tt.attempt)
+			assert.Equal(t, tt.retry, retry)
+			assert.Equal(t, tt.delay, delay)
+		})
+	}
+
+	p.RetryNonIdempotent = true
+	_, retry := p.Retry(&RetryAttempt{Attempt: 1, Method: http.MethodPost, StatusCode: 503, Err: &APIError{}})
+	assert.True(t, retry)
+	_, retry = p.Retry(&RetryAttempt{Attempt: 1, Method: http.MethodPost, Order: true, StatusCode: 503, Err: &APIError{}})
+	assert.False(t, retry)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := NewRetryPolicy()
+	p.Jitter = 0
+	assert.Equal(t, 200*time.Millisecond, p.Backoff(1))
+	assert.Equal(t, 800*time.Millisecond, p.Backoff(3))
+	assert.Equal(t, 5*time.Second, p.Backoff(10))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := p.Backoff(2)
+		assert.True(t, d >= 200*time.Millisecond && d <= 600*time.Millisecond, d)
+	}
+}
+
+func TestReconnectPolicyBackoff(t *testing.T) {
+	p := NewReconnectPolicy()
+	p.Jitter = 0
+	assert.Equal(t, 500*time.Millisecond, p.Backoff(1))
+	assert.Equal(t, 2*time.Second, p.Backoff(3))
+	assert.Equal(t, 30*time.Second, p.Backoff(10))
+}
@@ -0,0 +1,132 @@
+package common
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// Signer signs the payload of a SIGNED request and returns the signature as
+// it must be sent in the 'signature' parameter.
+// Implement it to delegate signing to a remote KMS or HSM so that no secret
+// needs to sit in process memory.
+type Signer interface {
+	Sign(payload []byte) (string, error)
+}
+
+// SignerFunc is an adapter to use an ordinary function as a Signer
+type SignerFunc func(payload []byte) (string, error)
+
+// Sign calls f(payload)
+func (f SignerFunc) Sign(payload []byte) (string, error) {
+	return f(payload)
+}
+
+// HMACSigner signs payloads with HMAC-SHA256, for API keys created with a secret key
+type HMACSigner struct {
+	secretKey []byte
+}
+
+// NewHMACSigner init a HMAC-SHA256 signer with the secret key
+func NewHMACSigner(secretKey string) *HMACSigner {
+	return &HMACSigner{secretKey: []byte(secretKey)}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of payload
+func (s *HMACSigner) Sign(payload []byte) (string, error) {
+	mac := hmac.New(sha256.New, s.secretKey)
+	_, err := mac.Write(payload)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// RSASigner signs payloads with RSASSA-PKCS1-v1_5 over SHA-256, for RSA API keys
+type RSASigner struct {
+	key *rsa.PrivateKey
+}
+
+// NewRSASigner init a RSA signer with the private key
+func NewRSASigner(key *rsa.PrivateKey) *RSASigner {
+	return &RSASigner{key: key}
+}
+
+// NewRSASignerFromPEM init a RSA signer from a PEM encoded PKCS #1 or PKCS #8 private key
+func NewRSASignerFromPEM(data []byte) (*RSASigner, error) {
+	block, err := decodePEM(data)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return NewRSASigner(key), nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected a RSA private key, got %T", key)
+	}
+	return NewRSASigner(rsaKey), nil
+}
+
+// Sign returns the base64 encoded RSA signature of payload
+func (s *RSASigner) Sign(payload []byte) (string, error) {
+	hashed := sha256.Sum256(payload)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// Ed25519Signer signs payloads with Ed25519, for Ed25519 API keys
+type Ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewEd25519Signer init an Ed25519 signer with the private key
+func NewEd25519Signer(key ed25519.PrivateKey) *Ed25519Signer {
+	return &Ed25519Signer{key: key}
+}
+
+// NewEd25519SignerFromPEM init an Ed25519 signer from a PEM encoded PKCS #8 private key
+func NewEd25519SignerFromPEM(data []byte) (*Ed25519Signer, error) {
+	block, err := decodePEM(data)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an Ed25519 private key, got %T", key)
+	}
+	return NewEd25519Signer(edKey), nil
+}
+
+// Sign returns the base64 encoded Ed25519 signature of payload
+func (s *Ed25519Signer) Sign(payload []byte) (string, error) {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, payload)), nil
+}
+
+func decodePEM(data []byte) (*pem.Block, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	return block, nil
+}
@@ -0,0 +1,75 @@
+package common
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACSigner(t *testing.T) {
+	// example from the Binance API documentation
+	s := NewHMACSigner("NhqPtmdSJYdKjVHjA7PZj4Mge3R5YNiP1e3UZjInClVN65XAbvqqM6A7H5fATj0j")
+	sig, err := s.Sign([]byte("symbol=LTCBTC&side=BUY&type=LIMIT&timeInForce=GTC&quantity=1&price=0.1&recvWindow=5000&timestamp=1499827319559"))
+	require.NoError(t, err)
+	assert.Equal(t, "c8db56825ae71d6d79447849e617115f4a920fa2acdcab2b053c4b2838bd6b71", sig)
+}
+
+func TestRSASignerFromPEM(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	payload := []byte("symbol=BTCUSDT&timestamp=1499827319559")
+
+	for _, der := range [][]byte{x509.MarshalPKCS1PrivateKey(key), mustMarshalPKCS8(t, key)} {
+		s, err := NewRSASignerFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+		require.NoError(t, err)
+		sig, err := s.Sign(payload)
+		require.NoError(t, err)
+		raw, err := base64.StdEncoding.DecodeString(sig)
+		require.NoError(t, err)
+		hashed := sha256.Sum256(payload)
+		assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hashed[:], raw))
+	}
+}
+
+func TestEd25519SignerFromPEM(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	payload := []byte("symbol=BTCUSDT&timestamp=1499827319559")
+
+	s, err := NewEd25519SignerFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: mustMarshalPKCS8(t, key)}))
+	require.NoError(t, err)
+	sig, err := s.Sign(payload)
+	require.NoError(t, err)
+	raw, err := base64.StdEncoding.DecodeString(sig)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(pub, payload, raw))
+
+	_, err = NewEd25519SignerFromPEM([]byte("not a pem"))
+	assert.Error(t, err)
+	_, err = NewRSASignerFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: mustMarshalPKCS8(t, key)}))
+	assert.Error(t, err)
+}
+
+func TestSignerFunc(t *testing.T) {
+	var s Signer = SignerFunc(func(payload []byte) (string, error) {
+		return "remote:" + string(payload), nil
+	})
+	sig, err := s.Sign([]byte("a=1"))
+	require.NoError(t, err)
+	assert.Equal(t, "remote:a=1", sig)
+}
+
+func mustMarshalPKCS8(t *testing.T, key interface{}) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return der
+}
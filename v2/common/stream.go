@@ -0,0 +1,123 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrStreamClosed is returned by Stream.Next once the stream is closed
+var ErrStreamClosed = errors.New("stream closed")
+
+// defaultStreamBuffer is the number of events buffered by NewStream
+const defaultStreamBuffer = 100
+
+// ServeFunc starts a websocket stream passing its events to handler and its
+// errors to errHandler, like the Ws*Serve functions of the product packages
+type ServeFunc[T any] func(handler func(event T), errHandler func(err error)) (doneC, stopC chan struct{}, err error)
+
+// Stream passes the events of a websocket stream through a channel instead
+// of a handler, e.g. to select over several streams or to feed a pool of
+// workers. The stream reads no more messages while the channel is full, so
+// the events must be received promptly, or the stream dispatched with a
+// DispatchPolicy.
+type Stream[T any] struct {
+	c      chan T
+	doneC  chan struct{}
+	stopC  chan struct{}
+	closeC chan struct{}
+
+	closeOnce sync.Once
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewStream starts a stream with serve, buffering 100 events, e.g.
+//
+//	stream, err := common.NewStream(func(h func(*binance.WsKlineEvent), eh func(error)) (chan struct{}, chan struct{}, error) {
+//		return binance.WsKlineServe("BTCUSDT", "1m", h, eh)
+//	})
+func NewStream[T any](serve ServeFunc[T]) (*Stream[T], error) {
+	return NewStreamWithBuffer(serve, defaultStreamBuffer)
+}
+
+// NewStreamWithBuffer starts a stream with serve, buffering size events
+func NewStreamWithBuffer[T any](serve ServeFunc[T], size int) (*Stream[T], error) {
+	s := &Stream[T]{
+		c:      make(chan T, size),
+		doneC:  make(chan struct{}),
+		closeC: make(chan struct{}),
+	}
+	doneC, stopC, err := serve(s.handle, s.handleErr)
+	if err != nil {
+		return nil, err
+	}
+	s.stopC = stopC
+	go func() {
+		// the handler is not called anymore once doneC is closed
+		<-doneC
+		close(s.c)
+		close(s.doneC)
+	}()
+	return s, nil
+}
+
+func (s *Stream[T]) handle(event T) {
+	select {
+	case s.c <- event:
+	case <-s.closeC:
+	}
+}
+
+func (s *Stream[T]) handleErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// C returns the channel of the events, closed once the stream stopped
+func (s *Stream[T]) C() <-chan T {
+	return s.c
+}
+
+// Err returns the last error of the stream, e.g. the error which stopped it
+// once C is closed, nil when none occurred
+func (s *Stream[T]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Next returns the next event, or the error which stopped the stream,
+// ErrStreamClosed when the stream stopped without error. It returns the error
+// of ctx once ctx is done.
+func (s *Stream[T]) Next(ctx context.Context) (T, error) {
+	var zero T
+	select {
+	case event, ok := <-s.c:
+		if ok {
+			return event, nil
+		}
+		if err := s.Err(); err != nil {
+			return zero, err
+		}
+		return zero, ErrStreamClosed
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// Close stops the stream, the events already buffered are still received
+// from C until it is closed
+func (s *Stream[T]) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closeC)
+		close(s.stopC)
+	})
+}
+
+// Done returns a channel closed once the stream stopped and C is closed
+func (s *Stream[T]) Done() <-chan struct{} {
+	return s.doneC
+}
@@ -0,0 +1,11 @@
+package common
+
+// StreamPoolLimits define the limits of Binance on every connection of a
+// stream pool
+type StreamPoolLimits struct {
+	// MaxStreams is the maximum number of streams of a connection, 0 means no limit
+	MaxStreams int
+	// MessageRate is the maximum number of requests sent per second on a
+	// connection, e.g. SUBSCRIBE and UNSUBSCRIBE, 0 means no limit
+	MessageRate int
+}
@@ -0,0 +1,95 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type streamTestSuite struct {
+	suite.Suite
+	handler    func(event int)
+	errHandler func(err error)
+	doneC      chan struct{}
+	stopC      chan struct{}
+}
+
+func TestStream(t *testing.T) {
+	suite.Run(t, new(streamTestSuite))
+}
+
+func (s *streamTestSuite) SetupTest() {
+	s.doneC = make(chan struct{})
+	s.stopC = make(chan struct{})
+}
+
+// serve emulates a websocket stream stopping once stopC is closed
+func (s *streamTestSuite) serve(handler func(event int), errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+	s.handler, s.errHandler = handler, errHandler
+	return s.doneC, s.stopC, nil
+}
+
+func (s *streamTestSuite) TestNext() {
+	stream, err := NewStream(s.serve)
+	s.Require().NoError(err)
+	ctx := context.Background()
+	s.handler(1)
+	s.handler(2)
+	event, err := stream.Next(ctx)
+	s.Require().NoError(err)
+	s.Equal(1, event)
+	s.Equal(2, <-stream.C())
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = stream.Next(timeoutCtx)
+	s.ErrorIs(err, context.DeadlineExceeded)
+
+	// the stream stops on an error
+	connErr := errors.New("connection reset")
+	s.errHandler(connErr)
+	close(s.doneC)
+	_, err = stream.Next(ctx)
+	s.Equal(connErr, err)
+	s.Equal(connErr, stream.Err())
+	<-stream.Done()
+}
+
+func (s *streamTestSuite) TestClose() {
+	stream, err := NewStreamWithBuffer(s.serve, 1)
+	s.Require().NoError(err)
+	s.handler(1)
+
+	// the handler blocks on a full buffer until the stream is closed
+	handledC := make(chan struct{})
+	go func() {
+		s.handler(2)
+		close(handledC)
+	}()
+	select {
+	case <-handledC:
+		s.FailNow("handler not blocked")
+	case <-time.After(10 * time.Millisecond):
+	}
+	stream.Close()
+	stream.Close()
+	<-handledC
+	<-s.stopC
+	close(s.doneC)
+
+	s.Equal(1, <-stream.C())
+	_, err = stream.Next(context.Background())
+	s.Equal(ErrStreamClosed, err)
+	s.NoError(stream.Err())
+}
+
+func (s *streamTestSuite) TestServeError() {
+	serveErr := errors.New("dial failed")
+	_, err := NewStream(func(handler func(event int), errHandler func(err error)) (chan struct{}, chan struct{}, error) {
+		return nil, nil, serveErr
+	})
+	s.Equal(serveErr, err)
+}
@@ -0,0 +1,300 @@
+package common
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// WsReceived records the local time a websocket event was received. It is
+// embedded in the events, so that the latency of the exchange, between the
+// event time and ReceivedAt, can be told from the local processing delays,
+// after ReceivedAt.
+type WsReceived struct {
+	// ReceivedAt is the local time the message of the event was read from
+	// the connection, before being queued by a dispatch policy
+	ReceivedAt time.Time `json:"-"`
+}
+
+// SetReceivedAt sets the receive time of the event
+func (r *WsReceived) SetReceivedAt(t time.Time) {
+	r.ReceivedAt = t
+}
+
+// SetReceivedAt sets the receive time of an event embedding WsReceived, or of
+// every event of a slice of events
+func SetReceivedAt(event interface{}, t time.Time) {
+	if e, ok := event.(interface{ SetReceivedAt(t time.Time) }); ok {
+		e.SetReceivedAt(t)
+		return
+	}
+	v := reflect.ValueOf(event)
+	if v.Kind() != reflect.Slice {
+		return
+	}
+	for i := 0; i < v.Len(); i++ {
+		SetReceivedAt(v.Index(i).Interface(), t)
+	}
+}
+
+// DefaultLatencyBuckets are the upper bounds of the latency histograms of a
+// stream telemetry
+var DefaultLatencyBuckets = []time.Duration{
+	time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// LatencyHistogram counts latencies by bucket
+type LatencyHistogram struct {
+	// Buckets are the upper bounds of the buckets, in increasing order
+	Buckets []time.Duration
+	// Counts are the number of latencies of every bucket, the last count is
+	// the number of latencies above the last bucket
+	Counts []int64
+	// Count is the number of latencies
+	Count int64
+	// Sum is the sum of the latencies
+	Sum time.Duration
+	// Min and Max are the lowest and highest latencies. A negative latency
+	// means the local clock is behind the exchange clock.
+	Min time.Duration
+	Max time.Duration
+}
+
+func newLatencyHistogram(buckets []time.Duration) LatencyHistogram {
+	return LatencyHistogram{Buckets: buckets, Counts: make([]int64, len(buckets)+1)}
+}
+
+func (h *LatencyHistogram) observe(latency time.Duration) {
+	i := sort.Search(len(h.Buckets), func(i int) bool { return latency <= h.Buckets[i] })
+	h.Counts[i]++
+	if h.Count == 0 || latency < h.Min {
+		h.Min = latency
+	}
+	if h.Count == 0 || latency > h.Max {
+		h.Max = latency
+	}
+	h.Count++
+	h.Sum += latency
+}
+
+func (h LatencyHistogram) clone() LatencyHistogram {
+	h.Counts = append([]int64(nil), h.Counts...)
+	return h
+}
+
+// Mean returns the average latency
+func (h LatencyHistogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// Quantile returns the upper bound of the bucket of the q quantile, e.g. 0.99,
+// or Max when above the last bucket
+func (h LatencyHistogram) Quantile(q float64) time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	rank := int64(q * float64(h.Count))
+	if rank < 1 {
+		rank = 1
+	}
+	var n int64
+	for i, count := range h.Counts {
+		n += count
+		if n >= rank {
+			if i < len(h.Buckets) && h.Buckets[i] < h.Max {
+				return h.Buckets[i]
+			}
+			return h.Max
+		}
+	}
+	return h.Max
+}
+
+// StreamStats are the telemetry of a stream
+type StreamStats struct {
+	// Messages is the number of messages received
+	Messages int64
+	// Rate is the number of messages received per second over the rate window
+	Rate float64
+	// LastReceived is the local time the last message was received
+	LastReceived time.Time
+	// Age is the time elapsed since the last message was received
+	Age time.Duration
+	// LastEventTime is the event time of the last message carrying one
+	LastEventTime time.Time
+	// Latency is the histogram of the delays between the event times and the
+	// receive times, corrected by the drift of the local clock when set
+	Latency LatencyHistogram
+}
+
+// StreamTelemetry collects the telemetry of websocket streams by stream name,
+// it may be shared by several streams and is safe for concurrent use
+type StreamTelemetry struct {
+	// Drift returns how far the local clock is ahead of the exchange clock,
+	// e.g. TimeSync.Drift, to correct the latencies. The latencies include
+	// the clock skew when nil.
+	Drift func() time.Duration
+	// Buckets are the upper bounds of the latency histograms, DefaultLatencyBuckets when nil
+	Buckets []time.Duration
+	// RateWindow is the period over which the message rates are measured, one minute when 0
+	RateWindow time.Duration
+
+	now func() time.Time
+
+	mu      sync.Mutex
+	streams map[string]*streamTelemetry
+}
+
+// streamTelemetry are the counters of a stream, the messages are counted per
+// second of the rate window in slots
+type streamTelemetry struct {
+	messages  int64
+	first     time.Time
+	last      time.Time
+	lastEvent time.Time
+	latency   LatencyHistogram
+	slots     []rateSlot
+}
+
+type rateSlot struct {
+	second int64
+	count  int64
+}
+
+// NewStreamTelemetry init an empty stream telemetry
+func NewStreamTelemetry() *StreamTelemetry {
+	return &StreamTelemetry{
+		now:     time.Now,
+		streams: make(map[string]*streamTelemetry),
+	}
+}
+
+func (t *StreamTelemetry) windowSeconds() int64 {
+	w := int64(t.RateWindow / time.Second)
+	if w <= 0 {
+		w = 60
+	}
+	return w
+}
+
+// Record records a message of the stream received at received, eventTime is
+// the event time of the message, zero when it has none. It is called by the
+// streams.
+func (t *StreamTelemetry) Record(stream string, received time.Time, eventTime time.Time) {
+	var drift time.Duration
+	if t.Drift != nil && !eventTime.IsZero() {
+		drift = t.Drift()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	st, ok := t.streams[stream]
+	if !ok {
+		buckets := t.Buckets
+		if buckets == nil {
+			buckets = DefaultLatencyBuckets
+		}
+		st = &streamTelemetry{
+			first:   received,
+			latency: newLatencyHistogram(buckets),
+			slots:   make([]rateSlot, t.windowSeconds()),
+		}
+		t.streams[stream] = st
+	}
+	st.messages++
+	if received.After(st.last) {
+		st.last = received
+	}
+	second := received.Unix()
+	slot := &st.slots[second%int64(len(st.slots))]
+	if slot.second != second {
+		slot.second, slot.count = second, 0
+	}
+	slot.count++
+	if !eventTime.IsZero() {
+		st.lastEvent = eventTime
+		st.latency.observe(received.Sub(eventTime) - drift)
+	}
+}
+
+func (t *StreamTelemetry) statsLocked(st *streamTelemetry, now time.Time) StreamStats {
+	window := int64(len(st.slots))
+	from := now.Unix() - window + 1
+	var count int64
+	for _, slot := range st.slots {
+		if slot.second >= from {
+			count += slot.count
+		}
+	}
+	// the rate of a stream younger than the window is measured since its first message
+	elapsed := now.Sub(st.first).Seconds()
+	if elapsed < 1 {
+		elapsed = 1
+	}
+	if elapsed > float64(window) {
+		elapsed = float64(window)
+	}
+	return StreamStats{
+		Messages:      st.messages,
+		Rate:          float64(count) / elapsed,
+		LastReceived:  st.last,
+		Age:           now.Sub(st.last),
+		LastEventTime: st.lastEvent,
+		Latency:       st.latency.clone(),
+	}
+}
+
+// Stream returns the telemetry of the stream, false when it is unknown
+func (t *StreamTelemetry) Stream(stream string) (StreamStats, bool) {
+	now := t.now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	st, ok := t.streams[stream]
+	if !ok {
+		return StreamStats{}, false
+	}
+	return t.statsLocked(st, now), true
+}
+
+// Streams returns the names of the streams, sorted
+func (t *StreamTelemetry) Streams() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	streams := make([]string, 0, len(t.streams))
+	for stream := range t.streams {
+		streams = append(streams, stream)
+	}
+	sort.Strings(streams)
+	return streams
+}
+
+// Stale returns the streams which received no message for longer than
+// maxAge, sorted, e.g. to alert on a stalled feed
+func (t *StreamTelemetry) Stale(maxAge time.Duration) []string {
+	now := t.now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var stale []string
+	for stream, st := range t.streams {
+		if now.Sub(st.last) > maxAge {
+			stale = append(stale, stream)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
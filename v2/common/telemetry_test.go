@@ -0,0 +1,87 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type telemetryTestSuite struct {
+	suite.Suite
+	telemetry *StreamTelemetry
+	now       time.Time
+}
+
+func TestTelemetry(t *testing.T) {
+	suite.Run(t, new(telemetryTestSuite))
+}
+
+func (s *telemetryTestSuite) SetupTest() {
+	s.now = time.Date(2022, 7, 30, 12, 0, 0, 0, time.UTC)
+	s.telemetry = NewStreamTelemetry()
+	s.telemetry.RateWindow = 10 * time.Second
+	s.telemetry.now = func() time.Time { return s.now }
+}
+
+func (s *telemetryTestSuite) TestRecord() {
+	for i := 0; i < 20; i++ {
+		received := s.now.Add(time.Duration(i) * 500 * time.Millisecond)
+		s.telemetry.Record("btcusdt@trade", received, received.Add(-time.Duration(i+1)*10*time.Millisecond))
+	}
+	s.telemetry.Record("btcusdt@depth", s.now, time.Time{})
+	s.now = s.now.Add(10 * time.Second)
+
+	stats, ok := s.telemetry.Stream("btcusdt@trade")
+	s.Require().True(ok)
+	s.Equal(int64(20), stats.Messages)
+	s.Equal(1.8, stats.Rate)
+	s.Equal(s.now.Add(-500*time.Millisecond), stats.LastReceived)
+	s.Equal(500*time.Millisecond, stats.Age)
+	s.Equal(s.now.Add(-700*time.Millisecond), stats.LastEventTime)
+
+	h := stats.Latency
+	s.Equal(int64(20), h.Count)
+	s.Equal(10*time.Millisecond, h.Min)
+	s.Equal(200*time.Millisecond, h.Max)
+	s.Equal(105*time.Millisecond, h.Mean())
+	s.Equal(100*time.Millisecond, h.Quantile(0.5))
+	s.Equal(200*time.Millisecond, h.Quantile(0.99))
+	s.Equal(int64(1), h.Counts[3])
+
+	stats, ok = s.telemetry.Stream("btcusdt@depth")
+	s.Require().True(ok)
+	s.Equal(int64(1), stats.Messages)
+	s.Equal(int64(0), stats.Latency.Count)
+	s.True(stats.LastEventTime.IsZero())
+
+	s.Equal([]string{"btcusdt@depth", "btcusdt@trade"}, s.telemetry.Streams())
+	s.Equal([]string{"btcusdt@depth"}, s.telemetry.Stale(time.Second))
+	_, ok = s.telemetry.Stream("ethusdt@trade")
+	s.False(ok)
+}
+
+func (s *telemetryTestSuite) TestDrift() {
+	// the local clock is 300ms behind the exchange clock
+	s.telemetry.Drift = func() time.Duration { return -300 * time.Millisecond }
+	s.telemetry.Record("btcusdt@trade", s.now, s.now.Add(250*time.Millisecond))
+	stats, _ := s.telemetry.Stream("btcusdt@trade")
+	s.Equal(50*time.Millisecond, stats.Latency.Min)
+}
+
+func (s *telemetryTestSuite) TestSetReceivedAt() {
+	type event struct {
+		WsReceived
+		Symbol string `json:"s"`
+	}
+	e := new(event)
+	SetReceivedAt(e, s.now)
+	s.Equal(s.now, e.ReceivedAt)
+
+	events := []*event{{Symbol: "BTCUSDT"}, {Symbol: "ETHUSDT"}}
+	SetReceivedAt(events, s.now)
+	for _, e := range events {
+		s.Equal(s.now, e.ReceivedAt)
+	}
+	SetReceivedAt("not an event", s.now)
+}
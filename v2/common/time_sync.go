@@ -0,0 +1,205 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultTimeSyncInterval is the delay between two syncs of a TimeSync whose
+// Interval is not set
+const DefaultTimeSyncInterval = time.Minute
+
+// errNoServerTime is returned by the syncs of a TimeSync not created by NewTimeSync
+var errNoServerTime = errors.New("time sync without server time function")
+
+// ServerTimeFunc returns the server time in milliseconds
+type ServerTimeFunc func(ctx context.Context) (int64, error)
+
+// TimeSync keeps track of the difference between the local clock and the
+// server clock, so that SIGNED requests carry a timestamp within the
+// recvWindow even when the local clock drifts.
+//
+// Every sync samples the server time several times and keeps the sample with
+// the shortest round trip, assuming the server time was read halfway through it.
+type TimeSync struct {
+	// Interval is the delay between two syncs when running in the background,
+	// DefaultTimeSyncInterval when not positive
+	Interval time.Duration
+	// Samples is the number of server time requests made per sync
+	Samples int
+	// OnError is called with the error of a failed background sync, may be nil
+	OnError func(err error)
+
+	serverTime ServerTimeFunc
+	now        func() time.Time
+
+	mu        sync.RWMutex
+	drift     time.Duration
+	roundTrip time.Duration
+	syncedAt  time.Time
+
+	syncMu sync.Mutex
+	stopC  chan struct{}
+	doneC  chan struct{}
+}
+
+// NewTimeSync init a time sync sampling serverTime every minute
+func NewTimeSync(serverTime ServerTimeFunc) *TimeSync {
+	return &TimeSync{
+		Interval:   DefaultTimeSyncInterval,
+		Samples:    3,
+		serverTime: serverTime,
+		now:        time.Now,
+	}
+}
+
+// clock returns the local time
+func (s *TimeSync) clock() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+	return s.now()
+}
+
+// Sync samples the server time and updates the drift
+func (s *TimeSync) Sync(ctx context.Context) error {
+	if s.serverTime == nil {
+		return errNoServerTime
+	}
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	samples := s.Samples
+	if samples < 1 {
+		samples = 1
+	}
+	var (
+		drift     time.Duration
+		roundTrip time.Duration = -1
+	)
+	for i := 0; i < samples; i++ {
+		sent := s.clock()
+		serverTime, err := s.serverTime(ctx)
+		if err != nil {
+			return err
+		}
+		received := s.clock()
+		rtt := received.Sub(sent)
+		if roundTrip >= 0 && rtt >= roundTrip {
+			continue
+		}
+		roundTrip = rtt
+		drift = sent.Add(rtt / 2).Sub(time.Unix(0, serverTime*int64(time.Millisecond)))
+	}
+	s.mu.Lock()
+	s.drift = drift
+	s.roundTrip = roundTrip
+	s.syncedAt = s.clock()
+	s.mu.Unlock()
+	return nil
+}
+
+// Drift returns how far the local clock is ahead of the server clock, negative when behind
+func (s *TimeSync) Drift() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.drift
+}
+
+// Offset returns the drift in milliseconds, to be subtracted from local timestamps
+func (s *TimeSync) Offset() int64 {
+	return s.Drift().Milliseconds()
+}
+
+// RoundTrip returns the round trip of the sample used by the last sync
+func (s *TimeSync) RoundTrip() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.roundTrip
+}
+
+// SyncedAt returns the time of the last successful sync, zero if none
+func (s *TimeSync) SyncedAt() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.syncedAt
+}
+
+// Start syncs once, then keeps syncing every Interval in the background until
+// Stop is called or ctx is done. It returns the error of the first sync.
+func (s *TimeSync) Start(ctx context.Context) error {
+	err := s.Sync(ctx)
+	s.syncMu.Lock()
+	if s.stopC != nil {
+		s.syncMu.Unlock()
+		return err
+	}
+	s.stopC = make(chan struct{})
+	s.doneC = make(chan struct{})
+	stopC, doneC := s.stopC, s.doneC
+	interval := s.Interval
+	if interval <= 0 {
+		interval = DefaultTimeSyncInterval
+	}
+	s.syncMu.Unlock()
+
+	go func() {
+		defer close(doneC)
+		defer func() {
+			// forget the background sync when ctx ends it, so that Start can restart it
+			s.syncMu.Lock()
+			if s.stopC == stopC {
+				s.stopC, s.doneC = nil, nil
+			}
+			s.syncMu.Unlock()
+		}()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopC:
+				return
+			case <-ticker.C:
+				if err := s.Sync(ctx); err != nil && s.OnError != nil {
+					s.OnError(err)
+				}
+			}
+		}
+	}()
+	return err
+}
+
+// Stop stops the background sync started by Start and waits for it to exit
+func (s *TimeSync) Stop() {
+	s.syncMu.Lock()
+	stopC, doneC := s.stopC, s.doneC
+	s.stopC, s.doneC = nil, nil
+	s.syncMu.Unlock()
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+// StartTimeSync stops previous, if any, then starts a time sync of serverTime
+// every interval, DefaultTimeSyncInterval when zero. It returns the error of
+// the first sync, the new time sync being stopped, as done by the
+// StartTimeSync methods of the clients.
+func StartTimeSync(ctx context.Context, previous *TimeSync, serverTime ServerTimeFunc, interval time.Duration) (*TimeSync, error) {
+	if previous != nil {
+		previous.Stop()
+	}
+	ts := NewTimeSync(serverTime)
+	if interval > 0 {
+		ts.Interval = interval
+	}
+	if err := ts.Start(ctx); err != nil {
+		ts.Stop()
+		return nil, err
+	}
+	return ts, nil
+}
@@ -0,0 +1,129 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeSyncSync(t *testing.T) {
+	local := time.Date(2022, 7, 30, 12, 0, 0, 0, time.UTC)
+	// round trips of 300ms, 100ms and 200ms, the server clock is 2s behind
+	roundTrips := []time.Duration{300 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond}
+	i := 0
+	ts := NewTimeSync(func(ctx context.Context) (int64, error) {
+		rtt := roundTrips[i]
+		i++
+		serverTime := local.Add(rtt / 2).Add(-2 * time.Second)
+		local = local.Add(rtt)
+		return toMilliseconds(serverTime), nil
+	})
+	ts.now = func() time.Time { return local }
+
+	require.NoError(t, ts.Sync(context.Background()))
+	assert.Equal(t, 2*time.Second, ts.Drift())
+	assert.Equal(t, int64(2000), ts.Offset())
+	assert.Equal(t, 100*time.Millisecond, ts.RoundTrip())
+	assert.Equal(t, local, ts.SyncedAt())
+}
+
+func TestTimeSyncError(t *testing.T) {
+	ts := NewTimeSync(func(ctx context.Context) (int64, error) {
+		return 0, errors.New("dummy error")
+	})
+	assert.Error(t, ts.Sync(context.Background()))
+	assert.True(t, ts.SyncedAt().IsZero())
+}
+
+func TestTimeSyncStartStop(t *testing.T) {
+	var calls int32
+	ts := NewTimeSync(func(ctx context.Context) (int64, error) {
+		atomic.AddInt32(&calls, 1)
+		return toMilliseconds(time.Now()), nil
+	})
+	ts.Samples = 1
+	ts.Interval = time.Millisecond
+	require.NoError(t, ts.Start(context.Background()))
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) > 2 }, time.Second, time.Millisecond)
+	ts.Stop()
+	n := atomic.LoadInt32(&calls)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, n, atomic.LoadInt32(&calls))
+}
+
+func TestTimeSyncRestartAfterContextDone(t *testing.T) {
+	var calls int32
+	ts := NewTimeSync(func(ctx context.Context) (int64, error) {
+		atomic.AddInt32(&calls, 1)
+		return toMilliseconds(time.Now()), nil
+	})
+	ts.Samples = 1
+	ts.Interval = time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, ts.Start(ctx))
+	cancel()
+	assert.Eventually(t, func() bool {
+		ts.syncMu.Lock()
+		defer ts.syncMu.Unlock()
+		return ts.stopC == nil
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, ts.Start(context.Background()))
+	n := atomic.LoadInt32(&calls)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) > n+2 }, time.Second, time.Millisecond)
+	ts.Stop()
+}
+
+func TestTimeSyncZeroInterval(t *testing.T) {
+	var calls int32
+	ts := NewTimeSync(func(ctx context.Context) (int64, error) {
+		atomic.AddInt32(&calls, 1)
+		return toMilliseconds(time.Now()), nil
+	})
+	ts.Samples = 1
+	ts.Interval = 0
+	require.NoError(t, ts.Start(context.Background()))
+	ts.Stop()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	var zero TimeSync
+	assert.Equal(t, errNoServerTime, zero.Start(context.Background()))
+	zero.Stop()
+}
+
+func TestStartTimeSync(t *testing.T) {
+	var fail int32
+	serverTime := func(ctx context.Context) (int64, error) {
+		if atomic.LoadInt32(&fail) == 1 {
+			return 0, errors.New("dummy error")
+		}
+		return toMilliseconds(time.Now()), nil
+	}
+	previous, err := StartTimeSync(context.Background(), nil, serverTime, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, previous.Interval)
+
+	ts, err := StartTimeSync(context.Background(), previous, serverTime, 0)
+	require.NoError(t, err)
+	defer ts.Stop()
+	assert.Equal(t, DefaultTimeSyncInterval, ts.Interval)
+	// the previous background sync is stopped
+	previous.syncMu.Lock()
+	assert.Nil(t, previous.stopC)
+	previous.syncMu.Unlock()
+
+	atomic.StoreInt32(&fail, 1)
+	failed, err := StartTimeSync(context.Background(), nil, serverTime, time.Hour)
+	assert.Error(t, err)
+	assert.Nil(t, failed)
+}
+
+// toMilliseconds returns the Unix timestamp of t in milliseconds
+func toMilliseconds(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,40 @@
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WsConnOptions define the options of the websocket connections, overriding
+// the settings of the environment and the package level settings. The zero
+// value of a field keeps the default.
+type WsConnOptions struct {
+	// Proxy returns the proxy URL of the connection, overriding the proxy of
+	// the environment. HTTP and SOCKS5 proxies are supported, with the
+	// credentials of the URL, e.g.
+	// http.ProxyURL(&url.URL{Scheme: "http", User: url.UserPassword(user, password), Host: "proxy:3128"})
+	Proxy func(*http.Request) (*url.URL, error)
+	// TLSConfig is used by the connection, overriding the TLS configuration
+	// of the environment
+	TLSConfig *tls.Config
+	// NetDialContext opens the network connections, to the proxy when set,
+	// net.Dialer is used when nil
+	NetDialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+	// HandshakeTimeout bounds the websocket handshake, 45 seconds when 0
+	HandshakeTimeout time.Duration
+	// EnableCompression negotiates the permessage-deflate extension
+	EnableCompression bool
+	// ReadLimit is the maximum size in bytes of a received message, 655350
+	// when 0, e.g. to raise it for the all market streams. The read limit is
+	// disabled when negative.
+	ReadLimit int64
+	// Keepalive is the interval of the ping messages, the connection is
+	// closed when no pong is received within Keepalive. The package level
+	// WebsocketKeepalive and WebsocketTimeout are used when 0, the keepalive
+	// is disabled when negative.
+	Keepalive time.Duration
+}
@@ -41,7 +41,7 @@ func (s *convertTradeTestSuite) TestConvertTradeHistory() {
 	startTime := time.Now().AddDate(0, 0, -7).Unix() * 1000
 	endTime := time.Now().Unix() * 1000
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"startTime": startTime,
 			"endTime":   endTime,
 		})
@@ -0,0 +1,134 @@
+package delivery
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/pooyakn/go-binance/v2/common"
+)
+
+// Environment define the endpoints and the connection settings of a client
+// and of the websocket streams. Unlike the package level settings, several
+// environments, e.g. production and testnet, can be used at the same time.
+type Environment struct {
+	// APIURL is the base URL of the REST API
+	APIURL string
+	// WsURL is the base URL of the raw websocket streams
+	WsURL string
+	// CombinedURL is the base URL of the combined websocket streams
+	CombinedURL string
+	// TLSConfig is used by the websocket connections and the HTTP client of
+	// NewClientWithEnvironment, the default configuration is used when nil
+	TLSConfig *tls.Config
+	// Proxy returns the proxy URL of a request, http.ProxyFromEnvironment is used when nil
+	Proxy func(*http.Request) (*url.URL, error)
+	// Reconnect enables reconnecting the websocket streams after their
+	// connection is lost when set, see common.NewReconnectPolicy
+	Reconnect *common.ReconnectPolicy
+	// Dispatch enables passing the messages of the websocket streams to their
+	// handler through a bounded queue, from another goroutine than the
+	// reads, when set
+	Dispatch *common.DispatchPolicy
+	// WsOptions override the options of the websocket connections when set,
+	// e.g. a custom dialer, compression or a larger read limit
+	WsOptions *common.WsConnOptions
+	// Telemetry records the receive times, the latencies and the rates of the
+	// messages of the websocket streams when set
+	Telemetry *common.StreamTelemetry
+
+	// ctx is the context of the websocket streams, set by WithContext
+	ctx context.Context
+}
+
+// MainnetEnvironment returns the production environment, with the endpoints
+// set by SetAPIEndpoints, SetWsEndpoints and SetWsCombinedEndpoints
+func MainnetEnvironment() *Environment {
+	return &Environment{
+		APIURL:      baseApiMainUrl,
+		WsURL:       baseWsMainUrl,
+		CombinedURL: baseCombinedMainURL,
+	}
+}
+
+// TestnetEnvironment returns the testnet environment, with the endpoints set
+// by SetAPIEndpoints, SetWsEndpoints and SetWsCombinedEndpoints
+func TestnetEnvironment() *Environment {
+	return &Environment{
+		APIURL:      baseApiTestnetUrl,
+		WsURL:       baseWsTestnetUrl,
+		CombinedURL: baseCombinedTestnetURL,
+	}
+}
+
+// DefaultEnvironment returns the environment selected by the UseTestnet flag,
+// with the WebsocketReconnect policy. It is used by NewClient and the package
+// level websocket functions.
+func DefaultEnvironment() *Environment {
+	e := MainnetEnvironment()
+	if UseTestnet {
+		e = TestnetEnvironment()
+	}
+	e.Reconnect = WebsocketReconnect
+	return e
+}
+
+// WithContext returns a copy of the environment whose websocket streams and
+// sessions are dialed with ctx, and stopped once ctx is done, e.g. to tie a
+// stream to the lifetime of a request or a service
+func (e *Environment) WithContext(ctx context.Context) *Environment {
+	e2 := *e
+	e2.ctx = ctx
+	return &e2
+}
+
+// WithWsOptions returns a copy of the environment whose websocket
+// connections are opened with o, e.g. to use another proxy or read limit for
+// some streams
+func (e *Environment) WithWsOptions(o *common.WsConnOptions) *Environment {
+	e2 := *e
+	e2.WsOptions = o
+	return &e2
+}
+
+// Context returns the context of the websocket streams of the environment,
+// context.Background() unless set by WithContext
+func (e *Environment) Context() context.Context {
+	if e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
+}
+
+// HTTPClient returns an HTTP client using the proxy and the TLS configuration
+// of the environment, or http.DefaultClient when none is set
+func (e *Environment) HTTPClient() *http.Client {
+	if e.TLSConfig == nil && e.Proxy == nil {
+		return http.DefaultClient
+	}
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if e.Proxy != nil {
+		tr.Proxy = e.Proxy
+	}
+	if e.TLSConfig != nil {
+		tr.TLSClientConfig = e.TLSConfig
+	}
+	return &http.Client{Transport: tr}
+}
+
+// NewClientWithEnvironment initialize an API client instance with API key and
+// secret key, sending requests and starting websocket streams on env.
+func NewClientWithEnvironment(apiKey, secretKey string, env *Environment) *Client {
+	return &Client{
+		APIKey:      apiKey,
+		SecretKey:   secretKey,
+		BaseURL:     env.APIURL,
+		UserAgent:   "Binance/golang",
+		HTTPClient:  env.HTTPClient(),
+		Logger:      log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+		Environment: env,
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+
+	"github.com/pooyakn/go-binance/v2/common"
 )
 
 // ExchangeInfoService exchange info service
@@ -14,9 +16,9 @@ type ExchangeInfoService struct {
 // Do send request
 func (s *ExchangeInfoService) Do(ctx context.Context, opts ...RequestOption) (res *ExchangeInfo, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/dapi/v1/exchangeInfo",
-		secType:  secTypeNone,
+		Method:   http.MethodGet,
+		Endpoint: "/dapi/v1/exchangeInfo",
+		SecType:  secTypeNone,
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -27,7 +29,9 @@ func (s *ExchangeInfoService) Do(ctx context.Context, opts ...RequestOption) (re
 	if err != nil {
 		return nil, err
 	}
-
+	if s.c.RateLimiter != nil {
+		s.c.RateLimiter.SeedLimits(res.RateLimits)
+	}
 	return res, nil
 }
 
@@ -41,12 +45,7 @@ type ExchangeInfo struct {
 }
 
 // RateLimit struct
-type RateLimit struct {
-	RateLimitType string `json:"rateLimitType"`
-	Interval      string `json:"interval"`
-	IntervalNum   int64  `json:"intervalNum"`
-	Limit         int64  `json:"limit"`
-}
+type RateLimit = common.RateLimit
 
 // Symbol market symbol
 type Symbol struct {
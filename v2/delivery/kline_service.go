@@ -49,19 +49,19 @@ func (s *KlinesService) EndTime(endTime int64) *KlinesService {
 // Do send request
 func (s *KlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*Kline, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/dapi/v1/klines",
+		Method:   http.MethodGet,
+		Endpoint: "/dapi/v1/klines",
 	}
-	r.setParam("symbol", s.symbol)
-	r.setParam("interval", s.interval)
+	r.SetParam("symbol", s.symbol)
+	r.SetParam("interval", s.interval)
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
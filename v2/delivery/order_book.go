@@ -0,0 +1,119 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/pooyakn/go-binance/v2/internal/orderbook"
+)
+
+// ErrOrderBookStopped is returned by OrderBook.WaitSynced once the book stopped
+var ErrOrderBookStopped = orderbook.ErrBookStopped
+
+// ErrOrderBookStarted is returned by OrderBook.Start while the book runs
+var ErrOrderBookStarted = orderbook.ErrBookStarted
+
+// OrderBookUpdate is a change of a local order book
+type OrderBookUpdate = common.OrderBookUpdate
+
+var _ common.OrderBook = (*OrderBook)(nil)
+
+// OrderBookHandler handles the changes of a local order book
+type OrderBookHandler func(update *OrderBookUpdate)
+
+// OrderBook maintains a local order book of a symbol from a depth snapshot
+// and the diff depth stream, as documented by Binance for the futures: the
+// diffs received while fetching the snapshot are buffered, and the book is
+// synchronised again with a new snapshot once the pu field of a diff does not
+// match the last update.
+// Before Start, or once Start failed, the book is empty and stopped.
+type OrderBook struct {
+	orderbook.Managed
+
+	c          *Client
+	symbol     string
+	limit      int
+	rate       *time.Duration
+	handler    OrderBookHandler
+	errHandler ErrHandler
+}
+
+// NewOrderBook init a local order book of symbol, maintained once started
+func (c *Client) NewOrderBook(symbol string) *OrderBook {
+	return &OrderBook{c: c, symbol: symbol, limit: 1000}
+}
+
+// Limit set the depth of the snapshots, 1000 by default
+func (b *OrderBook) Limit(limit int) *OrderBook {
+	b.limit = limit
+	return b
+}
+
+// Rate set the update speed of the diffs, 250ms by default, see WsDiffDepthServeWithRate
+func (b *OrderBook) Rate(rate time.Duration) *OrderBook {
+	b.rate = &rate
+	return b
+}
+
+// OnUpdate set the handler called with every change of the book, once
+// synchronised. It is called from a single goroutine, and may query the book.
+func (b *OrderBook) OnUpdate(handler OrderBookHandler) *OrderBook {
+	b.handler = handler
+	return b
+}
+
+// OnError set the handler called with the errors of the stream and of the snapshots
+func (b *OrderBook) OnError(errHandler ErrHandler) *OrderBook {
+	b.errHandler = errHandler
+	return b
+}
+
+// Start connects the diff depth stream, with the environment of the client,
+// and synchronises the book until Close is called, ctx is done or the stream
+// stopped. The book is synchronised once the first diff is received, see
+// WaitSynced. It returns ErrOrderBookStarted until the previous run is Done.
+func (b *OrderBook) Start(ctx context.Context) error {
+	env := b.c.Environment
+	if env == nil {
+		env = DefaultEnvironment()
+	}
+	return b.Managed.Start(ctx, orderbook.Config{
+		Symbol:       b.symbol,
+		Snapshot:     b.snapshot,
+		PrevUpdateID: true,
+		RetryDelay:   time.Second,
+		OnUpdate:     b.handler,
+		ErrHandler:   b.errHandler,
+		Logger:       WebsocketLogger,
+	}, func(ctx context.Context, push func(d *orderbook.Diff), errHandler func(err error)) (chan struct{}, error) {
+		handler := func(event *WsDepthEvent) {
+			push(&orderbook.Diff{
+				FirstUpdateID:    event.FirstUpdateID,
+				LastUpdateID:     event.LastUpdateID,
+				PrevLastUpdateID: event.PrevLastUpdateID,
+				Bids:             event.Bids,
+				Asks:             event.Asks,
+			})
+		}
+		if b.rate != nil {
+			doneC, _, err := env.WithContext(ctx).WsDiffDepthServeWithRate(b.symbol, b.rate, handler, errHandler)
+			return doneC, err
+		}
+		doneC, _, err := env.WithContext(ctx).WsDiffDepthServe(b.symbol, handler, errHandler)
+		return doneC, err
+	})
+}
+
+func (b *OrderBook) snapshot(ctx context.Context) (*orderbook.Snapshot, error) {
+	res, err := b.c.NewDepthService().Symbol(b.symbol).Limit(b.limit).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &orderbook.Snapshot{LastUpdateID: res.LastUpdateID, Bids: res.Bids, Asks: res.Asks}, nil
+}
+
+// Symbol returns the symbol of the book
+func (b *OrderBook) Symbol() string {
+	return b.symbol
+}
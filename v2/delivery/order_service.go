@@ -125,9 +125,9 @@ func (s *CreateOrderService) ClosePosition(closePosition bool) *CreateOrderServi
 
 func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: endpoint,
-		secType:  secTypeSigned,
+		Method:   http.MethodPost,
+		Endpoint: endpoint,
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"symbol":           s.symbol,
@@ -169,7 +169,7 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 	if s.closePosition != nil {
 		m["closePosition"] = *s.closePosition
 	}
-	r.setFormParams(m)
+	r.SetFormParams(m)
 	data, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []byte{}, err
@@ -241,15 +241,15 @@ func (s *ListOpenOrdersService) Pair(pair string) *ListOpenOrdersService {
 // Do send request
 func (s *ListOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*Order, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/dapi/v1/openOrders",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/dapi/v1/openOrders",
+		SecType:  secTypeSigned,
 	}
 	if s.symbol != "" {
-		r.setParam("symbol", s.symbol)
+		r.SetParam("symbol", s.symbol)
 	}
 	if s.pair != "" {
-		r.setParam("pair", s.symbol)
+		r.SetParam("pair", s.symbol)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -292,16 +292,16 @@ func (s *GetOrderService) OrigClientOrderID(origClientOrderID string) *GetOrderS
 // Do send request
 func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *Order, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/dapi/v1/order",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/dapi/v1/order",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.orderID != nil {
-		r.setParam("orderId", *s.orderID)
+		r.SetParam("orderId", *s.orderID)
 	}
 	if s.origClientOrderID != nil {
-		r.setParam("origClientOrderId", *s.origClientOrderID)
+		r.SetParam("origClientOrderId", *s.origClientOrderID)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -393,27 +393,28 @@ func (s *ListOrdersService) Limit(limit int) *ListOrdersService {
 // Do send request
 func (s *ListOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*Order, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/dapi/v1/allOrders",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/dapi/v1/allOrders",
+		SecType:  secTypeSigned,
 	}
 	if s.symbol != "" {
-		r.setParam("symbol", s.symbol)
+		r.SetParam("symbol", s.symbol)
 	}
 	if s.pair != "" {
-		r.setParam("pair", s.pair)
+		r.SetParam("pair", s.pair)
+		r.Weight = 40
 	}
 	if s.orderID != nil {
-		r.setParam("orderId", *s.orderID)
+		r.SetParam("orderId", *s.orderID)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -456,16 +457,16 @@ func (s *CancelOrderService) OrigClientOrderID(origClientOrderID string) *Cancel
 // Do send request
 func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CancelOrderResponse, err error) {
 	r := &request{
-		method:   http.MethodDelete,
-		endpoint: "/dapi/v1/order",
-		secType:  secTypeSigned,
+		Method:   http.MethodDelete,
+		Endpoint: "/dapi/v1/order",
+		SecType:  secTypeSigned,
 	}
-	r.setFormParam("symbol", s.symbol)
+	r.SetFormParam("symbol", s.symbol)
 	if s.orderID != nil {
-		r.setFormParam("orderId", *s.orderID)
+		r.SetFormParam("orderId", *s.orderID)
 	}
 	if s.origClientOrderID != nil {
-		r.setFormParam("origClientOrderId", *s.origClientOrderID)
+		r.SetFormParam("origClientOrderId", *s.origClientOrderID)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -522,11 +523,11 @@ func (s *CancelAllOpenOrdersService) Symbol(symbol string) *CancelAllOpenOrdersS
 // Do send request
 func (s *CancelAllOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (err error) {
 	r := &request{
-		method:   http.MethodDelete,
-		endpoint: "/dapi/v1/allOpenOrders",
-		secType:  secTypeSigned,
+		Method:   http.MethodDelete,
+		Endpoint: "/dapi/v1/allOpenOrders",
+		SecType:  secTypeSigned,
 	}
-	r.setFormParam("symbol", s.symbol)
+	r.SetFormParam("symbol", s.symbol)
 	_, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return err
@@ -577,24 +578,24 @@ func (s *ListLiquidationOrdersService) Limit(limit int) *ListLiquidationOrdersSe
 // Do send request
 func (s *ListLiquidationOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*LiquidationOrder, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/dapi/v1/allForceOrders",
-		secType:  secTypeNone,
+		Method:   http.MethodGet,
+		Endpoint: "/dapi/v1/allForceOrders",
+		SecType:  secTypeNone,
 	}
 	if s.pair != nil {
-		r.setParam("pair", *s.pair)
+		r.SetParam("pair", *s.pair)
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -64,7 +64,7 @@ func (s *orderServiceTestSuite) TestCreateOrder() {
 	priceProtect := false
 	newOrderResponseType := NewOrderRespTypeRESULT
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":           symbol,
 			"side":             side,
 			"positionSide":     positionSide,
@@ -184,7 +184,7 @@ func (s *orderServiceTestSuite) TestListOpenOrders() {
 	symbol := "BTCUSD_200925"
 	recvWindow := int64(1000)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":     symbol,
 			"recvWindow": recvWindow,
 		})
@@ -283,7 +283,7 @@ func (s *orderServiceTestSuite) TestGetOrder() {
 	orderID := int64(1917641)
 	origClientOrderID := "abc"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":            symbol,
 			"orderId":           orderID,
 			"origClientOrderId": origClientOrderID,
@@ -359,7 +359,7 @@ func (s *orderServiceTestSuite) TestListOrders() {
 	startTime := int64(1499827319559)
 	endTime := int64(1499827319560)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":    symbol,
 			"orderId":   orderID,
 			"startTime": startTime,
@@ -438,7 +438,7 @@ func (s *orderServiceTestSuite) TestCancelOrder() {
 	orderID := int64(283194212)
 	origClientOrderID := "myOrder1"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":            symbol,
 			"orderId":           orderID,
 			"origClientOrderId": origClientOrderID,
@@ -518,7 +518,7 @@ func (s *orderServiceTestSuite) TestCancelAllOpenOrders() {
 
 	symbol := "BTCUSDT"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol": symbol,
 		})
 		s.assertRequestEqual(e, r)
@@ -552,7 +552,7 @@ func (s *orderServiceTestSuite) TestListLiquidationOrders() {
 	endTime := int64(1568014460894)
 	limit := 1
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"symbol":    symbol,
 			"startTime": startTime,
 			"endTime":   endTime,
@@ -28,15 +28,15 @@ func (s *GetPositionRiskService) Pair(pair string) *GetPositionRiskService {
 // Do send request
 func (s *GetPositionRiskService) Do(ctx context.Context, opts ...RequestOption) (res []*PositionRisk, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/dapi/v1/positionRisk",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/dapi/v1/positionRisk",
+		SecType:  secTypeSigned,
 	}
 	if s.marginAsset != nil {
-		r.setParam("marginAsset", *s.marginAsset)
+		r.SetParam("marginAsset", *s.marginAsset)
 	}
 	if s.pair != nil {
-		r.setParam("pair", *s.pair)
+		r.SetParam("pair", *s.pair)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
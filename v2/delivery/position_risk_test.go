@@ -62,7 +62,7 @@ func (s *positionRiskServiceTestSuite) TestGetPositionRisk() {
 	s.mockDo(data, nil)
 	defer s.assertDo()
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"pair": "BTCUSD",
 		})
 		s.assertRequestEqual(e, r)
@@ -0,0 +1,99 @@
+package delivery
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/stretchr/testify/suite"
+)
+
+type streamSessionTestSuite struct {
+	suite.Suite
+	srv *binancetest.Server
+	env *Environment
+
+	mu   sync.Mutex
+	errs []error
+}
+
+func TestStreamSession(t *testing.T) {
+	suite.Run(t, new(streamSessionTestSuite))
+}
+
+func (s *streamSessionTestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.env = &Environment{
+		APIURL:      s.srv.URL,
+		WsURL:       s.srv.WsURL(binancetest.Delivery),
+		CombinedURL: s.srv.CombinedURL(binancetest.Delivery),
+	}
+	s.errs = nil
+}
+
+func (s *streamSessionTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *streamSessionTestSuite) TestSubscribe() {
+	ctx := context.Background()
+	session, err := s.env.NewStreamSession(func(err error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.errs = append(s.errs, err)
+	})
+	s.Require().NoError(err)
+	defer session.Close()
+
+	markPrices := make(chan *WsMarkPriceEvent, 1)
+	s.Require().NoError(session.SubscribeMarkPrice(ctx, "BTCUSD_PERP", func(event *WsMarkPriceEvent) {
+		markPrices <- event
+	}))
+	depths := make(chan *WsDepthEvent, 2)
+	s.Require().NoError(session.SubscribeDiffDepth(ctx, "BTCUSD_PERP", func(event *WsDepthEvent) {
+		depths <- event
+	}))
+	streams, err := session.ListSubscriptions(ctx)
+	s.Require().NoError(err)
+	s.Equal([]string{"btcusd_perp@depth", "btcusd_perp@markPrice"}, streams)
+
+	_, err = s.srv.PlaceOrder(binancetest.Delivery, "BTCUSD_PERP", "SELL", "100", "1")
+	s.Require().NoError(err)
+	depth := <-depths
+	s.Equal("BTCUSD_PERP", depth.Symbol)
+	s.Equal([]Ask{{Price: "100.00000000", Quantity: "1.00000000"}}, depth.Asks)
+
+	s.Require().NoError(session.Unsubscribe(ctx, "btcusd_perp@depth"))
+	s.Equal(0, s.srv.Subscribers(binancetest.Delivery, "btcusd_perp@depth"))
+	_, err = s.srv.PlaceOrder(binancetest.Delivery, "BTCUSD_PERP", "BUY", "100", "0.5")
+	s.Require().NoError(err)
+	_, err = s.srv.Publish(binancetest.Delivery, "btcusd_perp@markPrice", map[string]interface{}{
+		"e": "markPriceUpdate",
+		"s": "BTCUSD_PERP",
+		"p": "100.10000000",
+	})
+	s.Require().NoError(err)
+	select {
+	case markPrice := <-markPrices:
+		s.Equal("100.10000000", markPrice.MarkPrice)
+	case <-time.After(time.Second):
+		s.FailNow("mark price not received")
+	}
+	s.Empty(depths)
+	s.Empty(s.errs)
+}
+
+func (s *streamSessionTestSuite) TestClose() {
+	session, err := s.env.NewStreamSession(func(err error) {})
+	s.Require().NoError(err)
+	session.Close()
+	select {
+	case <-session.Done():
+	case <-time.After(time.Second):
+		s.FailNow("session not closed")
+	}
+	_, err = session.ListSubscriptions(context.Background())
+	s.Equal(ErrSessionClosed, err)
+}
@@ -28,14 +28,14 @@ func (s *ListBookTickersService) Pair(pair string) *ListBookTickersService {
 // Do send request.
 func (s *ListBookTickersService) Do(ctx context.Context, opts ...RequestOption) (res []*BookTicker, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/dapi/v1/ticker/bookTicker",
+		Method:   http.MethodGet,
+		Endpoint: "/dapi/v1/ticker/bookTicker",
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	}
 	if s.pair != nil {
-		r.setParam("pair", *s.pair)
+		r.SetParam("pair", *s.pair)
 	}
 
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -82,14 +82,14 @@ func (s *ListPricesService) Pair(pair string) *ListPricesService {
 // Do send request.
 func (s *ListPricesService) Do(ctx context.Context, opts ...RequestOption) (res []*SymbolPrice, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/dapi/v1/ticker/price",
+		Method:   http.MethodGet,
+		Endpoint: "/dapi/v1/ticker/price",
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	}
 	if s.pair != nil {
-		r.setParam("pair", *s.pair)
+		r.SetParam("pair", *s.pair)
 	}
 
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -133,14 +133,14 @@ func (s *ListPriceChangeStatsService) Pair(pair string) *ListPriceChangeStatsSer
 // Do send request.
 func (s *ListPriceChangeStatsService) Do(ctx context.Context, opts ...RequestOption) (res []*PriceChangeStats, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/dapi/v1/ticker/24hr",
+		Method:   http.MethodGet,
+		Endpoint: "/dapi/v1/ticker/24hr",
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	}
 	if s.pair != nil {
-		r.setParam("pair", *s.pair)
+		r.SetParam("pair", *s.pair)
 	}
 
 	data, err := s.c.callAPI(ctx, r, opts...)
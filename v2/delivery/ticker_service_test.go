@@ -82,7 +82,7 @@ func (s *tickerServiceTestSuite) TestSingleBookTicker() {
 	symbol := "BTCUSD_210625"
 
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbol", symbol)
+		e := newRequest().SetParam("symbol", symbol)
 		s.assertRequestEqual(e, r)
 	})
 
@@ -126,7 +126,7 @@ func (s *tickerServiceTestSuite) TestListBookTickersWithPair() {
 	pair := "ETHUSD"
 
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("pair", pair)
+		e := newRequest().SetParam("pair", pair)
 		s.assertRequestEqual(e, r)
 	})
 	tickers, err := s.client.NewListBookTickersService().Pair("ETHUSD").Do(newContext())
@@ -215,7 +215,7 @@ func (s *tickerServiceTestSuite) TestListSinglePrice() {
 
 	symbol := "BTCUSD_PERP"
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbol", symbol)
+		e := newRequest().SetParam("symbol", symbol)
 		s.assertRequestEqual(e, r)
 	})
 
@@ -249,7 +249,7 @@ func (s *tickerServiceTestSuite) TestListPricesWithPair() {
 
 	pair := "BTCUSD"
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("pair", pair)
+		e := newRequest().SetParam("pair", pair)
 		s.assertRequestEqual(e, r)
 	})
 
@@ -401,7 +401,7 @@ func (s *tickerServiceTestSuite) TestSinglePriceChangeStats() {
 
 	symbol := "BTCUSD_PERP"
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbol", symbol)
+		e := newRequest().SetParam("symbol", symbol)
 		s.assertRequestEqual(e, r)
 	})
 	stats, err := s.client.NewListPriceChangeStatsService().Symbol(symbol).Do(newContext())
@@ -476,7 +476,7 @@ func (s *tickerServiceTestSuite) TestPriceChangeStatsWithPair() {
 
 	pair := "BTCUSD"
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("pair", pair)
+		e := newRequest().SetParam("pair", pair)
 		s.assertRequestEqual(e, r)
 	})
 	stats, err := s.client.NewListPriceChangeStatsService().Pair(pair).Do(newContext())
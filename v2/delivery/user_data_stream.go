@@ -0,0 +1,169 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/internal/userstream"
+)
+
+// UserDataSnapshot is the state of the account fetched by a user data stream
+// once connected, initially and after every reconnection, to reconcile the
+// events missed in the meantime
+type UserDataSnapshot struct {
+	Account    *Account
+	OpenOrders []*Order
+}
+
+// ErrUserDataStreamStarted is returned by UserDataStream.Start while the stream runs
+var ErrUserDataStreamStarted = userstream.ErrStreamStarted
+
+// UserDataSnapshotHandler handles the snapshots of a user data stream
+type UserDataSnapshotHandler func(snapshot *UserDataSnapshot)
+
+// UserDataStream maintains the user data stream of the account: it owns the
+// listen key, keeps it alive, creates a new one once expired, reconnects the
+// stream with the reconnect policy of the environment and fetches a snapshot
+// of the account and of its open orders after every connection
+type UserDataStream struct {
+	c                 *Client
+	handler           WsUserDataHandler
+	errHandler        ErrHandler
+	snapshotHandler   UserDataSnapshotHandler
+	keepaliveInterval time.Duration
+
+	stream *userstream.Stream
+	cancel context.CancelFunc
+}
+
+// NewUserDataStream init the user data stream of the account
+func (c *Client) NewUserDataStream() *UserDataStream {
+	return &UserDataStream{c: c, keepaliveInterval: 30 * time.Minute}
+}
+
+// OnEvent set the handler called with the events of the stream
+func (s *UserDataStream) OnEvent(handler WsUserDataHandler) *UserDataStream {
+	s.handler = handler
+	return s
+}
+
+// OnError set the handler called with the errors of the stream, of the listen
+// key requests and of the snapshots
+func (s *UserDataStream) OnError(errHandler ErrHandler) *UserDataStream {
+	s.errHandler = errHandler
+	return s
+}
+
+// OnSnapshot set the handler called with the snapshot fetched after every
+// connection. The events received while fetching it are handled meanwhile.
+func (s *UserDataStream) OnSnapshot(handler UserDataSnapshotHandler) *UserDataStream {
+	s.snapshotHandler = handler
+	return s
+}
+
+// KeepaliveInterval set the delay between two keepalives of the listen key, 30 minutes by default
+func (s *UserDataStream) KeepaliveInterval(interval time.Duration) *UserDataStream {
+	s.keepaliveInterval = interval
+	return s
+}
+
+// Start creates a listen key and connects its stream, with the environment
+// of the client, then maintains it until Close is called or ctx is done.
+// The listen key is closed once stopped. It returns ErrUserDataStreamStarted
+// until the previous run is Done.
+func (s *UserDataStream) Start(ctx context.Context) error {
+	select {
+	case <-s.stream.Done():
+	default:
+		return ErrUserDataStreamStarted
+	}
+	env := s.c.Environment
+	if env == nil {
+		env = DefaultEnvironment()
+	}
+	errHandler := s.errHandler
+	if errHandler == nil {
+		errHandler = func(err error) {}
+	}
+	handler := s.handler
+	if handler == nil {
+		handler = func(event *WsUserDataEvent) {}
+	}
+	// the stream reconnects itself, as the listen key may have to be renewed
+	policy := env.Reconnect
+	connEnv := *env
+	connEnv.Reconnect = nil
+
+	ctx, cancel := context.WithCancel(ctx)
+	s.stream = userstream.New(userstream.Config{
+		StartListenKey: func(ctx context.Context) (string, error) {
+			return s.c.NewStartUserStreamService().Do(ctx)
+		},
+		KeepaliveListenKey: func(ctx context.Context, listenKey string) error {
+			return s.c.NewKeepaliveUserStreamService().ListenKey(listenKey).Do(ctx)
+		},
+		CloseListenKey: func(ctx context.Context, listenKey string) error {
+			return s.c.NewCloseUserStreamService().ListenKey(listenKey).Do(ctx)
+		},
+		Serve: func(ctx context.Context, listenKey string) (<-chan struct{}, error) {
+			doneC, _, err := connEnv.WithContext(ctx).WsUserDataServe(listenKey, func(event *WsUserDataEvent) {
+				if event.Event == UserDataEventTypeListenKeyExpired {
+					s.stream.Expired(listenKey)
+				}
+				handler(event)
+			}, errHandler)
+			return doneC, err
+		},
+		Snapshot: func(ctx context.Context) {
+			snapshot, err := s.snapshot(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					errHandler(err)
+				}
+				return
+			}
+			if s.snapshotHandler != nil {
+				s.snapshotHandler(snapshot)
+			}
+		},
+		KeepaliveInterval: s.keepaliveInterval,
+		Reconnect:         policy,
+		ErrHandler:        errHandler,
+		Logger:            WebsocketLogger,
+	})
+	s.cancel = cancel
+	if err := s.stream.Start(ctx); err != nil {
+		cancel()
+		return err
+	}
+	return nil
+}
+
+func (s *UserDataStream) snapshot(ctx context.Context) (*UserDataSnapshot, error) {
+	account, err := s.c.NewGetAccountService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := s.c.NewListOpenOrdersService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &UserDataSnapshot{Account: account, OpenOrders: orders}, nil
+}
+
+// ListenKey returns the current listen key of the stream, empty until started
+func (s *UserDataStream) ListenKey() string {
+	return s.stream.ListenKey()
+}
+
+// Close stops the stream and closes its listen key, it does nothing before Start
+func (s *UserDataStream) Close() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// Done returns a channel closed once the stream stopped, or if not started
+func (s *UserDataStream) Done() <-chan struct{} {
+	return s.stream.Done()
+}
@@ -0,0 +1,92 @@
+package delivery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/stretchr/testify/suite"
+)
+
+type userDataStreamTestSuite struct {
+	suite.Suite
+	srv    *binancetest.Server
+	client *Client
+}
+
+func TestUserDataStream(t *testing.T) {
+	suite.Run(t, new(userDataStreamTestSuite))
+}
+
+func (s *userDataStreamTestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.client = NewClientWithEnvironment(s.srv.APIKey, s.srv.SecretKey, &Environment{
+		APIURL: s.srv.URL,
+		WsURL:  s.srv.WsURL(binancetest.Delivery),
+	})
+}
+
+func (s *userDataStreamTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *userDataStreamTestSuite) TestUserDataStream() {
+	ctx := context.Background()
+	s.srv.SetBalance(binancetest.Delivery, "BTC", "1")
+
+	events := make(chan *WsUserDataEvent, 10)
+	snapshots := make(chan *UserDataSnapshot, 10)
+	stream := s.client.NewUserDataStream().OnEvent(func(event *WsUserDataEvent) {
+		events <- event
+	}).OnSnapshot(func(snapshot *UserDataSnapshot) {
+		snapshots <- snapshot
+	}).OnError(func(err error) {
+		s.Fail(err.Error())
+	})
+	s.Require().NoError(stream.Start(ctx))
+	s.Equal(ErrUserDataStreamStarted, stream.Start(ctx))
+	listenKey := stream.ListenKey()
+
+	waitSnapshot := func() *UserDataSnapshot {
+		select {
+		case snapshot := <-snapshots:
+			return snapshot
+		case <-time.After(time.Second):
+			s.FailNow("timeout waiting for a snapshot")
+			return nil
+		}
+	}
+
+	snapshot := waitSnapshot()
+	s.Require().NotNil(snapshot.Account)
+	s.Empty(snapshot.OpenOrders)
+
+	// the listenKeyExpired event is handled, then a new listen key is created
+	s.Equal(1, s.srv.ExpireListenKeys(binancetest.Delivery))
+	select {
+	case event := <-events:
+		s.Equal(UserDataEventTypeListenKeyExpired, event.Event)
+	case <-time.After(time.Second):
+		s.FailNow("timeout waiting for the listenKeyExpired event")
+	}
+	waitSnapshot()
+	s.NotEmpty(stream.ListenKey())
+	s.NotEqual(listenKey, stream.ListenKey())
+
+	// the listen key is closed once stopped
+	stream.Close()
+	select {
+	case <-stream.Done():
+	case <-time.After(time.Second):
+		s.FailNow("stream not stopped")
+	}
+	s.Zero(s.srv.ExpireListenKeys(binancetest.Delivery))
+}
+
+func (s *userDataStreamTestSuite) TestNotStarted() {
+	stream := s.client.NewUserDataStream()
+	s.Empty(stream.ListenKey())
+	stream.Close()
+	<-stream.Done()
+}
@@ -13,9 +13,9 @@ type StartUserStreamService struct {
 // Do send request
 func (s *StartUserStreamService) Do(ctx context.Context, opts ...RequestOption) (listenKey string, err error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: "/dapi/v1/listenKey",
-		secType:  secTypeSigned,
+		Method:   http.MethodPost,
+		Endpoint: "/dapi/v1/listenKey",
+		SecType:  secTypeSigned,
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -44,11 +44,11 @@ func (s *KeepaliveUserStreamService) ListenKey(listenKey string) *KeepaliveUserS
 // Do send request
 func (s *KeepaliveUserStreamService) Do(ctx context.Context, opts ...RequestOption) (err error) {
 	r := &request{
-		method:   http.MethodPut,
-		endpoint: "/dapi/v1/listenKey",
-		secType:  secTypeSigned,
+		Method:   http.MethodPut,
+		Endpoint: "/dapi/v1/listenKey",
+		SecType:  secTypeSigned,
 	}
-	r.setFormParam("listenKey", s.listenKey)
+	r.SetFormParam("listenKey", s.listenKey)
 	_, err = s.c.callAPI(ctx, r, opts...)
 	return err
 }
@@ -68,11 +68,11 @@ func (s *CloseUserStreamService) ListenKey(listenKey string) *CloseUserStreamSer
 // Do send request
 func (s *CloseUserStreamService) Do(ctx context.Context, opts ...RequestOption) (err error) {
 	r := &request{
-		method:   http.MethodDelete,
-		endpoint: "/dapi/v1/listenKey",
-		secType:  secTypeSigned,
+		Method:   http.MethodDelete,
+		Endpoint: "/dapi/v1/listenKey",
+		SecType:  secTypeSigned,
 	}
-	r.setFormParam("listenKey", s.listenKey)
+	r.SetFormParam("listenKey", s.listenKey)
 	_, err = s.c.callAPI(ctx, r, opts...)
 	return err
 }
@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/pooyakn/go-binance/v2/common"
 )
 
 // Endpoints
 var (
-	baseWsMainUrl    = "wss://dstream.binance.com/ws"
-	baseWsTestnetUrl = "wss://dstream.binancefuture.com/ws"
+	baseWsMainUrl          = "wss://dstream.binance.com/ws"
+	baseWsTestnetUrl       = "wss://dstream.binancefuture.com/ws"
+	baseCombinedMainURL    = "wss://dstream.binance.com/stream?streams="
+	baseCombinedTestnetURL = "wss://dstream.binancefuture.com/stream?streams="
 )
 
 var (
@@ -19,6 +23,11 @@ var (
 	WebsocketTimeout = time.Second * 60
 	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
 	WebsocketKeepalive = false
+	// WebsocketLogger logs the websocket connection events, listen keys are redacted
+	WebsocketLogger = common.NewNopLogger()
+	// WebsocketReconnect enables reconnecting the websocket streams of the
+	// default environment when set, e.g. to common.NewReconnectPolicy()
+	WebsocketReconnect *common.ReconnectPolicy
 	// UseTestnet switch all the WS streams from production to the testnet
 	UseTestnet = false
 )
@@ -29,16 +38,54 @@ func SetWsEndpoints(main, testnet string) {
 	baseWsTestnetUrl = testnet
 }
 
-// getWsEndpoint return the base endpoint of the WS according the UseTestnet flag
-func getWsEndpoint() string {
-	if UseTestnet {
-		return baseWsTestnetUrl
+// SetWsCombinedEndpoints set the base endpoint of the combined streams
+func SetWsCombinedEndpoints(main, testnet string) {
+	baseCombinedMainURL = main
+	baseCombinedTestnetURL = testnet
+}
+
+// wsCombinedServe serve the combined streams, the data of their messages is
+// decoded into events of type E
+func wsCombinedServe[E any](e *Environment, streams []string, handler func(event *E), errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(e.CombinedURL + strings.Join(streams, "/"))
+	wsHandler := func(message []byte) {
+		var m wsCombinedMessage
+		err := json.Unmarshal(message, &m)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		event := new(E)
+		err = json.Unmarshal(m.Data, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		cfg.stamp(event)
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// wsCombinedMessage define the message of a combined stream
+type wsCombinedMessage struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// combinedStreams returns the lowercase streams "<symbol><suffix>" of symbols
+func combinedStreams(symbols []string, suffix string) []string {
+	streams := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		streams = append(streams, strings.ToLower(s)+suffix)
 	}
-	return baseWsMainUrl
+	return streams
 }
 
 // WsAggTradeEvent define websocket aggTrde event.
 type WsAggTradeEvent struct {
+	common.WsReceived
+
 	Event            string `json:"e"`
 	Time             int64  `json:"E"`
 	AggregateTradeID int64  `json:"a"`
@@ -56,8 +103,13 @@ type WsAggTradeHandler func(event *WsAggTradeEvent)
 
 // WsAggTradeServe serve websocket that push trade information that is aggregated for a single taker order.
 func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@aggTrade", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAggTradeServe(symbol, handler, errHandler)
+}
+
+// WsAggTradeServe serve websocket that push trade information that is aggregated for a single taker order.
+func (e *Environment) WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@aggTrade", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsAggTradeEvent)
 		err := json.Unmarshal(message, &event)
@@ -65,13 +117,26 @@ func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHan
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
+// WsCombinedAggTradeServe is similar to WsAggTradeServe, but it handles multiple symbols
+func WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedAggTradeServe(symbols, handler, errHandler)
+}
+
+// WsCombinedAggTradeServe is similar to WsAggTradeServe, but it handles multiple symbols
+func (e *Environment) WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedServe(e, combinedStreams(symbols, "@aggTrade"), handler, errHandler)
+}
+
 // WsIndexPriceEvent define websocket indexPriceUpdate event.
 type WsIndexPriceEvent struct {
+	common.WsReceived
+
 	Event      string `json:"e"`
 	Time       int64  `json:"E"`
 	Pair       string `json:"i"`
@@ -83,8 +148,13 @@ type WsIndexPriceHandler func(event *WsIndexPriceEvent)
 
 // WsIndexPriceServe serve websocket that pushes index price for a pair.
 func WsIndexPriceServe(symbol string, handler WsIndexPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@indexPrice", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsIndexPriceServe(symbol, handler, errHandler)
+}
+
+// WsIndexPriceServe serve websocket that pushes index price for a pair.
+func (e *Environment) WsIndexPriceServe(symbol string, handler WsIndexPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@indexPrice", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsIndexPriceEvent)
 		err := json.Unmarshal(message, &event)
@@ -92,13 +162,26 @@ func WsIndexPriceServe(symbol string, handler WsIndexPriceHandler, errHandler Er
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
+// WsCombinedIndexPriceServe is similar to WsIndexPriceServe, but it handles multiple pairs
+func WsCombinedIndexPriceServe(pairs []string, handler WsIndexPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedIndexPriceServe(pairs, handler, errHandler)
+}
+
+// WsCombinedIndexPriceServe is similar to WsIndexPriceServe, but it handles multiple pairs
+func (e *Environment) WsCombinedIndexPriceServe(pairs []string, handler WsIndexPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedServe(e, combinedStreams(pairs, "@indexPrice"), handler, errHandler)
+}
+
 // WsMarkPriceEvent define websocket markPriceUpdate event.
 type WsMarkPriceEvent struct {
+	common.WsReceived
+
 	Event                string `json:"e"`
 	Time                 int64  `json:"E"`
 	Symbol               string `json:"s"`
@@ -113,8 +196,13 @@ type WsMarkPriceHandler func(event *WsMarkPriceEvent)
 
 // WsMarkPriceServe serve websocket that pushes price and funding rate for a single symbol.
 func WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@markPrice", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsMarkPriceServe(symbol, handler, errHandler)
+}
+
+// WsMarkPriceServe serve websocket that pushes price and funding rate for a single symbol.
+func (e *Environment) WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@markPrice", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarkPriceEvent)
 		err := json.Unmarshal(message, &event)
@@ -122,11 +210,22 @@ func WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrH
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
+// WsCombinedMarkPriceServe is similar to WsMarkPriceServe, but it handles multiple symbols
+func WsCombinedMarkPriceServe(symbols []string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedMarkPriceServe(symbols, handler, errHandler)
+}
+
+// WsCombinedMarkPriceServe is similar to WsMarkPriceServe, but it handles multiple symbols
+func (e *Environment) WsCombinedMarkPriceServe(symbols []string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedServe(e, combinedStreams(symbols, "@markPrice"), handler, errHandler)
+}
+
 // WsPairMarkPriceEvent defines an array of websocket markPriceUpdate events.
 type WsPairMarkPriceEvent []*WsMarkPriceEvent
 
@@ -135,8 +234,13 @@ type WsPairMarkPriceHandler func(event WsPairMarkPriceEvent)
 
 // WsPairMarkPriceServe serve websocket that pushes price and funding rate for all symbol.
 func WsPairMarkPriceServe(handler WsPairMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/markPrice@arr", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsPairMarkPriceServe(handler, errHandler)
+}
+
+// WsPairMarkPriceServe serve websocket that pushes price and funding rate for all symbol.
+func (e *Environment) WsPairMarkPriceServe(handler WsPairMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/markPrice@arr", e.WsURL)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsPairMarkPriceEvent
 		err := json.Unmarshal(message, &event)
@@ -144,6 +248,7 @@ func WsPairMarkPriceServe(handler WsPairMarkPriceHandler, errHandler ErrHandler)
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -151,6 +256,8 @@ func WsPairMarkPriceServe(handler WsPairMarkPriceHandler, errHandler ErrHandler)
 
 // WsKlineEvent define websocket kline event
 type WsKlineEvent struct {
+	common.WsReceived
+
 	Event  string  `json:"e"`
 	Time   int64   `json:"E"`
 	Symbol string  `json:"s"`
@@ -182,8 +289,13 @@ type WsKlineHandler func(event *WsKlineEvent)
 
 // WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
 func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@kline_%s", getWsEndpoint(), strings.ToLower(symbol), interval)
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
+func (e *Environment) WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@kline_%s", e.WsURL, strings.ToLower(symbol), interval)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsKlineEvent)
 		err := json.Unmarshal(message, event)
@@ -191,13 +303,30 @@ func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHan
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
+// WsCombinedKlineServe is similar to WsKlineServe, but it handles multiple symbols with it interval
+func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedKlineServe(symbolIntervalPair, handler, errHandler)
+}
+
+// WsCombinedKlineServe is similar to WsKlineServe, but it handles multiple symbols with it interval
+func (e *Environment) WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	streams := make([]string, 0, len(symbolIntervalPair))
+	for symbol, interval := range symbolIntervalPair {
+		streams = append(streams, fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval))
+	}
+	return wsCombinedServe(e, streams, handler, errHandler)
+}
+
 // WsContinuousKlineEvent define websocket continuous kline event
 type WsContinuousKlineEvent struct {
+	common.WsReceived
+
 	Event        string            `json:"e"`
 	Time         int64             `json:"E"`
 	Pair         string            `json:"ps"`
@@ -229,8 +358,13 @@ type WsContinuousKlineHandler func(event *WsContinuousKlineEvent)
 
 // WsContinuousKlineServe serve websocket kline handler with a pair, a contract type and interval like 15m, 30s
 func WsContinuousKlineServe(pair string, contractType string, interval string, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s_%s@continuousKline_%s", getWsEndpoint(), strings.ToLower(pair), strings.ToLower(contractType), interval)
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsContinuousKlineServe(pair, contractType, interval, handler, errHandler)
+}
+
+// WsContinuousKlineServe serve websocket kline handler with a pair, a contract type and interval like 15m, 30s
+func (e *Environment) WsContinuousKlineServe(pair string, contractType string, interval string, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s_%s@continuousKline_%s", e.WsURL, strings.ToLower(pair), strings.ToLower(contractType), interval)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsContinuousKlineEvent)
 		err := json.Unmarshal(message, event)
@@ -238,13 +372,38 @@ func WsContinuousKlineServe(pair string, contractType string, interval string, h
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
+// WsContinuousKlineSubcribeArgs used with WsCombinedContinuousKlineServe
+type WsContinuousKlineSubcribeArgs struct {
+	Pair         string
+	ContractType string
+	Interval     string
+}
+
+// WsCombinedContinuousKlineServe is similar to WsContinuousKlineServe, but it handles multiple pairs of different contractType with its interval
+func WsCombinedContinuousKlineServe(subscribeArgsList []*WsContinuousKlineSubcribeArgs, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedContinuousKlineServe(subscribeArgsList, handler, errHandler)
+}
+
+// WsCombinedContinuousKlineServe is similar to WsContinuousKlineServe, but it handles multiple pairs of different contractType with its interval
+func (e *Environment) WsCombinedContinuousKlineServe(subscribeArgsList []*WsContinuousKlineSubcribeArgs, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	streams := make([]string, 0, len(subscribeArgsList))
+	for _, args := range subscribeArgsList {
+		streams = append(streams, fmt.Sprintf("%s_%s@continuousKline_%s", strings.ToLower(args.Pair),
+			strings.ToLower(args.ContractType), args.Interval))
+	}
+	return wsCombinedServe(e, streams, handler, errHandler)
+}
+
 // WsIndexPriceKlineEvent define websocket index price kline event
 type WsIndexPriceKlineEvent struct {
+	common.WsReceived
+
 	Event string            `json:"e"`
 	Time  int64             `json:"E"`
 	Pair  string            `json:"ps"`
@@ -270,8 +429,13 @@ type WsIndexPriceKlineHandler func(event *WsIndexPriceKlineEvent)
 
 // WsIndexPriceKlineServe serve websocket kline handler with a pair and interval like 15m, 30s
 func WsIndexPriceKlineServe(pair string, interval string, handler WsIndexPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@indexPriceKline_%s", getWsEndpoint(), strings.ToLower(pair), interval)
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsIndexPriceKlineServe(pair, interval, handler, errHandler)
+}
+
+// WsIndexPriceKlineServe serve websocket kline handler with a pair and interval like 15m, 30s
+func (e *Environment) WsIndexPriceKlineServe(pair string, interval string, handler WsIndexPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@indexPriceKline_%s", e.WsURL, strings.ToLower(pair), interval)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsIndexPriceKlineEvent)
 		err := json.Unmarshal(message, event)
@@ -279,13 +443,30 @@ func WsIndexPriceKlineServe(pair string, interval string, handler WsIndexPriceKl
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
+// WsCombinedIndexPriceKlineServe is similar to WsIndexPriceKlineServe, but it handles multiple pairs with it interval
+func WsCombinedIndexPriceKlineServe(pairIntervalPair map[string]string, handler WsIndexPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedIndexPriceKlineServe(pairIntervalPair, handler, errHandler)
+}
+
+// WsCombinedIndexPriceKlineServe is similar to WsIndexPriceKlineServe, but it handles multiple pairs with it interval
+func (e *Environment) WsCombinedIndexPriceKlineServe(pairIntervalPair map[string]string, handler WsIndexPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	streams := make([]string, 0, len(pairIntervalPair))
+	for pair, interval := range pairIntervalPair {
+		streams = append(streams, fmt.Sprintf("%s@indexPriceKline_%s", strings.ToLower(pair), interval))
+	}
+	return wsCombinedServe(e, streams, handler, errHandler)
+}
+
 // WsMarkPriceKlineEvent define websocket market price kline event
 type WsMarkPriceKlineEvent struct {
+	common.WsReceived
+
 	Event string           `json:"e"`
 	Time  int64            `json:"E"`
 	Pair  string           `json:"ps"`
@@ -312,8 +493,13 @@ type WsMarkPriceKlineHandler func(event *WsMarkPriceKlineEvent)
 
 // WsMarkPriceKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
 func WsMarkPriceKlineServe(symbol string, interval string, handler WsMarkPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@markPriceKline_%s", getWsEndpoint(), strings.ToLower(symbol), interval)
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsMarkPriceKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsMarkPriceKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
+func (e *Environment) WsMarkPriceKlineServe(symbol string, interval string, handler WsMarkPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@markPriceKline_%s", e.WsURL, strings.ToLower(symbol), interval)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarkPriceKlineEvent)
 		err := json.Unmarshal(message, event)
@@ -321,13 +507,30 @@ func WsMarkPriceKlineServe(symbol string, interval string, handler WsMarkPriceKl
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
+// WsCombinedMarkPriceKlineServe is similar to WsMarkPriceKlineServe, but it handles multiple symbols with it interval
+func WsCombinedMarkPriceKlineServe(symbolIntervalPair map[string]string, handler WsMarkPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedMarkPriceKlineServe(symbolIntervalPair, handler, errHandler)
+}
+
+// WsCombinedMarkPriceKlineServe is similar to WsMarkPriceKlineServe, but it handles multiple symbols with it interval
+func (e *Environment) WsCombinedMarkPriceKlineServe(symbolIntervalPair map[string]string, handler WsMarkPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	streams := make([]string, 0, len(symbolIntervalPair))
+	for symbol, interval := range symbolIntervalPair {
+		streams = append(streams, fmt.Sprintf("%s@markPriceKline_%s", strings.ToLower(symbol), interval))
+	}
+	return wsCombinedServe(e, streams, handler, errHandler)
+}
+
 // WsMiniMarketTickerEvent define websocket mini market ticker event.
 type WsMiniMarketTickerEvent struct {
+	common.WsReceived
+
 	Event       string `json:"e"`
 	Time        int64  `json:"E"`
 	Symbol      string `json:"s"`
@@ -345,8 +548,13 @@ type WsMiniMarketTickerHandler func(event *WsMiniMarketTickerEvent)
 
 // WsMiniMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
 func WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@miniTicker", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsMiniMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsMiniMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
+func (e *Environment) WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@miniTicker", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMiniMarketTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -354,11 +562,22 @@ func WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, e
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
+// WsCombinedMiniMarketTickerServe is similar to WsMiniMarketTickerServe, but it handles multiple symbols
+func WsCombinedMiniMarketTickerServe(symbols []string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedMiniMarketTickerServe(symbols, handler, errHandler)
+}
+
+// WsCombinedMiniMarketTickerServe is similar to WsMiniMarketTickerServe, but it handles multiple symbols
+func (e *Environment) WsCombinedMiniMarketTickerServe(symbols []string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedServe(e, combinedStreams(symbols, "@miniTicker"), handler, errHandler)
+}
+
 // WsAllMiniMarketTickerEvent define an array of websocket mini market ticker events.
 type WsAllMiniMarketTickerEvent []*WsMiniMarketTickerEvent
 
@@ -367,8 +586,13 @@ type WsAllMiniMarketTickerHandler func(event WsAllMiniMarketTickerEvent)
 
 // WsAllMiniMarketTickerServe serve websocket that pushes price and funding rate for all markets.
 func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!miniTicker@arr", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAllMiniMarketTickerServe(handler, errHandler)
+}
+
+// WsAllMiniMarketTickerServe serve websocket that pushes price and funding rate for all markets.
+func (e *Environment) WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!miniTicker@arr", e.WsURL)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMiniMarketTickerEvent
 		err := json.Unmarshal(message, &event)
@@ -376,6 +600,7 @@ func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -383,6 +608,8 @@ func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler
 
 // WsMarketTickerEvent define websocket market ticker event.
 type WsMarketTickerEvent struct {
+	common.WsReceived
+
 	Event              string `json:"e"`
 	Time               int64  `json:"E"`
 	Symbol             string `json:"s"`
@@ -409,8 +636,13 @@ type WsMarketTickerHandler func(event *WsMarketTickerEvent)
 
 // WsMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
 func WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@ticker", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
+func (e *Environment) WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@ticker", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarketTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -418,11 +650,22 @@ func WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandle
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
+// WsCombinedMarketTickerServe is similar to WsMarketTickerServe, but it handles multiple symbols
+func WsCombinedMarketTickerServe(symbols []string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedMarketTickerServe(symbols, handler, errHandler)
+}
+
+// WsCombinedMarketTickerServe is similar to WsMarketTickerServe, but it handles multiple symbols
+func (e *Environment) WsCombinedMarketTickerServe(symbols []string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedServe(e, combinedStreams(symbols, "@ticker"), handler, errHandler)
+}
+
 // WsAllMarketTickerEvent define an array of websocket mini ticker events.
 type WsAllMarketTickerEvent []*WsMarketTickerEvent
 
@@ -431,8 +674,13 @@ type WsAllMarketTickerHandler func(event WsAllMarketTickerEvent)
 
 // WsAllMarketTickerServe serve websocket that pushes price and funding rate for all markets.
 func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!ticker@arr", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAllMarketTickerServe(handler, errHandler)
+}
+
+// WsAllMarketTickerServe serve websocket that pushes price and funding rate for all markets.
+func (e *Environment) WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!ticker@arr", e.WsURL)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarketTickerEvent
 		err := json.Unmarshal(message, &event)
@@ -440,6 +688,7 @@ func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHand
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -447,6 +696,8 @@ func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHand
 
 // WsBookTickerEvent define websocket best book ticker event.
 type WsBookTickerEvent struct {
+	common.WsReceived
+
 	Event           string `json:"e"`
 	UpdateID        int64  `json:"u"`
 	Symbol          string `json:"s"`
@@ -464,8 +715,13 @@ type WsBookTickerHandler func(event *WsBookTickerEvent)
 
 // WsBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for a specified symbol.
 func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@bookTicker", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsBookTickerServe(symbol, handler, errHandler)
+}
+
+// WsBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for a specified symbol.
+func (e *Environment) WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@bookTicker", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -473,15 +729,31 @@ func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler Er
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
+// WsCombinedBookTickerServe is similar to WsBookTickerServe, but it handles multiple symbols
+func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedBookTickerServe(symbols, handler, errHandler)
+}
+
+// WsCombinedBookTickerServe is similar to WsBookTickerServe, but it handles multiple symbols
+func (e *Environment) WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedServe(e, combinedStreams(symbols, "@bookTicker"), handler, errHandler)
+}
+
 // WsAllBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for all symbols.
 func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!bookTicker", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAllBookTickerServe(handler, errHandler)
+}
+
+// WsAllBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for all symbols.
+func (e *Environment) WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!bookTicker", e.WsURL)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -489,6 +761,7 @@ func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (d
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -496,6 +769,8 @@ func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (d
 
 // WsLiquidationOrderEvent define websocket liquidation order event.
 type WsLiquidationOrderEvent struct {
+	common.WsReceived
+
 	Event            string             `json:"e"`
 	Time             int64              `json:"E"`
 	LiquidationOrder WsLiquidationOrder `json:"o"`
@@ -522,8 +797,13 @@ type WsLiquidationOrderHandler func(event *WsLiquidationOrderEvent)
 
 // WsLiquidationOrderServe serve websocket that pushes force liquidation order information for specific symbol.
 func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@forceOrder", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsLiquidationOrderServe(symbol, handler, errHandler)
+}
+
+// WsLiquidationOrderServe serve websocket that pushes force liquidation order information for specific symbol.
+func (e *Environment) WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@forceOrder", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
 		err := json.Unmarshal(message, &event)
@@ -531,6 +811,7 @@ func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, e
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -538,8 +819,13 @@ func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, e
 
 // WsAllLiquidationOrderServe serve websocket that pushes force liquidation order information for all symbols.
 func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!forceOrder@arr", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAllLiquidationOrderServe(handler, errHandler)
+}
+
+// WsAllLiquidationOrderServe serve websocket that pushes force liquidation order information for all symbols.
+func (e *Environment) WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!forceOrder@arr", e.WsURL)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
 		err := json.Unmarshal(message, &event)
@@ -547,6 +833,7 @@ func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler Er
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -554,6 +841,8 @@ func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler Er
 
 // WsDepthEvent define websocket depth book event
 type WsDepthEvent struct {
+	common.WsReceived
+
 	Event            string `json:"e"`
 	Time             int64  `json:"E"`
 	TransactionTime  int64  `json:"T"`
@@ -569,35 +858,55 @@ type WsDepthEvent struct {
 // WsDepthHandler handle websocket depth event
 type WsDepthHandler func(event *WsDepthEvent)
 
-func wsPartialDepthServe(symbol string, levels int, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func (e *Environment) wsPartialDepthServe(symbol string, levels int, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	if levels != 5 && levels != 10 && levels != 20 {
 		return nil, nil, errors.New("Invalid levels")
 	}
 	levelsStr := fmt.Sprintf("%d", levels)
-	return wsDepthServe(symbol, levelsStr, rate, handler, errHandler)
+	return e.wsDepthServe(symbol, levelsStr, rate, handler, errHandler)
 }
 
 // WsPartialDepthServe serve websocket partial depth handler.
 func WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsPartialDepthServe(symbol, levels, nil, handler, errHandler)
+	return DefaultEnvironment().WsPartialDepthServe(symbol, levels, handler, errHandler)
+}
+
+// WsPartialDepthServe serve websocket partial depth handler.
+func (e *Environment) WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsPartialDepthServe(symbol, levels, nil, handler, errHandler)
 }
 
 // WsPartialDepthServeWithRate serve websocket partial depth handler with rate.
 func WsPartialDepthServeWithRate(symbol string, levels int, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsPartialDepthServe(symbol, levels, rate, handler, errHandler)
+	return DefaultEnvironment().WsPartialDepthServeWithRate(symbol, levels, rate, handler, errHandler)
+}
+
+// WsPartialDepthServeWithRate serve websocket partial depth handler with rate.
+func (e *Environment) WsPartialDepthServeWithRate(symbol string, levels int, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsPartialDepthServe(symbol, levels, rate, handler, errHandler)
 }
 
 // WsDiffDepthServe serve websocket diff. depth handler.
 func WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsDepthServe(symbol, "", nil, handler, errHandler)
+	return DefaultEnvironment().WsDiffDepthServe(symbol, handler, errHandler)
+}
+
+// WsDiffDepthServe serve websocket diff. depth handler.
+func (e *Environment) WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsDepthServe(symbol, "", nil, handler, errHandler)
 }
 
 // WsDiffDepthServe serve websocket diff. depth handler with rate.
 func WsDiffDepthServeWithRate(symbol string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsDepthServe(symbol, "", rate, handler, errHandler)
+	return DefaultEnvironment().WsDiffDepthServeWithRate(symbol, rate, handler, errHandler)
+}
+
+// WsDiffDepthServe serve websocket diff. depth handler with rate.
+func (e *Environment) WsDiffDepthServeWithRate(symbol string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsDepthServe(symbol, "", rate, handler, errHandler)
 }
 
-func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func (e *Environment) wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	var rateStr string
 	if rate != nil {
 		switch *rate {
@@ -612,8 +921,8 @@ func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsD
 		}
 	}
 
-	endpoint := fmt.Sprintf("%s/%s@depth%s%s", getWsEndpoint(), strings.ToLower(symbol), levels, rateStr)
-	cfg := newWsConfig(endpoint)
+	endpoint := fmt.Sprintf("%s/%s@depth%s%s", e.WsURL, strings.ToLower(symbol), levels, rateStr)
+	cfg := e.newWsConfig(endpoint)
 
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
@@ -648,13 +957,44 @@ func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsD
 				Quantity: item.GetIndex(1).MustString(),
 			}
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
+// WsCombinedDepthServe serve the partial depth streams of several symbols,
+// symbolLevels maps each symbol to its levels, "5", "10" or "20", optionally
+// followed by the update speed, e.g. "20@500ms"
+func WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedDepthServe(symbolLevels, handler, errHandler)
+}
+
+// WsCombinedDepthServe serve the partial depth streams of several symbols,
+// symbolLevels maps each symbol to its levels, "5", "10" or "20", optionally
+// followed by the update speed, e.g. "20@500ms"
+func (e *Environment) WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	streams := make([]string, 0, len(symbolLevels))
+	for s, l := range symbolLevels {
+		streams = append(streams, fmt.Sprintf("%s@depth%s", strings.ToLower(s), l))
+	}
+	return wsCombinedServe(e, streams, handler, errHandler)
+}
+
+// WsCombinedDiffDepthServe is similar to WsDiffDepthServe, but it for multiple symbols
+func WsCombinedDiffDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedDiffDepthServe(symbols, handler, errHandler)
+}
+
+// WsCombinedDiffDepthServe is similar to WsDiffDepthServe, but it for multiple symbols
+func (e *Environment) WsCombinedDiffDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedServe(e, combinedStreams(symbols, "@depth"), handler, errHandler)
+}
+
 // WsUserDataEvent define user data event
 type WsUserDataEvent struct {
+	common.WsReceived
+
 	Event               UserDataEventType  `json:"e"`
 	Time                int64              `json:"E"`
 	Alias               string             `json:"i"`
@@ -741,8 +1081,13 @@ type WsUserDataHandler func(event *WsUserDataEvent)
 
 // WsUserDataServe serve user data handler with listen key
 func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsUserDataServe(listenKey, handler, errHandler)
+}
+
+// WsUserDataServe serve user data handler with listen key
+func (e *Environment) WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", e.WsURL, listenKey)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsUserDataEvent)
 		err := json.Unmarshal(message, event)
@@ -750,6 +1095,7 @@ func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler Err
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
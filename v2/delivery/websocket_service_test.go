@@ -1218,6 +1218,254 @@ func (s *websocketServiceTestSuite) assertDepthEvent(e, a *WsDepthEvent) {
 	}
 }
 
+func (s *websocketServiceTestSuite) TestCombinedAggTradeServe() {
+	data := []byte(`{
+		"stream":"btcusd_perp@aggTrade",
+		"data":{
+			"e":"aggTrade",
+			"E":1591261134288,
+			"a":424951,
+			"s":"BTCUSD_PERP",
+			"p":"9643.5",
+			"q":"2",
+			"f":606073,
+			"l":606073,
+			"T":1591261134199,
+			"m":false
+		}
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsCombinedAggTradeServe([]string{"BTCUSD_PERP"}, func(event *WsAggTradeEvent) {
+		e := &WsAggTradeEvent{
+			Event:            "aggTrade",
+			Time:             1591261134288,
+			AggregateTradeID: 424951,
+			Symbol:           "BTCUSD_PERP",
+			Price:            "9643.5",
+			Quantity:         "2",
+			FirstTradeID:     606073,
+			LastTradeID:      606073,
+			TradeTime:        1591261134199,
+			Maker:            false,
+		}
+		s.assertWsAggTradeEvent(e, event)
+	},
+		func(err error) {
+			s.r().EqualError(err, fakeErrMsg)
+		})
+
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestCombinedMarkPriceServe() {
+	data := []byte(`{
+		"stream":"btcusd_200626@markPrice",
+		"data":{
+			"e":"markPriceUpdate",
+			"E":1596095725000,
+			"s":"BTCUSD_200626",
+			"p":"11012.31074109",
+			"P":"11015.12345678",
+			"r":"",
+			"T":0
+		}
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsCombinedMarkPriceServe([]string{"BTCUSD_200626"}, func(event *WsMarkPriceEvent) {
+		e := &WsMarkPriceEvent{
+			Event:                "markPriceUpdate",
+			Time:                 1596095725000,
+			Symbol:               "BTCUSD_200626",
+			MarkPrice:            "11012.31074109",
+			EstimatedSettlePrice: "11015.12345678",
+		}
+		s.assertWsMarkPriceEvent(e, event)
+	},
+		func(err error) {
+			s.r().EqualError(err, fakeErrMsg)
+		})
+
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestCombinedContinuousKlineServe() {
+	data := []byte(`{
+		"stream":"btcusd_current_quarter@continuousKline_1m",
+		"data":{
+			"e":"continuous_kline",
+			"E":1591261542539,
+			"ps":"BTCUSD",
+			"ct":"CURRENT_QUARTER",
+			"k":{
+				"t":1591261500000,
+				"T":1591261559999,
+				"i":"1m",
+				"f":606400,
+				"L":606430,
+				"o":"9638.9",
+				"c":"9639.8",
+				"h":"9639.8",
+				"l":"9638.6",
+				"v":"156",
+				"n":30,
+				"x":false,
+				"q":"1.61836886",
+				"V":"73",
+				"Q":"0.75731156"
+			}
+		}
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	args := []*WsContinuousKlineSubcribeArgs{{Pair: "BTCUSD", ContractType: "CURRENT_QUARTER", Interval: "1m"}}
+	doneC, stopC, err := WsCombinedContinuousKlineServe(args, func(event *WsContinuousKlineEvent) {
+		e := &WsContinuousKlineEvent{
+			Event:        "continuous_kline",
+			Time:         1591261542539,
+			Pair:         "BTCUSD",
+			ContractType: "CURRENT_QUARTER",
+			Kline: WsContinuousKline{
+				StartTime:            1591261500000,
+				EndTime:              1591261559999,
+				Interval:             "1m",
+				FirstTradeID:         606400,
+				LastTradeID:          606430,
+				Open:                 "9638.9",
+				Close:                "9639.8",
+				High:                 "9639.8",
+				Low:                  "9638.6",
+				Volume:               "156",
+				TradeNum:             30,
+				IsFinal:              false,
+				QuoteVolume:          "1.61836886",
+				ActiveBuyVolume:      "73",
+				ActiveBuyQuoteVolume: "0.75731156",
+			},
+		}
+		s.assertWsContinuousKlineEventEqual(e, event)
+	},
+		func(err error) {
+			s.r().EqualError(err, fakeErrMsg)
+		})
+
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) testCombinedDepthServe(serve func(handler WsDepthHandler, errHandler ErrHandler) (chan struct{}, chan struct{}, error)) {
+	data := []byte(`{
+		"stream":"btcusd_200626@depth5",
+		"data":{
+			"e":"depthUpdate",
+			"E":1591269996801,
+			"T":1591269996646,
+			"s":"BTCUSD_200626",
+			"ps":"BTCUSD",
+			"U":17276694,
+			"u":17276701,
+			"pu":17276678,
+			"b":[["9523.0","5"]],
+			"a":[["9524.6","9"]]
+		}
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := serve(func(event *WsDepthEvent) {
+		e := &WsDepthEvent{
+			Event:            "depthUpdate",
+			Time:             1591269996801,
+			TransactionTime:  1591269996646,
+			Symbol:           "BTCUSD_200626",
+			Pair:             "BTCUSD",
+			FirstUpdateID:    17276694,
+			LastUpdateID:     17276701,
+			PrevLastUpdateID: 17276678,
+			Bids:             []Bid{{Price: "9523.0", Quantity: "5"}},
+			Asks:             []Ask{{Price: "9524.6", Quantity: "9"}},
+		}
+		s.assertDepthEvent(e, event)
+	},
+		func(err error) {
+			s.r().EqualError(err, fakeErrMsg)
+		})
+
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestCombinedDepthServe() {
+	s.testCombinedDepthServe(func(handler WsDepthHandler, errHandler ErrHandler) (chan struct{}, chan struct{}, error) {
+		return WsCombinedDepthServe(map[string]string{"BTCUSD_200626": "5"}, handler, errHandler)
+	})
+}
+
+func (s *websocketServiceTestSuite) TestCombinedDiffDepthServe() {
+	s.testCombinedDepthServe(func(handler WsDepthHandler, errHandler ErrHandler) (chan struct{}, chan struct{}, error) {
+		return WsCombinedDiffDepthServe([]string{"BTCUSD_200626"}, handler, errHandler)
+	})
+}
+
+func (s *websocketServiceTestSuite) TestCombinedEndpoints() {
+	var endpoint string
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		endpoint = cfg.Endpoint
+		return nil, nil, nil
+	}
+	e := MainnetEnvironment()
+
+	_, _, err := e.WsCombinedBookTickerServe([]string{"BTCUSD_PERP", "BTCUSD_200626"}, func(event *WsBookTickerEvent) {}, func(err error) {})
+	s.r().NoError(err)
+	s.r().Equal("wss://dstream.binance.com/stream?streams=btcusd_perp@bookTicker/btcusd_200626@bookTicker", endpoint)
+
+	_, _, err = e.WsCombinedIndexPriceKlineServe(map[string]string{"BTCUSD": "1m"}, func(event *WsIndexPriceKlineEvent) {}, func(err error) {})
+	s.r().NoError(err)
+	s.r().Equal("wss://dstream.binance.com/stream?streams=btcusd@indexPriceKline_1m", endpoint)
+
+	_, _, err = e.WsCombinedMiniMarketTickerServe([]string{"ETHUSD_PERP"}, func(event *WsMiniMarketTickerEvent) {}, func(err error) {})
+	s.r().NoError(err)
+	s.r().Equal("wss://dstream.binance.com/stream?streams=ethusd_perp@miniTicker", endpoint)
+
+	SetWsCombinedEndpoints("wss://main.test/stream?streams=", "wss://testnet.test/stream?streams=")
+	defer SetWsCombinedEndpoints("wss://dstream.binance.com/stream?streams=", "wss://dstream.binancefuture.com/stream?streams=")
+	s.r().Equal("wss://main.test/stream?streams=", MainnetEnvironment().CombinedURL)
+	s.r().Equal("wss://dstream.binance.com/ws", MainnetEnvironment().WsURL)
+	s.r().Equal("wss://testnet.test/stream?streams=", TestnetEnvironment().CombinedURL)
+}
+
+func (s *websocketServiceTestSuite) TestCombinedServeInvalidData() {
+	s.mockWsServe([]byte(`{"stream":"btcusd_perp@ticker","data":[1]}`), nil)
+	defer s.assertWsServe()
+
+	var errs []error
+	doneC, stopC, err := WsCombinedMarketTickerServe([]string{"BTCUSD_PERP"}, func(event *WsMarketTickerEvent) {
+		s.r().Fail("unexpected event")
+	},
+		func(err error) {
+			errs = append(errs, err)
+		})
+
+	s.r().NoError(err)
+	s.r().Len(errs, 1)
+	stopC <- struct{}{}
+	<-doneC
+}
+
 func (s *websocketServiceTestSuite) testWsUserDataServe(data []byte, expectedEvent *WsUserDataEvent) {
 	fakeErrMsg := "fake error"
 	s.mockWsServe(data, errors.New(fakeErrMsg))
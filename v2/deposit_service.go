@@ -65,30 +65,30 @@ func (s *ListDepositsService) TxID(id string) *ListDepositsService {
 // Do sends the request.
 func (s *ListDepositsService) Do(ctx context.Context) (res []*Deposit, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/capital/deposit/hisrec",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/capital/deposit/hisrec",
+		SecType:  secTypeSigned,
 	}
 	if s.coin != nil {
-		r.setParam("coin", *s.coin)
+		r.SetParam("coin", *s.coin)
 	}
 	if s.status != nil {
-		r.setParam("status", *s.status)
+		r.SetParam("status", *s.status)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.offset != nil {
-		r.setParam("offset", *s.offset)
+		r.SetParam("offset", *s.offset)
 	}
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	if s.txId != nil {
-		r.setParam("txId", *s.txId)
+		r.SetParam("txId", *s.txId)
 	}
 
 	data, err := s.c.callAPI(ctx, r)
@@ -144,13 +144,13 @@ func (s *GetDepositsAddressService) Network(network string) *GetDepositsAddressS
 // Do sends the request.
 func (s *GetDepositsAddressService) Do(ctx context.Context) (*GetDepositAddressResponse, error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/capital/deposit/address",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/capital/deposit/address",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("coin", s.coin)
+	r.SetParam("coin", s.coin)
 	if s.network != nil {
-		r.setParam("network", *s.network)
+		r.SetParam("network", *s.network)
 	}
 
 	data, err := s.c.callAPI(ctx, r)
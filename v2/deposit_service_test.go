@@ -46,7 +46,7 @@ func (s *depositServiceTestSuite) TestListDeposits() {
 	s.mockDo(data, nil)
 	defer s.assertDo()
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"coin":      "BTC",
 			"status":    1,
 			"startTime": 1508198532000,
@@ -127,7 +127,7 @@ func (s *depositServiceTestSuite) TestGetDepositAddress() {
 	coin := "BTC"
 	network := "BTC"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"coin":    coin,
 			"network": network,
 		})
@@ -29,12 +29,13 @@ func (s *DepthService) Limit(limit int) *DepthService {
 // Do send request
 func (s *DepthService) Do(ctx context.Context, opts ...RequestOption) (res *DepthResponse, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/depth",
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/depth",
+		Weight:   depthWeight(s.limit),
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -67,6 +68,19 @@ func (s *DepthService) Do(ctx context.Context, opts ...RequestOption) (res *Dept
 	return res, nil
 }
 
+// depthWeight returns the request weight of the depth endpoint for the limit
+func depthWeight(limit *int) int64 {
+	switch {
+	case limit == nil || *limit <= 100:
+		return 5
+	case *limit <= 500:
+		return 25
+	case *limit <= 1000:
+		return 50
+	}
+	return 250
+}
+
 // DepthResponse define depth info with bids and asks
 type DepthResponse struct {
 	LastUpdateID int64 `json:"lastUpdateId"`
@@ -37,8 +37,8 @@ func (s *depthServiceTestSuite) TestDepth() {
 	symbol := "LTCBTC"
 	limit := 3
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbol", symbol).
-			setParam("limit", limit)
+		e := newRequest().SetParam("symbol", symbol).
+			SetParam("limit", limit)
 		s.assertRequestEqual(e, r)
 	})
 	res, err := s.client.NewDepthService().Symbol(symbol).Limit(limit).Do(newContext())
@@ -61,6 +61,15 @@ func (s *depthServiceTestSuite) TestDepth() {
 	s.assertDepthResponseEqual(e, res)
 }
 
+func (s *depthServiceTestSuite) TestDepthWeight() {
+	limit := func(n int) *int { return &n }
+	s.r().Equal(int64(5), depthWeight(nil))
+	s.r().Equal(int64(5), depthWeight(limit(100)))
+	s.r().Equal(int64(25), depthWeight(limit(500)))
+	s.r().Equal(int64(50), depthWeight(limit(1000)))
+	s.r().Equal(int64(250), depthWeight(limit(5000)))
+}
+
 func (s *depthServiceTestSuite) assertDepthResponseEqual(e, a *DepthResponse) {
 	r := s.r()
 	r.Equal(e.LastUpdateID, a.LastUpdateID, "LastUpdateID")
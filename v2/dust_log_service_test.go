@@ -209,7 +209,7 @@ func (s *dustTransferTestSuite) TestTransfer() {
 	s.assertReq(func(r *request) {
 		e := newSignedRequest()
 		for _, a := range asset {
-			e.addParam("asset", a)
+			e.AddParam("asset", a)
 		}
 		s.assertRequestEqual(e, r)
 	})
@@ -0,0 +1,112 @@
+package binance
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type environmentTestSuite struct {
+	suite.Suite
+	origWsServe func(*WsConfig, WsHandler, ErrHandler) (chan struct{}, chan struct{}, error)
+	cfg         *WsConfig
+}
+
+func TestEnvironment(t *testing.T) {
+	suite.Run(t, new(environmentTestSuite))
+}
+
+func (s *environmentTestSuite) SetupTest() {
+	s.origWsServe = wsServe
+	s.cfg = nil
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		s.cfg = cfg
+		return make(chan struct{}), make(chan struct{}), nil
+	}
+}
+
+func (s *environmentTestSuite) TearDownTest() {
+	wsServe = s.origWsServe
+	UseTestnet = false
+}
+
+func (s *environmentTestSuite) TestDefaultEnvironment() {
+	s.Equal(baseAPIMainURL, DefaultEnvironment().APIURL)
+	UseTestnet = true
+	e := DefaultEnvironment()
+	s.Equal(baseAPITestnetURL, e.APIURL)
+	s.Equal(baseWsTestnetURL, e.WsURL)
+	s.Equal(baseCombinedTestnetURL, e.CombinedURL)
+}
+
+func (s *environmentTestSuite) TestNewClientWithEnvironment() {
+	proxyURL, _ := url.Parse("http://127.0.0.1:3128")
+	env := TestnetEnvironment()
+	env.Proxy = http.ProxyURL(proxyURL)
+	env.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+	c := NewClientWithEnvironment("apiKey", "secretKey", env)
+	s.Equal(baseAPITestnetURL, c.BaseURL)
+	s.Equal(env, c.Environment)
+	tr, ok := c.HTTPClient.Transport.(*http.Transport)
+	s.Require().True(ok)
+	s.Equal(env.TLSConfig, tr.TLSClientConfig)
+	proxy, err := tr.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "testnet.binance.vision"}})
+	s.Require().NoError(err)
+	s.Equal(proxyURL, proxy)
+
+	s.Equal(http.DefaultClient, NewClientWithEnvironment("apiKey", "secretKey", MainnetEnvironment()).HTTPClient)
+}
+
+func (s *environmentTestSuite) TestEnvironmentStreams() {
+	testnet := TestnetEnvironment()
+	testnet.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	_, _, err := testnet.WsDepthServe("BTCUSDT", func(event *WsDepthEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+	s.Equal(baseWsTestnetURL+"/btcusdt@depth", s.cfg.Endpoint)
+	s.Equal(testnet.TLSConfig, s.cfg.TLSConfig)
+
+	mainnet := MainnetEnvironment()
+	_, _, err = mainnet.WsCombinedBookTickerServe([]string{"BTCUSDT"}, func(event *WsBookTickerEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+	s.Equal(baseCombinedMainURL+"btcusdt@bookTicker", s.cfg.Endpoint)
+	_, _, err = testnet.WsCombinedBookTickerServe([]string{"BTCUSDT", "ETHUSDT"}, func(event *WsBookTickerEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+	s.Equal(baseCombinedTestnetURL+"btcusdt@bookTicker/ethusdt@bookTicker", s.cfg.Endpoint)
+
+	_, _, err = WsTradeServe("BTCUSDT", func(event *WsTradeEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+	s.Equal(baseWsMainURL+"/btcusdt@trade", s.cfg.Endpoint)
+}
+
+func (s *environmentTestSuite) TestWithContext() {
+	env := MainnetEnvironment()
+	s.Equal(context.Background(), env.Context())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	withCtx := env.WithContext(ctx)
+	s.Equal(ctx, withCtx.Context())
+	s.Equal(context.Background(), env.Context())
+
+	_, _, err := withCtx.WsTradeServe("BTCUSDT", func(event *WsTradeEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+	s.Equal(ctx, s.cfg.Context)
+	s.Equal(ctx, s.cfg.connConfig().Context)
+}
+
+func (s *environmentTestSuite) TestWithWsOptions() {
+	env := MainnetEnvironment()
+	o := &common.WsConnOptions{EnableCompression: true, ReadLimit: 1 << 22}
+	withOptions := env.WithWsOptions(o)
+	s.Nil(env.WsOptions)
+
+	_, _, err := withOptions.WsAllMarketsStatServe(func(event WsAllMarketsStatEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+	s.Equal(o, s.cfg.Options)
+	s.Equal(o, s.cfg.connConfig().Options)
+}
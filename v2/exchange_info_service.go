@@ -3,6 +3,8 @@ package binance
 import (
 	"context"
 	"net/http"
+
+	"github.com/pooyakn/go-binance/v2/common"
 )
 
 // ExchangeInfoService exchange info service
@@ -36,9 +38,9 @@ func (s *ExchangeInfoService) Permissions(permissions ...string) *ExchangeInfoSe
 // Do send request
 func (s *ExchangeInfoService) Do(ctx context.Context, opts ...RequestOption) (res *ExchangeInfo, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/exchangeInfo",
-		secType:  secTypeNone,
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/exchangeInfo",
+		SecType:  secTypeNone,
 	}
 	m := params{}
 	if s.symbol != "" {
@@ -50,7 +52,7 @@ func (s *ExchangeInfoService) Do(ctx context.Context, opts ...RequestOption) (re
 	if len(s.permissions) != 0 {
 		m["permissions"] = s.permissions
 	}
-	r.setParams(m)
+	r.SetParams(m)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
@@ -60,7 +62,9 @@ func (s *ExchangeInfoService) Do(ctx context.Context, opts ...RequestOption) (re
 	if err != nil {
 		return nil, err
 	}
-
+	if s.c.RateLimiter != nil {
+		s.c.RateLimiter.SeedLimits(res.RateLimits)
+	}
 	return res, nil
 }
 
@@ -74,12 +78,7 @@ type ExchangeInfo struct {
 }
 
 // RateLimit struct
-type RateLimit struct {
-	RateLimitType string `json:"rateLimitType"`
-	Interval      string `json:"interval"`
-	IntervalNum   int64  `json:"intervalNum"`
-	Limit         int64  `json:"limit"`
-}
+type RateLimit = common.RateLimit
 
 // Symbol market symbol
 type Symbol struct {
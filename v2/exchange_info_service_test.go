@@ -3,6 +3,7 @@ package binance
 import (
 	"testing"
 
+	"github.com/pooyakn/go-binance/v2/common"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -63,7 +64,7 @@ func (s *exchangeInfoServiceTestSuite) TestExchangeInfo() {
 	symbols := []string{"ETHBTC", "LTCBTC"}
 	permissions := []string{"SPOT", "MARGIN"}
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(map[string]interface{}{
+		e := newRequest().SetParams(map[string]interface{}{
 			"symbol":      symbol,
 			"symbols":     `["ETHBTC","LTCBTC"]`,
 			"permissions": `["SPOT","MARGIN"]`,
@@ -132,6 +133,25 @@ func (s *exchangeInfoServiceTestSuite) TestExchangeInfo() {
 	s.assertMaxNumAlgoOrdersFilterEqual(eMaxNumAlgoOrdersFilter, res.Symbols[0].MaxNumAlgoOrdersFilter())
 }
 
+func (s *exchangeInfoServiceTestSuite) TestExchangeInfoSeedsRateLimiter() {
+	data := []byte(`{
+		"rateLimits": [
+			{"rateLimitType": "REQUEST_WEIGHT", "interval": "MINUTE", "intervalNum": 1, "limit": 6000},
+			{"rateLimitType": "ORDERS", "interval": "SECOND", "intervalNum": 10, "limit": 100}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.client.RateLimiter = common.NewRateLimiter()
+
+	_, err := s.client.NewExchangeInfoService().Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]common.RateLimit{
+		{RateLimitType: "REQUEST_WEIGHT", Interval: "MINUTE", IntervalNum: 1, Limit: 6000},
+		{RateLimitType: "ORDERS", Interval: "SECOND", IntervalNum: 10, Limit: 100},
+	}, s.client.RateLimiter.Limits())
+}
+
 func (s *exchangeInfoServiceTestSuite) assertExchangeInfoEqual(e, a *ExchangeInfo) {
 	r := s.r()
 
@@ -48,22 +48,22 @@ func (s *FiatDepositWithdrawHistoryService) Rows(rows int32) *FiatDepositWithdra
 // Do send request
 func (s *FiatDepositWithdrawHistoryService) Do(ctx context.Context, opts ...RequestOption) (*FiatDepositWithdrawHistory, error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/fiat/orders",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/fiat/orders",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("transactionType", s.transactionType)
+	r.SetParam("transactionType", s.transactionType)
 	if s.beginTime != nil {
-		r.setParam("beginTime", *s.beginTime)
+		r.SetParam("beginTime", *s.beginTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.page != nil {
-		r.setParam("page", *s.page)
+		r.SetParam("page", *s.page)
 	}
 	if s.rows != nil {
-		r.setParam("rows", *s.rows)
+		r.SetParam("rows", *s.rows)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -141,22 +141,22 @@ func (s *FiatPaymentsHistoryService) Rows(rows int32) *FiatPaymentsHistoryServic
 // Do send request
 func (s *FiatPaymentsHistoryService) Do(ctx context.Context, opts ...RequestOption) (*FiatPaymentsHistory, error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/fiat/payments",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/fiat/payments",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("transactionType", s.transactionType)
+	r.SetParam("transactionType", s.transactionType)
 	if s.beginTime != nil {
-		r.setParam("beginTime", *s.beginTime)
+		r.SetParam("beginTime", *s.beginTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.page != nil {
-		r.setParam("page", *s.page)
+		r.SetParam("page", *s.page)
 	}
 	if s.rows != nil {
-		r.setParam("rows", *s.rows)
+		r.SetParam("rows", *s.rows)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
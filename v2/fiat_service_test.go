@@ -38,7 +38,7 @@ func (s *fiatServiceTestSuite) TestFiatDepositWithdrawHistory() {
 
 	transactionType := TransactionTypeDeposit
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"transactionType": transactionType,
 		})
 		s.assertRequestEqual(e, r)
@@ -122,7 +122,7 @@ func (s *fiatServiceTestSuite) TestFiatPaymentsHistory() {
 
 	transactionType := TransactionTypeBuy
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"transactionType": transactionType,
 		})
 		s.assertRequestEqual(e, r)
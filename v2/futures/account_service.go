@@ -14,9 +14,9 @@ type GetBalanceService struct {
 // Do send request
 func (s *GetBalanceService) Do(ctx context.Context, opts ...RequestOption) (res []*Balance, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v2/balance",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v2/balance",
+		SecType:  secTypeSigned,
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -49,9 +49,9 @@ type GetAccountService struct {
 // Do send request
 func (s *GetAccountService) Do(ctx context.Context, opts ...RequestOption) (res *Account, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v2/account",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v2/account",
+		SecType:  secTypeSigned,
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -47,8 +47,8 @@ func (s *baseTestSuite) assertReq(f func(r *request)) {
 }
 
 func (s *baseTestSuite) assertRequestEqual(e, a *request) {
-	s.assertURLValuesEqual(e.query, a.query)
-	s.assertURLValuesEqual(e.form, a.form)
+	s.assertURLValuesEqual(e.Query, a.Query)
+	s.assertURLValuesEqual(e.Form, a.Form)
 }
 
 func (s *baseTestSuite) assertURLValuesEqual(e, a url.Values) {
@@ -92,14 +92,14 @@ func newHTTPResponse(data []byte, statusCode int) *http.Response {
 
 func newRequest() *request {
 	r := &request{
-		query: url.Values{},
-		form:  url.Values{},
+		Query: url.Values{},
+		Form:  url.Values{},
 	}
 	return r
 }
 
 func newSignedRequest() *request {
-	return newRequest().setParams(params{
+	return newRequest().SetParams(params{
 		timestampKey: "",
 		signatureKey: "",
 	})
@@ -122,7 +122,7 @@ func newMockedClient(apiKey, secretKey string) *mockedClient {
 func (m *mockedClient) do(req *http.Request) (*http.Response, error) {
 	if m.assertReq != nil {
 		r := newRequest()
-		r.query = req.URL.Query()
+		r.Query = req.URL.Query()
 		if req.Body != nil {
 			bs := make([]byte, req.ContentLength)
 			for {
@@ -135,7 +135,7 @@ func (m *mockedClient) do(req *http.Request) (*http.Response, error) {
 			if err != nil {
 				panic(err)
 			}
-			r.form = form
+			r.Form = form
 		}
 		m.assertReq(r)
 	}
@@ -20,12 +20,12 @@ func (service *CommissionRateService) Symbol(symbol string) *CommissionRateServi
 // Do send request
 func (s *CommissionRateService) Do(ctx context.Context, opts ...RequestOption) (res *CommissionRate, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/commissionRate",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/commissionRate",
+		SecType:  secTypeSigned,
 	}
 	if s.symbol != "" {
-		r.setParam("symbol", s.symbol)
+		r.SetParam("symbol", s.symbol)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
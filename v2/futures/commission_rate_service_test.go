@@ -28,7 +28,7 @@ func (commissionRateService *commissionRateServiceTestSuite) TestCommissionRate(
 
 	symbol := "BTCUSDT"
 	commissionRateService.assertReq(func(request *request) {
-		requestParams := newSignedRequest().setParam("symbol", symbol)
+		requestParams := newSignedRequest().SetParam("symbol", symbol)
 		commissionRateService.assertRequestEqual(requestParams, request)
 	})
 	res, err := commissionRateService.client.NewCommissionRateService().Symbol(symbol).Do(newContext())
@@ -56,20 +56,20 @@ func (s *ContinuousKlinesService) EndTime(endTime int64) *ContinuousKlinesServic
 // Do send request
 func (s *ContinuousKlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*ContinuousKline, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/continuousKlines",
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/continuousKlines",
 	}
-	r.setParam("pair", s.pair)
-	r.setParam("contractType", s.contractType)
-	r.setParam("interval", s.interval)
+	r.SetParam("pair", s.pair)
+	r.SetParam("contractType", s.contractType)
+	r.SetParam("interval", s.interval)
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
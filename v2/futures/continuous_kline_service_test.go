@@ -55,7 +55,7 @@ func (s *ContinuousklineServiceTestSuite) TestContinuousKlines() {
 	startTime := int64(1499040000000)
 	endTime := int64(1499040000001)
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"pair":         pair,
 			"contractType": contractType,
 			"interval":     interval,
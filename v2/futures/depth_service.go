@@ -29,12 +29,13 @@ func (s *DepthService) Limit(limit int) *DepthService {
 // Do send request
 func (s *DepthService) Do(ctx context.Context, opts ...RequestOption) (res *DepthResponse, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/depth",
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/depth",
+		Weight:   depthWeight(s.limit),
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -69,6 +70,22 @@ func (s *DepthService) Do(ctx context.Context, opts ...RequestOption) (res *Dept
 	return res, nil
 }
 
+// depthWeight returns the request weight of the depth endpoint for the limit,
+// 500 by default
+func depthWeight(limit *int) int64 {
+	switch {
+	case limit == nil:
+		return 10
+	case *limit <= 50:
+		return 2
+	case *limit <= 100:
+		return 5
+	case *limit <= 500:
+		return 10
+	}
+	return 20
+}
+
 // DepthResponse define depth info with bids and asks
 type DepthResponse struct {
 	LastUpdateID int64 `json:"lastUpdateId"`
@@ -0,0 +1,75 @@
+package futures
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type environmentTestSuite struct {
+	suite.Suite
+	origWsServe func(*WsConfig, WsHandler, ErrHandler) (chan struct{}, chan struct{}, error)
+	cfg         *WsConfig
+}
+
+func TestEnvironment(t *testing.T) {
+	suite.Run(t, new(environmentTestSuite))
+}
+
+func (s *environmentTestSuite) SetupTest() {
+	s.origWsServe = wsServe
+	s.cfg = nil
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		s.cfg = cfg
+		return make(chan struct{}), make(chan struct{}), nil
+	}
+}
+
+func (s *environmentTestSuite) TearDownTest() {
+	wsServe = s.origWsServe
+	UseTestnet = false
+}
+
+func (s *environmentTestSuite) TestDefaultEnvironment() {
+	s.Equal(baseApiMainUrl, DefaultEnvironment().APIURL)
+	UseTestnet = true
+	s.Equal(baseApiTestnetUrl, DefaultEnvironment().APIURL)
+	s.Equal(baseApiTestnetUrl, NewClient("apiKey", "secretKey").BaseURL)
+}
+
+func (s *environmentTestSuite) TestEnvironmentStreams() {
+	UseTestnet = true
+	c := NewClientWithEnvironment("apiKey", "secretKey", MainnetEnvironment())
+	s.Equal(baseApiMainUrl, c.BaseURL)
+
+	_, _, err := c.Environment.WsMarkPriceServe("BTCUSDT", func(event *WsMarkPriceEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+	s.Equal(baseWsMainUrl+"/btcusdt@markPrice", s.cfg.Endpoint)
+
+	_, _, err = WsMarkPriceServe("BTCUSDT", func(event *WsMarkPriceEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+	s.Equal(baseWsTestnetUrl+"/btcusdt@markPrice", s.cfg.Endpoint)
+}
+
+func (s *environmentTestSuite) TestWithContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := MainnetEnvironment().WithContext(ctx)
+	s.Equal(ctx, env.Context())
+
+	_, _, err := env.WsMarkPriceServe("BTCUSDT", func(event *WsMarkPriceEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+	s.Equal(ctx, s.cfg.Context)
+}
+
+func (s *environmentTestSuite) TestWithWsOptions() {
+	o := &common.WsConnOptions{Keepalive: -1}
+	env := MainnetEnvironment().WithWsOptions(o)
+
+	_, _, err := env.WsMarkPriceServe("BTCUSDT", func(event *WsMarkPriceEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+	s.Equal(o, s.cfg.Options)
+	s.Equal(o, s.cfg.connConfig().Options)
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+
+	"github.com/pooyakn/go-binance/v2/common"
 )
 
 // ExchangeInfoService exchange info service
@@ -15,9 +17,9 @@ type ExchangeInfoService struct {
 // Do send request
 func (s *ExchangeInfoService) Do(ctx context.Context, opts ...RequestOption) (res *ExchangeInfo, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/exchangeInfo",
-		secType:  secTypeNone,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/exchangeInfo",
+		SecType:  secTypeNone,
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -28,7 +30,9 @@ func (s *ExchangeInfoService) Do(ctx context.Context, opts ...RequestOption) (re
 	if err != nil {
 		return nil, err
 	}
-
+	if s.c.RateLimiter != nil {
+		s.c.RateLimiter.SeedLimits(res.RateLimits)
+	}
 	return res, nil
 }
 
@@ -42,12 +46,7 @@ type ExchangeInfo struct {
 }
 
 // RateLimit struct
-type RateLimit struct {
-	RateLimitType string `json:"rateLimitType"`
-	Interval      string `json:"interval"`
-	IntervalNum   int64  `json:"intervalNum"`
-	Limit         int64  `json:"limit"`
-}
+type RateLimit = common.RateLimit
 
 // Symbol market symbol
 type Symbol struct {
@@ -40,7 +40,7 @@ func (s *longShortRatioServiceTestSuite) TestOpenInterestStatistics() {
 	startTime := int64(1583139600000)
 	endTime := int64(1583139900000)
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"symbol":    symbol,
 			"period":    period,
 			"limit":     limit,
@@ -23,12 +23,12 @@ func (s *PremiumIndexService) Symbol(symbol string) *PremiumIndexService {
 // Do send request
 func (s *PremiumIndexService) Do(ctx context.Context, opts ...RequestOption) (res []*PremiumIndex, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/premiumIndex",
-		secType:  secTypeNone,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/premiumIndex",
+		SecType:  secTypeNone,
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	data = common.ToJSONList(data)
@@ -88,19 +88,19 @@ func (s *FundingRateService) Limit(limit int) *FundingRateService {
 // Do send request
 func (s *FundingRateService) Do(ctx context.Context, opts ...RequestOption) (res []*FundingRate, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/fundingRate",
-		secType:  secTypeNone,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/fundingRate",
+		SecType:  secTypeNone,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -137,13 +137,13 @@ func (s *GetLeverageBracketService) Symbol(symbol string) *GetLeverageBracketSer
 // Do send request
 func (s *GetLeverageBracketService) Do(ctx context.Context, opts ...RequestOption) (res []*LeverageBracket, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/leverageBracket",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/leverageBracket",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.symbol != "" {
-		r.setParam("symbol", s.symbol)
+		r.SetParam("symbol", s.symbol)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
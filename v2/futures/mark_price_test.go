@@ -27,7 +27,7 @@ func (s *premiumIndexServiceTestSuite) TestGetPremiumIndex() {
 
 	symbol := "BTCUSDT"
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"symbol": symbol,
 		})
 		s.assertRequestEqual(e, r)
@@ -86,7 +86,7 @@ func (s *fundingRateServiceTestSuite) TestGetFundingRate() {
 	endTime := int64(1676566020000)
 	limit := 10
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"symbol":    symbol,
 			"startTime": startTime,
 			"endTime":   endTime,
@@ -154,7 +154,7 @@ func (s *getLeverageBracketServiceTestSuite) TestGetLeverageBracket() {
 	symbol := "ETHUSDT"
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol": symbol,
 		})
 		s.assertRequestEqual(e, r)
@@ -25,7 +25,7 @@ func (s *openInterestServiceTestSuite) TestGetOpenInterest() {
 
 	symbol := "BTCUSDT"
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"symbol": symbol,
 		})
 		s.assertRequestEqual(e, r)
@@ -69,7 +69,7 @@ func (s *openInterestServiceTestSuite) TestOpenInterestStatistics() {
 	startTime := int64(1499040000000)
 	endTime := int64(1499040000001)
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"symbol":    symbol,
 			"period":    period,
 			"limit":     limit,
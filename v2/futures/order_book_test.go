@@ -0,0 +1,86 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/stretchr/testify/suite"
+)
+
+type orderBookTestSuite struct {
+	suite.Suite
+	srv    *binancetest.Server
+	client *Client
+
+	mu        sync.Mutex
+	snapshots int
+}
+
+func TestOrderBook(t *testing.T) {
+	suite.Run(t, new(orderBookTestSuite))
+}
+
+func (s *orderBookTestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.client = NewClientWithEnvironment(s.srv.APIKey, s.srv.SecretKey, &Environment{
+		APIURL: s.srv.URL,
+		WsURL:  s.srv.WsURL(binancetest.Futures),
+	})
+	s.snapshots = 0
+}
+
+func (s *orderBookTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *orderBookTestSuite) placeOrder(side, price, quantity string) {
+	_, err := s.srv.PlaceOrder(binancetest.Futures, "BTCUSDT", side, price, quantity)
+	s.Require().NoError(err)
+}
+
+func (s *orderBookTestSuite) TestResyncOnPrevUpdateIDMismatch() {
+	book := s.client.NewOrderBook("BTCUSDT").Limit(100).OnUpdate(func(update *OrderBookUpdate) {
+		if update.Snapshot {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.snapshots++
+		}
+	}).OnError(func(err error) {
+		s.Fail(err.Error())
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	s.Require().NoError(book.Start(ctx))
+	defer book.Close()
+	s.Require().Eventually(func() bool {
+		return s.srv.Subscribers(binancetest.Futures, "btcusdt@depth") == 1
+	}, time.Second, 5*time.Millisecond)
+
+	s.placeOrder("BUY", "100", "1")
+	s.Require().NoError(book.WaitSynced(ctx))
+	s.Equal(int64(1), book.LastUpdateID())
+
+	// the pu field of the diff does not match the last update
+	_, err := s.srv.Publish(binancetest.Futures, "btcusdt@depth", json.RawMessage(`{
+		"e":"depthUpdate","E":1,"T":1,"s":"BTCUSDT","U":1,"u":1,"pu":0,
+		"b":[["99.00000000","3.00000000"]],"a":[]
+	}`))
+	s.Require().NoError(err)
+
+	// the book is synchronised again with a new snapshot, then the diff
+	s.Require().Eventually(func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.snapshots == 2
+	}, time.Second, 5*time.Millisecond)
+	s.Require().NoError(book.WaitSynced(ctx))
+	s.Equal(int64(1), book.LastUpdateID())
+	quantity, err := book.BidQuantity("99")
+	s.Require().NoError(err)
+	s.Equal("3.00000000", quantity)
+	s.Len(book.Bids(0), 2)
+}
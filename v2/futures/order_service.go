@@ -129,9 +129,9 @@ func (s *CreateOrderService) ClosePosition(closePosition bool) *CreateOrderServi
 func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, header *http.Header, err error) {
 
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: endpoint,
-		secType:  secTypeSigned,
+		Method:   http.MethodPost,
+		Endpoint: endpoint,
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"symbol":           s.symbol,
@@ -175,7 +175,7 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 	if s.closePosition != nil {
 		m["closePosition"] = *s.closePosition
 	}
-	r.setFormParams(m)
+	r.SetFormParams(m)
 	data, header, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []byte{}, &http.Header{}, err
@@ -242,12 +242,12 @@ func (s *ListOpenOrdersService) Symbol(symbol string) *ListOpenOrdersService {
 // Do send request
 func (s *ListOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*Order, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/openOrders",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/openOrders",
+		SecType:  secTypeSigned,
 	}
 	if s.symbol != "" {
-		r.setParam("symbol", s.symbol)
+		r.SetParam("symbol", s.symbol)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -286,19 +286,19 @@ func (s *GetOpenOrderService) OrigClientOrderID(origClientOrderID string) *GetOp
 
 func (s *GetOpenOrderService) Do(ctx context.Context, opts ...RequestOption) (res *Order, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/openOrder",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/openOrder",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.orderID == nil && s.origClientOrderID == nil {
 		return nil, errors.New("either orderId or origClientOrderId must be sent")
 	}
 	if s.orderID != nil {
-		r.setParam("orderId", *s.orderID)
+		r.SetParam("orderId", *s.orderID)
 	}
 	if s.origClientOrderID != nil {
-		r.setParam("origClientOrderId", *s.origClientOrderID)
+		r.SetParam("origClientOrderId", *s.origClientOrderID)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -341,16 +341,16 @@ func (s *GetOrderService) OrigClientOrderID(origClientOrderID string) *GetOrderS
 // Do send request
 func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *Order, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/order",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/order",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.orderID != nil {
-		r.setParam("orderId", *s.orderID)
+		r.SetParam("orderId", *s.orderID)
 	}
 	if s.origClientOrderID != nil {
-		r.setParam("origClientOrderId", *s.origClientOrderID)
+		r.SetParam("origClientOrderId", *s.origClientOrderID)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -435,22 +435,22 @@ func (s *ListOrdersService) Limit(limit int) *ListOrdersService {
 // Do send request
 func (s *ListOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*Order, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/allOrders",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/allOrders",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.orderID != nil {
-		r.setParam("orderId", *s.orderID)
+		r.SetParam("orderId", *s.orderID)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -493,16 +493,16 @@ func (s *CancelOrderService) OrigClientOrderID(origClientOrderID string) *Cancel
 // Do send request
 func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CancelOrderResponse, err error) {
 	r := &request{
-		method:   http.MethodDelete,
-		endpoint: "/fapi/v1/order",
-		secType:  secTypeSigned,
+		Method:   http.MethodDelete,
+		Endpoint: "/fapi/v1/order",
+		SecType:  secTypeSigned,
 	}
-	r.setFormParam("symbol", s.symbol)
+	r.SetFormParam("symbol", s.symbol)
 	if s.orderID != nil {
-		r.setFormParam("orderId", *s.orderID)
+		r.SetFormParam("orderId", *s.orderID)
 	}
 	if s.origClientOrderID != nil {
-		r.setFormParam("origClientOrderId", *s.origClientOrderID)
+		r.SetFormParam("origClientOrderId", *s.origClientOrderID)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -556,11 +556,11 @@ func (s *CancelAllOpenOrdersService) Symbol(symbol string) *CancelAllOpenOrdersS
 // Do send request
 func (s *CancelAllOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (err error) {
 	r := &request{
-		method:   http.MethodDelete,
-		endpoint: "/fapi/v1/allOpenOrders",
-		secType:  secTypeSigned,
+		Method:   http.MethodDelete,
+		Endpoint: "/fapi/v1/allOpenOrders",
+		SecType:  secTypeSigned,
 	}
-	r.setFormParam("symbol", s.symbol)
+	r.SetFormParam("symbol", s.symbol)
 	_, _, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return err
@@ -597,18 +597,18 @@ func (s *CancelMultiplesOrdersService) OrigClientOrderIDList(origClientOrderIDLi
 // Do send request
 func (s *CancelMultiplesOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*CancelOrderResponse, err error) {
 	r := &request{
-		method:   http.MethodDelete,
-		endpoint: "/fapi/v1/batchOrders",
-		secType:  secTypeSigned,
+		Method:   http.MethodDelete,
+		Endpoint: "/fapi/v1/batchOrders",
+		SecType:  secTypeSigned,
 	}
-	r.setFormParam("symbol", s.symbol)
+	r.SetFormParam("symbol", s.symbol)
 	if s.orderIDList != nil {
 		// convert a slice of integers to a string e.g. [1 2 3] => "[1,2,3]"
 		orderIDListString := strings.Join(strings.Fields(fmt.Sprint(s.orderIDList)), ",")
-		r.setFormParam("orderIdList", orderIDListString)
+		r.SetFormParam("orderIdList", orderIDListString)
 	}
 	if s.origClientOrderIDList != nil {
-		r.setFormParam("origClientOrderIdList", s.origClientOrderIDList)
+		r.SetFormParam("origClientOrderIdList", s.origClientOrderIDList)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -658,21 +658,21 @@ func (s *ListLiquidationOrdersService) Limit(limit int) *ListLiquidationOrdersSe
 // Do send request
 func (s *ListLiquidationOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*LiquidationOrder, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/allForceOrders",
-		secType:  secTypeNone,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/allForceOrders",
+		SecType:  secTypeNone,
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -743,23 +743,23 @@ func (s *ListUserLiquidationOrdersService) Limit(limit int) *ListUserLiquidation
 // Do send request
 func (s *ListUserLiquidationOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*UserLiquidationOrder, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/forceOrders",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/forceOrders",
+		SecType:  secTypeSigned,
 	}
 
-	r.setParam("autoCloseType", s.autoCloseType)
+	r.SetParam("autoCloseType", s.autoCloseType)
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -813,9 +813,9 @@ func (s *CreateBatchOrdersService) OrderList(orders []*CreateOrderService) *Crea
 
 func (s *CreateBatchOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *CreateBatchOrdersResponse, err error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: "/fapi/v1/batchOrders",
-		secType:  secTypeSigned,
+		Method:   http.MethodPost,
+		Endpoint: "/fapi/v1/batchOrders",
+		SecType:  secTypeSigned,
 	}
 
 	orders := []params{}
@@ -871,7 +871,7 @@ func (s *CreateBatchOrdersService) Do(ctx context.Context, opts ...RequestOption
 		"batchOrders": string(b),
 	}
 
-	r.setFormParams(m)
+	r.SetFormParams(m)
 
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 
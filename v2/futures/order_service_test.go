@@ -60,7 +60,7 @@ func (s *orderServiceTestSuite) TestCreateOrder() {
 	newOrderResponseType := NewOrderRespTypeRESULT
 	closePosition := false
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":           symbol,
 			"side":             side,
 			"type":             orderType,
@@ -168,7 +168,7 @@ func (s *orderServiceTestSuite) TestListOpenOrders() {
 	symbol := "BTCUSDT"
 	recvWindow := int64(1000)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":     symbol,
 			"recvWindow": recvWindow,
 		})
@@ -258,7 +258,7 @@ func (s *orderServiceTestSuite) TestGetOpenOrder() {
 	orderId := int64(1)
 	recvWindow := int64(1000)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":     symbol,
 			"orderId":    1,
 			"recvWindow": recvWindow,
@@ -324,7 +324,7 @@ func (s *orderServiceTestSuite) TestGetOrder() {
 	orderID := int64(1)
 	origClientOrderID := "myOrder1"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":            symbol,
 			"orderId":           orderID,
 			"origClientOrderId": origClientOrderID,
@@ -393,7 +393,7 @@ func (s *orderServiceTestSuite) TestListOrders() {
 	startTime := int64(1499827319559)
 	endTime := int64(1499827319560)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":    symbol,
 			"orderId":   orderID,
 			"startTime": startTime,
@@ -463,7 +463,7 @@ func (s *orderServiceTestSuite) TestCancelOrder() {
 	orderID := int64(28)
 	origClientOrderID := "myOrder1"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":            symbol,
 			"orderId":           orderID,
 			"origClientOrderId": origClientOrderID,
@@ -535,7 +535,7 @@ func (s *orderServiceTestSuite) TestCancelAllOpenOrders() {
 
 	symbol := "BTCUSDT"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol": symbol,
 		})
 		s.assertRequestEqual(e, r)
@@ -569,7 +569,7 @@ func (s *orderServiceTestSuite) TestListLiquidationOrders() {
 	endTime := int64(1568014460894)
 	limit := 1
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"symbol":    symbol,
 			"startTime": startTime,
 			"endTime":   endTime,
@@ -21,12 +21,12 @@ func (s *GetPositionRiskService) Symbol(symbol string) *GetPositionRiskService {
 // Do send request
 func (s *GetPositionRiskService) Do(ctx context.Context, opts ...RequestOption) (res []*PositionRisk, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v2/positionRisk",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v2/positionRisk",
+		SecType:  secTypeSigned,
 	}
 	if s.symbol != "" {
-		r.setParam("symbol", s.symbol)
+		r.SetParam("symbol", s.symbol)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
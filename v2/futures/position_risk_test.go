@@ -38,7 +38,7 @@ func (s *positionRiskServiceTestSuite) TestGetPositionRisk() {
 	symbol := "BTCUSDT"
 	recvWindow := int64(1000)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":     symbol,
 			"recvWindow": recvWindow,
 		})
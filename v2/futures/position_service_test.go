@@ -25,7 +25,7 @@ func (s *positionServiceTestSuite) TestChangeLeverage() {
 	symbol := "BTCUSDT"
 	leverage := 21
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":   symbol,
 			"leverage": leverage,
 		})
@@ -53,7 +53,7 @@ func (s *positionServiceTestSuite) TestChangeMarginType() {
 	symbol := "BTCUSDT"
 	marginType := MarginTypeIsolated
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":     symbol,
 			"marginType": marginType,
 		})
@@ -78,7 +78,7 @@ func (s *positionServiceTestSuite) TestUpdatePositionMargin() {
 	amount := "100.0"
 	actionType := 1
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":       symbol,
 			"positionSide": positionSide,
 			"amount":       amount,
@@ -99,7 +99,7 @@ func (s *positionServiceTestSuite) TestChangePositionMode() {
 	s.mockDo(data, nil)
 	defer s.assertDo()
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"dualSidePosition": "true",
 		})
 		s.assertRequestEqual(e, r)
@@ -115,7 +115,7 @@ func (s *positionServiceTestSuite) TestGetPositionMode() {
 	s.mockDo(data, nil)
 	defer s.assertDo()
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{})
+		e := newSignedRequest().SetFormParams(params{})
 		s.assertRequestEqual(e, r)
 	})
 	res, err := s.client.NewGetPositionModeService().Do(newContext())
@@ -131,7 +131,7 @@ func (s *positionServiceTestSuite) TestChangeMultiAssetMode() {
 	s.mockDo(data, nil)
 	defer s.assertDo()
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"multiAssetsMargin": "true",
 		})
 		s.assertRequestEqual(e, r)
@@ -147,7 +147,7 @@ func (s *positionServiceTestSuite) TestGetMultiAssetMode() {
 	s.mockDo(data, nil)
 	defer s.assertDo()
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{})
+		e := newSignedRequest().SetFormParams(params{})
 		s.assertRequestEqual(e, r)
 	})
 	res, err := s.client.NewGetMultiAssetModeService().Do(newContext())
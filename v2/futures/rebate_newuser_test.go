@@ -32,7 +32,7 @@ func (s *baseRebateNewUserServiceTestSuite) TestRebateNewUser() {
 	brokerageID := "123456"
 	recvWindow := int64(1000)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"brokerId":   brokerageID,
 			"recvWindow": recvWindow,
 		})
@@ -0,0 +1,143 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pooyakn/go-binance/v2/internal/wsconn"
+)
+
+// ErrSessionClosed is returned by the requests of a closed stream session
+var ErrSessionClosed = wsconn.ErrSessionClosed
+
+// ErrConnectionClosed is returned by the requests pending when the connection
+// is lost, their outcome is unknown
+var ErrConnectionClosed = wsconn.ErrConnectionClosed
+
+// StreamSession is a connection to the combined stream endpoint whose streams
+// are subscribed and unsubscribed while connected, unlike the WsCombined*Serve
+// functions which set the streams when connecting. The events are passed to
+// the handler of their stream.
+type StreamSession struct {
+	session    *wsconn.Session
+	cfg        *WsConfig
+	errHandler ErrHandler
+}
+
+// NewStreamSession connects a stream session to the default environment
+func NewStreamSession(errHandler ErrHandler) (*StreamSession, error) {
+	return DefaultEnvironment().NewStreamSession(errHandler)
+}
+
+// NewStreamSession connects a stream session. errHandler is called with the
+// connection errors and the events which can not be decoded.
+func (e *Environment) NewStreamSession(errHandler ErrHandler) (*StreamSession, error) {
+	cfg := e.newWsConfig(strings.TrimSuffix(e.CombinedURL, "?streams="))
+	s := &StreamSession{cfg: cfg, errHandler: errHandler}
+	session, err := wsconn.NewSession(cfg.connConfig(), func(message []byte) {
+		errHandler(fmt.Errorf("unrouted stream message: %s", message))
+	}, errHandler)
+	if err != nil {
+		return nil, err
+	}
+	s.session = session
+	return s, nil
+}
+
+// Subscribe subscribes to the streams, e.g. "btcusdt@aggTrade", whose event
+// payloads are passed to handler
+func (s *StreamSession) Subscribe(ctx context.Context, streams []string, handler WsHandler) error {
+	return s.session.Subscribe(ctx, streams, handler)
+}
+
+// Unsubscribe unsubscribes from the streams
+func (s *StreamSession) Unsubscribe(ctx context.Context, streams ...string) error {
+	return s.session.Unsubscribe(ctx, streams)
+}
+
+// ListSubscriptions returns the streams subscribed by the session
+func (s *StreamSession) ListSubscriptions(ctx context.Context) ([]string, error) {
+	return s.session.ListSubscriptions(ctx)
+}
+
+// SetProperty sets a property of the connection. Events are routed with the
+// stream name of the combined payloads, so they are passed to the error
+// handler once the "combined" property is disabled.
+func (s *StreamSession) SetProperty(ctx context.Context, name string, value interface{}) error {
+	return s.session.SetProperty(ctx, name, value)
+}
+
+// Close closes the connection
+func (s *StreamSession) Close() {
+	s.session.Close()
+}
+
+// Done returns a channel closed once the session is closed
+func (s *StreamSession) Done() <-chan struct{} {
+	return s.session.Done()
+}
+
+// subscribeEvent subscribes to a stream whose events are decoded with
+// newEvent, then passed to handler
+func (s *StreamSession) subscribeEvent(ctx context.Context, stream string, newEvent func() interface{}, handler func(event interface{})) error {
+	return s.session.Subscribe(ctx, []string{stream}, func(data []byte) {
+		event := newEvent()
+		if err := json.Unmarshal(data, event); err != nil {
+			s.errHandler(err)
+			return
+		}
+		stampEvent(s.cfg.clock.Stream(stream), event)
+		handler(event)
+	})
+}
+
+// SubscribeAggTrade subscribes to the aggregate trades of a symbol
+func (s *StreamSession) SubscribeAggTrade(ctx context.Context, symbol string, handler WsAggTradeHandler) error {
+	return s.subscribeEvent(ctx, fmt.Sprintf("%s@aggTrade", strings.ToLower(symbol)),
+		func() interface{} { return new(WsAggTradeEvent) },
+		func(event interface{}) { handler(event.(*WsAggTradeEvent)) })
+}
+
+// SubscribeMarkPrice subscribes to the mark price of a symbol, using 3sec updates
+func (s *StreamSession) SubscribeMarkPrice(ctx context.Context, symbol string, handler WsMarkPriceHandler) error {
+	return s.subscribeEvent(ctx, fmt.Sprintf("%s@markPrice", strings.ToLower(symbol)),
+		func() interface{} { return new(WsMarkPriceEvent) },
+		func(event interface{}) { handler(event.(*WsMarkPriceEvent)) })
+}
+
+// SubscribeKline subscribes to the klines of a symbol
+func (s *StreamSession) SubscribeKline(ctx context.Context, symbol string, interval string, handler WsKlineHandler) error {
+	return s.subscribeEvent(ctx, fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval),
+		func() interface{} { return new(WsKlineEvent) },
+		func(event interface{}) { handler(event.(*WsKlineEvent)) })
+}
+
+// SubscribeMarketTicker subscribes to the 24hr statistics of a symbol
+func (s *StreamSession) SubscribeMarketTicker(ctx context.Context, symbol string, handler WsMarketTickerHandler) error {
+	return s.subscribeEvent(ctx, fmt.Sprintf("%s@ticker", strings.ToLower(symbol)),
+		func() interface{} { return new(WsMarketTickerEvent) },
+		func(event interface{}) { handler(event.(*WsMarketTickerEvent)) })
+}
+
+// SubscribeBookTicker subscribes to the best bid and ask of a symbol
+func (s *StreamSession) SubscribeBookTicker(ctx context.Context, symbol string, handler WsBookTickerHandler) error {
+	return s.subscribeEvent(ctx, fmt.Sprintf("%s@bookTicker", strings.ToLower(symbol)),
+		func() interface{} { return new(WsBookTickerEvent) },
+		func(event interface{}) { handler(event.(*WsBookTickerEvent)) })
+}
+
+// SubscribeDiffDepth subscribes to the depth updates of a symbol, using 250msec updates
+func (s *StreamSession) SubscribeDiffDepth(ctx context.Context, symbol string, handler WsDepthHandler) error {
+	return s.subscribeEvent(ctx, fmt.Sprintf("%s@depth", strings.ToLower(symbol)),
+		func() interface{} { return new(WsDepthEvent) },
+		func(event interface{}) { handler(event.(*WsDepthEvent)) })
+}
+
+// SubscribePartialDepth subscribes to the top levels, 5, 10 or 20, of the depth of a symbol
+func (s *StreamSession) SubscribePartialDepth(ctx context.Context, symbol string, levels int, handler WsDepthHandler) error {
+	return s.subscribeEvent(ctx, fmt.Sprintf("%s@depth%d", strings.ToLower(symbol), levels),
+		func() interface{} { return new(WsDepthEvent) },
+		func(event interface{}) { handler(event.(*WsDepthEvent)) })
+}
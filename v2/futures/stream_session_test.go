@@ -0,0 +1,55 @@
+package futures
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/stretchr/testify/suite"
+)
+
+type streamSessionTestSuite struct {
+	suite.Suite
+	srv *binancetest.Server
+	env *Environment
+}
+
+func TestStreamSession(t *testing.T) {
+	suite.Run(t, new(streamSessionTestSuite))
+}
+
+func (s *streamSessionTestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.env = &Environment{
+		APIURL:      s.srv.URL,
+		WsURL:       s.srv.WsURL(binancetest.Futures),
+		CombinedURL: s.srv.CombinedURL(binancetest.Futures),
+	}
+}
+
+func (s *streamSessionTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *streamSessionTestSuite) TestSubscribeDiffDepth() {
+	ctx := context.Background()
+	session, err := s.env.NewStreamSession(func(err error) {
+		s.Fail(err.Error())
+	})
+	s.Require().NoError(err)
+	defer session.Close()
+
+	depths := make(chan *WsDepthEvent, 2)
+	s.Require().NoError(session.SubscribeDiffDepth(ctx, "BTCUSDT", func(event *WsDepthEvent) {
+		depths <- event
+	}))
+	_, err = s.srv.PlaceOrder(binancetest.Futures, "BTCUSDT", "BUY", "100", "1")
+	s.Require().NoError(err)
+	_, err = s.srv.PlaceOrder(binancetest.Futures, "BTCUSDT", "BUY", "99", "1")
+	s.Require().NoError(err)
+
+	first, second := <-depths, <-depths
+	s.Equal([]Bid{{Price: "100.00000000", Quantity: "1.00000000"}}, first.Bids)
+	s.Equal(first.LastUpdateID, second.PrevLastUpdateID)
+	s.Equal([]Bid{{Price: "99.00000000", Quantity: "1.00000000"}}, second.Bids)
+}
@@ -23,11 +23,11 @@ func (s *ListBookTickersService) Symbol(symbol string) *ListBookTickersService {
 // Do send request
 func (s *ListBookTickersService) Do(ctx context.Context, opts ...RequestOption) (res []*BookTicker, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/ticker/bookTicker",
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/ticker/bookTicker",
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	data = common.ToJSONList(data)
@@ -66,11 +66,11 @@ func (s *ListPricesService) Symbol(symbol string) *ListPricesService {
 // Do send request
 func (s *ListPricesService) Do(ctx context.Context, opts ...RequestOption) (res []*SymbolPrice, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/ticker/price",
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/ticker/price",
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -106,11 +106,11 @@ func (s *ListPriceChangeStatsService) Symbol(symbol string) *ListPriceChangeStat
 // Do send request
 func (s *ListPriceChangeStatsService) Do(ctx context.Context, opts ...RequestOption) (res []*PriceChangeStats, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/ticker/24hr",
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/ticker/24hr",
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
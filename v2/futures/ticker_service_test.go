@@ -75,7 +75,7 @@ func (s *tickerServiceTestSuite) TestSingleBookTicker() {
 	symbol := "LTCBTC"
 
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbol", symbol)
+		e := newRequest().SetParam("symbol", symbol)
 		s.assertRequestEqual(e, r)
 	})
 
@@ -147,7 +147,7 @@ func (s *tickerServiceTestSuite) TestListSinglePrice() {
 
 	symbol := "LTCBTC"
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbol", symbol)
+		e := newRequest().SetParam("symbol", symbol)
 		s.assertRequestEqual(e, r)
 	})
 
@@ -193,7 +193,7 @@ func (s *tickerServiceTestSuite) TestPriceChangeStats() {
 
 	symbol := "BTCUSDT"
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbol", symbol)
+		e := newRequest().SetParam("symbol", symbol)
 		s.assertRequestEqual(e, r)
 	})
 	stats, err := s.client.NewListPriceChangeStatsService().Symbol(symbol).Do(newContext())
@@ -35,16 +35,16 @@ func (s *HistoricalTradesService) FromID(fromID int64) *HistoricalTradesService
 // Do send request
 func (s *HistoricalTradesService) Do(ctx context.Context, opts ...RequestOption) (res []*Trade, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/historicalTrades",
-		secType:  secTypeAPIKey,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/historicalTrades",
+		SecType:  secTypeAPIKey,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	if s.fromID != nil {
-		r.setParam("fromId", *s.fromID)
+		r.SetParam("fromId", *s.fromID)
 	}
 
 	data, _, err := s.c.callAPI(ctx, r, opts...)
@@ -128,21 +128,21 @@ func (s *AggTradesService) Limit(limit int) *AggTradesService {
 // Do send request
 func (s *AggTradesService) Do(ctx context.Context, opts ...RequestOption) (res []*AggTrade, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/aggTrades",
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/aggTrades",
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.fromID != nil {
-		r.setParam("fromId", *s.fromID)
+		r.SetParam("fromId", *s.fromID)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -189,12 +189,12 @@ func (s *RecentTradesService) Limit(limit int) *RecentTradesService {
 // Do send request
 func (s *RecentTradesService) Do(ctx context.Context, opts ...RequestOption) (res []*Trade, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/trades",
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/trades",
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -258,25 +258,25 @@ func (s *ListAccountTradeService) Limit(limit int) *ListAccountTradeService {
 // Do send request
 func (s *ListAccountTradeService) Do(ctx context.Context, opts ...RequestOption) (res []*AccountTrade, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/fapi/v1/userTrades",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/fapi/v1/userTrades",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.orderId != nil {
-		r.setParam("orderId", *s.orderId)
+		r.SetParam("orderId", *s.orderId)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.fromID != nil {
-		r.setParam("fromID", *s.fromID)
+		r.SetParam("fromID", *s.fromID)
 	}
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -35,7 +35,7 @@ func (s *tradeServiceTestSuite) TestAggregateTrades() {
 	endTime := int64(1498793709156)
 	limit := 1
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"symbol":    symbol,
 			"fromId":    fromID,
 			"startTime": startTime,
@@ -92,7 +92,7 @@ func (s *tradeServiceTestSuite) TestHistoricalTrades() {
 	limit := 3
 	fromID := int64(1)
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"symbol": symbol,
 			"limit":  limit,
 			"fromId": fromID,
@@ -133,7 +133,7 @@ func (s *tradeServiceTestSuite) TestRecentTrades() {
 	symbol := "LTCBTC"
 	limit := 3
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"symbol": symbol,
 			"limit":  limit,
 		})
@@ -193,7 +193,7 @@ func (s *tradeServiceTestSuite) TestAccountTradeList() {
 	fromID := int64(698759)
 	limit := 3
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":    symbol,
 			"startTime": startTime,
 			"endTime":   endTime,
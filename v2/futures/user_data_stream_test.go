@@ -0,0 +1,121 @@
+package futures
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type userDataStreamTestSuite struct {
+	suite.Suite
+	srv    *binancetest.Server
+	client *Client
+}
+
+func TestUserDataStream(t *testing.T) {
+	suite.Run(t, new(userDataStreamTestSuite))
+}
+
+func (s *userDataStreamTestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.client = NewClientWithEnvironment(s.srv.APIKey, s.srv.SecretKey, &Environment{
+		APIURL: s.srv.URL,
+		WsURL:  s.srv.WsURL(binancetest.Futures),
+	})
+}
+
+func (s *userDataStreamTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *userDataStreamTestSuite) createOrder(ctx context.Context, price string) {
+	_, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).
+		Quantity("0.1").Price(price).Do(ctx)
+	s.Require().NoError(err)
+}
+
+func (s *userDataStreamTestSuite) TestUserDataStream() {
+	ctx := context.Background()
+	s.srv.SetBalance(binancetest.Futures, "USDT", "1000")
+
+	var mu sync.Mutex
+	var states []common.WsState
+	s.client.Environment.Reconnect = common.NewReconnectPolicy()
+	s.client.Environment.Reconnect.OnStateChange = func(state common.WsState, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		states = append(states, state)
+	}
+
+	events := make(chan *WsUserDataEvent, 10)
+	snapshots := make(chan *UserDataSnapshot, 10)
+	stream := s.client.NewUserDataStream().OnEvent(func(event *WsUserDataEvent) {
+		events <- event
+	}).OnSnapshot(func(snapshot *UserDataSnapshot) {
+		snapshots <- snapshot
+	}).OnError(func(err error) {
+		s.Fail(err.Error())
+	})
+	s.Require().NoError(stream.Start(ctx))
+	s.Equal(ErrUserDataStreamStarted, stream.Start(ctx))
+	listenKey := stream.ListenKey()
+
+	waitSnapshot := func() *UserDataSnapshot {
+		select {
+		case snapshot := <-snapshots:
+			return snapshot
+		case <-time.After(time.Second):
+			s.FailNow("timeout waiting for a snapshot")
+			return nil
+		}
+	}
+	waitOrderTradeUpdate := func() *WsUserDataEvent {
+		for {
+			select {
+			case event := <-events:
+				if event.Event == UserDataEventTypeOrderTradeUpdate {
+					return event
+				}
+			case <-time.After(time.Second):
+				s.FailNow("timeout waiting for an order trade update")
+				return nil
+			}
+		}
+	}
+
+	snapshot := waitSnapshot()
+	s.Require().NotNil(snapshot.Account)
+	s.Empty(snapshot.OpenOrders)
+
+	// the listenKeyExpired event is handled, then a new listen key is created
+	s.Equal(1, s.srv.ExpireListenKeys(binancetest.Futures))
+	waitSnapshot()
+	s.NotEqual(listenKey, stream.ListenKey())
+	s.createOrder(ctx, "100")
+	update := waitOrderTradeUpdate()
+	s.Equal("100.00000000", update.OrderTradeUpdate.OriginalPrice)
+
+	mu.Lock()
+	s.Equal([]common.WsState{common.WsStateReconnecting, common.WsStateConnected}, states)
+	mu.Unlock()
+
+	stream.Close()
+	select {
+	case <-stream.Done():
+	case <-time.After(time.Second):
+		s.FailNow("stream not stopped")
+	}
+}
+
+func (s *userDataStreamTestSuite) TestNotStarted() {
+	stream := s.client.NewUserDataStream()
+	s.Empty(stream.ListenKey())
+	stream.Close()
+	<-stream.Done()
+}
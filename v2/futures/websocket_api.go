@@ -0,0 +1,134 @@
+package futures
+
+import (
+	"context"
+
+	"github.com/pooyakn/go-binance/v2/internal/transport"
+	"github.com/pooyakn/go-binance/v2/internal/wsconn"
+)
+
+// WebSocket API endpoints
+var (
+	baseWsAPIMainURL    = "wss://ws-fapi.binance.com/ws-fapi/v1"
+	baseWsAPITestnetURL = "wss://testnet.binancefuture.com/ws-fapi/v1"
+)
+
+// SetWsAPIEndpoints sets the endpoints of the WebSocket API
+func SetWsAPIEndpoints(main, testnet string) {
+	baseWsAPIMainURL = main
+	baseWsAPITestnetURL = testnet
+}
+
+// wsAPIMethods are the WebSocket API methods equivalent to the REST endpoints
+var wsAPIMethods = transport.WsAPIMethods{
+	"GET /fapi/v1/depth":        "depth",
+	"GET /fapi/v1/ticker/price": "ticker.price",
+	"POST /fapi/v1/order":       "order.place",
+	"GET /fapi/v1/order":        "order.status",
+	"DELETE /fapi/v1/order":     "order.cancel",
+	"GET /fapi/v2/account":      "v2/account.status",
+	"GET /fapi/v2/balance":      "v2/account.balance",
+	"POST /fapi/v1/listenKey":   "userDataStream.start",
+	"PUT /fapi/v1/listenKey":    "userDataStream.ping",
+	"DELETE /fapi/v1/listenKey": "userDataStream.stop",
+}
+
+// WsAPIClient sends requests over a WebSocket API connection instead of an
+// HTTP request per call. Its services are the ones of the REST client, with
+// the same parameters and responses, and use the settings of the client it
+// was created from, e.g. the signer, the time sync and the rate limiter.
+// The order count headers of the REST API are not available.
+type WsAPIClient struct {
+	c    *Client
+	conn *wsconn.APIConn
+}
+
+// NewWsAPIClient connects to the WebSocket API of the client environment.
+// errHandler is called with the connection errors.
+func (c *Client) NewWsAPIClient(errHandler ErrHandler) (*WsAPIClient, error) {
+	env := c.Environment
+	if env == nil {
+		env = DefaultEnvironment()
+	}
+	cfg := env.newWsConfig(env.WsAPIURL)
+	conn, err := wsconn.DialAPI(cfg.connConfig(), errHandler)
+	if err != nil {
+		return nil, err
+	}
+	client := *c
+	client.wsAPI = conn
+	return &WsAPIClient{c: &client, conn: conn}, nil
+}
+
+// Logon authenticates the session with the API key of the client, so that the
+// following requests are neither signed nor sent with the API key. Binance
+// only accepts Ed25519 keys, see common.NewEd25519Signer. The session must be
+// authenticated again after a reconnection.
+func (c *WsAPIClient) Logon(ctx context.Context) error {
+	return c.c.transport().WsAPILogon(ctx, c.conn)
+}
+
+// Logout forgets the authentication of the session
+func (c *WsAPIClient) Logout(ctx context.Context) error {
+	return c.c.transport().WsAPILogout(ctx, c.conn)
+}
+
+// Close closes the connection
+func (c *WsAPIClient) Close() {
+	c.conn.Close()
+}
+
+// Done returns a channel closed once the connection is closed
+func (c *WsAPIClient) Done() <-chan struct{} {
+	return c.conn.Done()
+}
+
+// NewDepthService init depth service
+func (c *WsAPIClient) NewDepthService() *DepthService {
+	return c.c.NewDepthService()
+}
+
+// NewListPricesService init listing prices service
+func (c *WsAPIClient) NewListPricesService() *ListPricesService {
+	return c.c.NewListPricesService()
+}
+
+// NewCreateOrderService init creating order service
+func (c *WsAPIClient) NewCreateOrderService() *CreateOrderService {
+	return c.c.NewCreateOrderService()
+}
+
+// NewGetOrderService init get order service
+func (c *WsAPIClient) NewGetOrderService() *GetOrderService {
+	return c.c.NewGetOrderService()
+}
+
+// NewCancelOrderService init cancel order service
+func (c *WsAPIClient) NewCancelOrderService() *CancelOrderService {
+	return c.c.NewCancelOrderService()
+}
+
+// NewGetAccountService init getting account service
+func (c *WsAPIClient) NewGetAccountService() *GetAccountService {
+	return c.c.NewGetAccountService()
+}
+
+// NewGetBalanceService init getting balance service
+func (c *WsAPIClient) NewGetBalanceService() *GetBalanceService {
+	return c.c.NewGetBalanceService()
+}
+
+// NewStartUserStreamService init starting user stream service
+func (c *WsAPIClient) NewStartUserStreamService() *StartUserStreamService {
+	return c.c.NewStartUserStreamService()
+}
+
+// NewKeepaliveUserStreamService init keep alive user stream service
+func (c *WsAPIClient) NewKeepaliveUserStreamService() *KeepaliveUserStreamService {
+	return c.c.NewKeepaliveUserStreamService()
+}
+
+// NewCloseUserStreamService init closing user stream service
+func (c *WsAPIClient) NewCloseUserStreamService() *CloseUserStreamService {
+	return c.c.NewCloseUserStreamService()
+}
@@ -0,0 +1,69 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsAPITestSuite struct {
+	suite.Suite
+	srv    *binancetest.Server
+	client *Client
+}
+
+func TestWsAPIClient(t *testing.T) {
+	suite.Run(t, new(wsAPITestSuite))
+}
+
+func (s *wsAPITestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.client = NewClientWithEnvironment(s.srv.APIKey, s.srv.SecretKey, &Environment{
+		APIURL:   s.srv.URL,
+		WsAPIURL: s.srv.WsAPIURL(binancetest.Futures),
+	})
+}
+
+func (s *wsAPITestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *wsAPITestSuite) TestOrders() {
+	ctx := context.Background()
+	c, err := s.client.NewWsAPIClient(func(err error) {
+		s.Fail(err.Error())
+	})
+	s.Require().NoError(err)
+	defer c.Close()
+	s.srv.SetBalance(binancetest.Futures, "USDT", "1000")
+	_, err = s.srv.PlaceOrder(binancetest.Futures, "BTCUSDT", "BUY", "100", "1")
+	s.Require().NoError(err)
+
+	res, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeMarket).Quantity("0.5").Do(ctx)
+	s.Require().NoError(err)
+	s.Equal(OrderStatusTypeFilled, res.Status)
+	s.Equal("100.00000000", res.AvgPrice)
+
+	order, err := c.NewGetOrderService().Symbol("BTCUSDT").OrderID(res.OrderID).Do(ctx)
+	s.Require().NoError(err)
+	s.Equal("0.50000000", order.ExecutedQuantity)
+
+	balances, err := c.NewGetBalanceService().Do(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(balances, 1)
+
+	_, err = c.NewCancelOrderService().Symbol("BTCUSDT").OrderID(res.OrderID).Do(ctx)
+	var apiErr *common.APIError
+	s.Require().True(errors.As(err, &apiErr), err)
+	s.Equal("DELETE", apiErr.Method)
+	s.Equal("/fapi/v1/order", apiErr.Endpoint)
+
+	listenKey, err := c.NewStartUserStreamService().Do(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(c.NewKeepaliveUserStreamService().ListenKey(listenKey).Do(ctx))
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/pooyakn/go-binance/v2/common"
 )
 
 // Endpoints
@@ -21,6 +23,11 @@ var (
 	WebsocketTimeout = time.Second * 60
 	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
 	WebsocketKeepalive = false
+	// WebsocketLogger logs the websocket connection events, listen keys are redacted
+	WebsocketLogger = common.NewNopLogger()
+	// WebsocketReconnect enables reconnecting the websocket streams of the
+	// default environment when set, e.g. to common.NewReconnectPolicy()
+	WebsocketReconnect *common.ReconnectPolicy
 	// UseTestnet switch all the WS streams from production to the testnet
 	UseTestnet = false
 )
@@ -33,24 +40,48 @@ func SetWsEndpoints(main, testnet, combinedMain, combinedTestnet string) {
 	baseCombinedTestnetURL = combinedTestnet
 }
 
-// getWsEndpoint return the base endpoint of the WS according the UseTestnet flag
-func getWsEndpoint() string {
-	if UseTestnet {
-		return baseWsTestnetUrl
+// wsCombinedServe serve the combined streams, the data of their messages is
+// decoded into events of type E
+func wsCombinedServe[E any](e *Environment, streams []string, handler func(event *E), errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(e.CombinedURL + strings.Join(streams, "/"))
+	wsHandler := func(message []byte) {
+		var m wsCombinedMessage
+		err := json.Unmarshal(message, &m)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		event := new(E)
+		err = json.Unmarshal(m.Data, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		cfg.stamp(event)
+		handler(event)
 	}
-	return baseWsMainUrl
+	return wsServe(cfg, wsHandler, errHandler)
 }
 
-// getCombinedEndpoint return the base endpoint of the combined stream according the UseTestnet flag
-func getCombinedEndpoint() string {
-	if UseTestnet {
-		return baseCombinedTestnetURL
+// wsCombinedMessage define the message of a combined stream
+type wsCombinedMessage struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// combinedStreams returns the lowercase streams "<symbol><suffix>" of symbols
+func combinedStreams(symbols []string, suffix string) []string {
+	streams := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		streams = append(streams, strings.ToLower(s)+suffix)
 	}
-	return baseCombinedMainURL
+	return streams
 }
 
 // WsAggTradeEvent define websocket aggTrde event.
 type WsAggTradeEvent struct {
+	common.WsReceived
+
 	Event            string `json:"e"`
 	Time             int64  `json:"E"`
 	Symbol           string `json:"s"`
@@ -68,8 +99,13 @@ type WsAggTradeHandler func(event *WsAggTradeEvent)
 
 // WsAggTradeServe serve websocket that push trade information that is aggregated for a single taker order.
 func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@aggTrade", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAggTradeServe(symbol, handler, errHandler)
+}
+
+// WsAggTradeServe serve websocket that push trade information that is aggregated for a single taker order.
+func (e *Environment) WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@aggTrade", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsAggTradeEvent)
 		err := json.Unmarshal(message, &event)
@@ -77,6 +113,7 @@ func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHan
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -84,41 +121,18 @@ func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHan
 
 // WsCombinedAggTradeServe is similar to WsAggTradeServe, but it handles multiple symbols
 func WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
-	for _, s := range symbols {
-		endpoint += fmt.Sprintf("%s@aggTrade", strings.ToLower(s)) + "/"
-	}
-	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
-	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-
-		stream := j.Get("stream").MustString()
-		data := j.Get("data").MustMap()
-
-		symbol := strings.Split(stream, "@")[0]
-
-		jsonData, _ := json.Marshal(data)
-
-		event := new(WsAggTradeEvent)
-		err = json.Unmarshal(jsonData, event)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-		event.Symbol = strings.ToUpper(symbol)
+	return DefaultEnvironment().WsCombinedAggTradeServe(symbols, handler, errHandler)
+}
 
-		handler(event)
-	}
-	return wsServe(cfg, wsHandler, errHandler)
+// WsCombinedAggTradeServe is similar to WsAggTradeServe, but it handles multiple symbols
+func (e *Environment) WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedServe(e, combinedStreams(symbols, "@aggTrade"), handler, errHandler)
 }
 
 // WsMarkPriceEvent define websocket markPriceUpdate event.
 type WsMarkPriceEvent struct {
+	common.WsReceived
+
 	Event                string `json:"e"`
 	Time                 int64  `json:"E"`
 	Symbol               string `json:"s"`
@@ -132,8 +146,8 @@ type WsMarkPriceEvent struct {
 // WsMarkPriceHandler handle websocket that pushes price and funding rate for a single symbol.
 type WsMarkPriceHandler func(event *WsMarkPriceEvent)
 
-func wsMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	cfg := newWsConfig(endpoint)
+func (e *Environment) wsMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarkPriceEvent)
 		err := json.Unmarshal(message, &event)
@@ -141,6 +155,7 @@ func wsMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHandler Er
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -148,12 +163,22 @@ func wsMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHandler Er
 
 // WsMarkPriceServe serve websocket that pushes price and funding rate for a single symbol.
 func WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@markPrice", getWsEndpoint(), strings.ToLower(symbol))
-	return wsMarkPriceServe(endpoint, handler, errHandler)
+	return DefaultEnvironment().WsMarkPriceServe(symbol, handler, errHandler)
+}
+
+// WsMarkPriceServe serve websocket that pushes price and funding rate for a single symbol.
+func (e *Environment) WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@markPrice", e.WsURL, strings.ToLower(symbol))
+	return e.wsMarkPriceServe(endpoint, handler, errHandler)
 }
 
 // WsMarkPriceServeWithRate serve websocket that pushes price and funding rate for a single symbol and rate.
 func WsMarkPriceServeWithRate(symbol string, rate time.Duration, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsMarkPriceServeWithRate(symbol, rate, handler, errHandler)
+}
+
+// WsMarkPriceServeWithRate serve websocket that pushes price and funding rate for a single symbol and rate.
+func (e *Environment) WsMarkPriceServeWithRate(symbol string, rate time.Duration, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	var rateStr string
 	switch rate {
 	case 3 * time.Second:
@@ -163,49 +188,28 @@ func WsMarkPriceServeWithRate(symbol string, rate time.Duration, handler WsMarkP
 	default:
 		return nil, nil, errors.New("Invalid rate")
 	}
-	endpoint := fmt.Sprintf("%s/%s@markPrice%s", getWsEndpoint(), strings.ToLower(symbol), rateStr)
-	return wsMarkPriceServe(endpoint, handler, errHandler)
-}
-
-func wsCombinedMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	cfg := newWsConfig(endpoint)
-	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-
-		data := j.Get("data").MustMap()
-		jsonData, _ := json.Marshal(data)
-
-		event := new(WsMarkPriceEvent)
-		err = json.Unmarshal(jsonData, event)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-
-		handler(event)
-	}
-
-	return wsServe(cfg, wsHandler, errHandler)
+	endpoint := fmt.Sprintf("%s/%s@markPrice%s", e.WsURL, strings.ToLower(symbol), rateStr)
+	return e.wsMarkPriceServe(endpoint, handler, errHandler)
 }
 
 // WsCombinedMarkPriceServe is similar to WsMarkPriceServe, but it handles multiple symbols
 func WsCombinedMarkPriceServe(symbols []string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
-	for _, s := range symbols {
-		endpoint += fmt.Sprintf("%s@markPrice", strings.ToLower(s)) + "/"
-	}
-	endpoint = endpoint[:len(endpoint)-1]
+	return DefaultEnvironment().WsCombinedMarkPriceServe(symbols, handler, errHandler)
+}
 
-	return wsCombinedMarkPriceServe(endpoint, handler, errHandler)
+// WsCombinedMarkPriceServe is similar to WsMarkPriceServe, but it handles multiple symbols
+func (e *Environment) WsCombinedMarkPriceServe(symbols []string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedServe(e, combinedStreams(symbols, "@markPrice"), handler, errHandler)
 }
 
 // WsCombinedMarkPriceServeWithRate is similar to WsMarkPriceServeWithRate, but it for multiple symbols
 func WsCombinedMarkPriceServeWithRate(symbolLevels map[string]time.Duration, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+	return DefaultEnvironment().WsCombinedMarkPriceServeWithRate(symbolLevels, handler, errHandler)
+}
+
+// WsCombinedMarkPriceServeWithRate is similar to WsMarkPriceServeWithRate, but it for multiple symbols
+func (e *Environment) WsCombinedMarkPriceServeWithRate(symbolLevels map[string]time.Duration, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	streams := make([]string, 0, len(symbolLevels))
 	for symbol, rate := range symbolLevels {
 		var rateStr string
 		switch rate {
@@ -216,13 +220,9 @@ func WsCombinedMarkPriceServeWithRate(symbolLevels map[string]time.Duration, han
 		default:
 			return nil, nil, fmt.Errorf("invalid rate. Symbol %s (rate %d)", symbol, rate)
 		}
-
-		endpoint += fmt.Sprintf("%s@markPrice%s", strings.ToLower(symbol), rateStr) + "/"
+		streams = append(streams, fmt.Sprintf("%s@markPrice%s", strings.ToLower(symbol), rateStr))
 	}
-
-	endpoint = endpoint[:len(endpoint)-1]
-
-	return wsCombinedMarkPriceServe(endpoint, handler, errHandler)
+	return wsCombinedServe(e, streams, handler, errHandler)
 }
 
 // WsAllMarkPriceEvent defines an array of websocket markPriceUpdate events.
@@ -231,8 +231,8 @@ type WsAllMarkPriceEvent []*WsMarkPriceEvent
 // WsAllMarkPriceHandler handle websocket that pushes price and funding rate for all symbol.
 type WsAllMarkPriceHandler func(event WsAllMarkPriceEvent)
 
-func wsAllMarkPriceServe(endpoint string, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	cfg := newWsConfig(endpoint)
+func (e *Environment) wsAllMarkPriceServe(endpoint string, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarkPriceEvent
 		err := json.Unmarshal(message, &event)
@@ -240,6 +240,7 @@ func wsAllMarkPriceServe(endpoint string, handler WsAllMarkPriceHandler, errHand
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -247,12 +248,22 @@ func wsAllMarkPriceServe(endpoint string, handler WsAllMarkPriceHandler, errHand
 
 // WsAllMarkPriceServe serve websocket that pushes price and funding rate for all symbol.
 func WsAllMarkPriceServe(handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!markPrice@arr", getWsEndpoint())
-	return wsAllMarkPriceServe(endpoint, handler, errHandler)
+	return DefaultEnvironment().WsAllMarkPriceServe(handler, errHandler)
+}
+
+// WsAllMarkPriceServe serve websocket that pushes price and funding rate for all symbol.
+func (e *Environment) WsAllMarkPriceServe(handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!markPrice@arr", e.WsURL)
+	return e.wsAllMarkPriceServe(endpoint, handler, errHandler)
 }
 
 // WsAllMarkPriceServeWithRate serve websocket that pushes price and funding rate for all symbol and rate.
 func WsAllMarkPriceServeWithRate(rate time.Duration, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllMarkPriceServeWithRate(rate, handler, errHandler)
+}
+
+// WsAllMarkPriceServeWithRate serve websocket that pushes price and funding rate for all symbol and rate.
+func (e *Environment) WsAllMarkPriceServeWithRate(rate time.Duration, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	var rateStr string
 	switch rate {
 	case 3 * time.Second:
@@ -262,12 +273,14 @@ func WsAllMarkPriceServeWithRate(rate time.Duration, handler WsAllMarkPriceHandl
 	default:
 		return nil, nil, errors.New("Invalid rate")
 	}
-	endpoint := fmt.Sprintf("%s/!markPrice@arr%s", getWsEndpoint(), rateStr)
-	return wsAllMarkPriceServe(endpoint, handler, errHandler)
+	endpoint := fmt.Sprintf("%s/!markPrice@arr%s", e.WsURL, rateStr)
+	return e.wsAllMarkPriceServe(endpoint, handler, errHandler)
 }
 
 // WsKlineEvent define websocket kline event
 type WsKlineEvent struct {
+	common.WsReceived
+
 	Event  string  `json:"e"`
 	Time   int64   `json:"E"`
 	Symbol string  `json:"s"`
@@ -299,8 +312,13 @@ type WsKlineHandler func(event *WsKlineEvent)
 
 // WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
 func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@kline_%s", getWsEndpoint(), strings.ToLower(symbol), interval)
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
+func (e *Environment) WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@kline_%s", e.WsURL, strings.ToLower(symbol), interval)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsKlineEvent)
 		err := json.Unmarshal(message, event)
@@ -308,6 +326,7 @@ func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHan
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -315,41 +334,22 @@ func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHan
 
 // WsCombinedKlineServe is similar to WsKlineServe, but it handles multiple symbols with it interval
 func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
-	for symbol, interval := range symbolIntervalPair {
-		endpoint += fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval) + "/"
-	}
-	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
-	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-
-		stream := j.Get("stream").MustString()
-		data := j.Get("data").MustMap()
-
-		symbol := strings.Split(stream, "@")[0]
-
-		jsonData, _ := json.Marshal(data)
-
-		event := new(WsKlineEvent)
-		err = json.Unmarshal(jsonData, event)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-		event.Symbol = strings.ToUpper(symbol)
+	return DefaultEnvironment().WsCombinedKlineServe(symbolIntervalPair, handler, errHandler)
+}
 
-		handler(event)
+// WsCombinedKlineServe is similar to WsKlineServe, but it handles multiple symbols with it interval
+func (e *Environment) WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	streams := make([]string, 0, len(symbolIntervalPair))
+	for symbol, interval := range symbolIntervalPair {
+		streams = append(streams, fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval))
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return wsCombinedServe(e, streams, handler, errHandler)
 }
 
 // WsContinuousKlineEvent define websocket continuous kline event
 type WsContinuousKlineEvent struct {
+	common.WsReceived
+
 	Event        string            `json:"e"`
 	Time         int64             `json:"E"`
 	PairSymbol   string            `json:"ps"`
@@ -389,9 +389,15 @@ type WsContinuousKlineHandler func(event *WsContinuousKlineEvent)
 // WsContinuousKlineServe serve websocket continuous kline handler with a pair and contractType and interval like 15m, 30s
 func WsContinuousKlineServe(subscribeArgs *WsContinuousKlineSubcribeArgs, handler WsContinuousKlineHandler,
 	errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s_%s@continuousKline_%s", getWsEndpoint(), strings.ToLower(subscribeArgs.Pair),
+	return DefaultEnvironment().WsContinuousKlineServe(subscribeArgs, handler, errHandler)
+}
+
+// WsContinuousKlineServe serve websocket continuous kline handler with a pair and contractType and interval like 15m, 30s
+func (e *Environment) WsContinuousKlineServe(subscribeArgs *WsContinuousKlineSubcribeArgs, handler WsContinuousKlineHandler,
+	errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s_%s@continuousKline_%s", e.WsURL, strings.ToLower(subscribeArgs.Pair),
 		strings.ToLower(subscribeArgs.ContractType), subscribeArgs.Interval)
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsContinuousKlineEvent)
 		err := json.Unmarshal(message, event)
@@ -399,6 +405,7 @@ func WsContinuousKlineServe(subscribeArgs *WsContinuousKlineSubcribeArgs, handle
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -407,38 +414,24 @@ func WsContinuousKlineServe(subscribeArgs *WsContinuousKlineSubcribeArgs, handle
 // WsCombinedContinuousKlineServe is similar to WsContinuousKlineServe, but it handles multiple pairs of different contractType with its interval
 func WsCombinedContinuousKlineServe(subscribeArgsList []*WsContinuousKlineSubcribeArgs,
 	handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
-	for _, val := range subscribeArgsList {
-		endpoint += fmt.Sprintf("%s_%s@continuousKline_%s", strings.ToLower(val.Pair),
-			strings.ToLower(val.ContractType), val.Interval) + "/"
-	}
-	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
-	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-
-		data := j.Get("data").MustMap()
-
-		jsonData, _ := json.Marshal(data)
-
-		event := new(WsContinuousKlineEvent)
-		err = json.Unmarshal(jsonData, event)
-		if err != nil {
-			errHandler(err)
-			return
-		}
+	return DefaultEnvironment().WsCombinedContinuousKlineServe(subscribeArgsList, handler, errHandler)
+}
 
-		handler(event)
+// WsCombinedContinuousKlineServe is similar to WsContinuousKlineServe, but it handles multiple pairs of different contractType with its interval
+func (e *Environment) WsCombinedContinuousKlineServe(subscribeArgsList []*WsContinuousKlineSubcribeArgs,
+	handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	streams := make([]string, 0, len(subscribeArgsList))
+	for _, args := range subscribeArgsList {
+		streams = append(streams, fmt.Sprintf("%s_%s@continuousKline_%s", strings.ToLower(args.Pair),
+			strings.ToLower(args.ContractType), args.Interval))
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return wsCombinedServe(e, streams, handler, errHandler)
 }
 
 // WsMiniMarketTickerEvent define websocket mini market ticker event.
 type WsMiniMarketTickerEvent struct {
+	common.WsReceived
+
 	Event       string `json:"e"`
 	Time        int64  `json:"E"`
 	Symbol      string `json:"s"`
@@ -455,8 +448,13 @@ type WsMiniMarketTickerHandler func(event *WsMiniMarketTickerEvent)
 
 // WsMiniMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
 func WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@miniTicker", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsMiniMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsMiniMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
+func (e *Environment) WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@miniTicker", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMiniMarketTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -464,6 +462,7 @@ func WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, e
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -477,8 +476,13 @@ type WsAllMiniMarketTickerHandler func(event WsAllMiniMarketTickerEvent)
 
 // WsAllMiniMarketTickerServe serve websocket that pushes price and funding rate for all markets.
 func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!miniTicker@arr", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAllMiniMarketTickerServe(handler, errHandler)
+}
+
+// WsAllMiniMarketTickerServe serve websocket that pushes price and funding rate for all markets.
+func (e *Environment) WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!miniTicker@arr", e.WsURL)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMiniMarketTickerEvent
 		err := json.Unmarshal(message, &event)
@@ -486,6 +490,7 @@ func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -493,6 +498,8 @@ func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler
 
 // WsMarketTickerEvent define websocket market ticker event.
 type WsMarketTickerEvent struct {
+	common.WsReceived
+
 	Event              string `json:"e"`
 	Time               int64  `json:"E"`
 	Symbol             string `json:"s"`
@@ -518,8 +525,13 @@ type WsMarketTickerHandler func(event *WsMarketTickerEvent)
 
 // WsMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
 func WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@ticker", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
+func (e *Environment) WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@ticker", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarketTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -527,6 +539,7 @@ func WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandle
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -540,8 +553,13 @@ type WsAllMarketTickerHandler func(event WsAllMarketTickerEvent)
 
 // WsAllMarketTickerServe serve websocket that pushes price and funding rate for all markets.
 func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!ticker@arr", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAllMarketTickerServe(handler, errHandler)
+}
+
+// WsAllMarketTickerServe serve websocket that pushes price and funding rate for all markets.
+func (e *Environment) WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!ticker@arr", e.WsURL)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarketTickerEvent
 		err := json.Unmarshal(message, &event)
@@ -549,6 +567,7 @@ func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHand
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -556,6 +575,8 @@ func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHand
 
 // WsBookTickerEvent define websocket best book ticker event.
 type WsBookTickerEvent struct {
+	common.WsReceived
+
 	Event           string `json:"e"`
 	UpdateID        int64  `json:"u"`
 	Time            int64  `json:"E"`
@@ -572,8 +593,13 @@ type WsBookTickerHandler func(event *WsBookTickerEvent)
 
 // WsBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for a specified symbol.
 func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@bookTicker", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsBookTickerServe(symbol, handler, errHandler)
+}
+
+// WsBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for a specified symbol.
+func (e *Environment) WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@bookTicker", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -581,6 +607,7 @@ func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler Er
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -588,8 +615,13 @@ func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler Er
 
 // WsAllBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for all symbols.
 func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!bookTicker", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAllBookTickerServe(handler, errHandler)
+}
+
+// WsAllBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for all symbols.
+func (e *Environment) WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!bookTicker", e.WsURL)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -597,6 +629,7 @@ func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (d
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -604,6 +637,8 @@ func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (d
 
 // WsLiquidationOrderEvent define websocket liquidation order event.
 type WsLiquidationOrderEvent struct {
+	common.WsReceived
+
 	Event            string             `json:"e"`
 	Time             int64              `json:"E"`
 	LiquidationOrder WsLiquidationOrder `json:"o"`
@@ -629,8 +664,13 @@ type WsLiquidationOrderHandler func(event *WsLiquidationOrderEvent)
 
 // WsLiquidationOrderServe serve websocket that pushes force liquidation order information for specific symbol.
 func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@forceOrder", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsLiquidationOrderServe(symbol, handler, errHandler)
+}
+
+// WsLiquidationOrderServe serve websocket that pushes force liquidation order information for specific symbol.
+func (e *Environment) WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@forceOrder", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
 		err := json.Unmarshal(message, &event)
@@ -638,6 +678,7 @@ func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, e
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -645,8 +686,13 @@ func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, e
 
 // WsAllLiquidationOrderServe serve websocket that pushes force liquidation order information for all symbols.
 func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!forceOrder@arr", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAllLiquidationOrderServe(handler, errHandler)
+}
+
+// WsAllLiquidationOrderServe serve websocket that pushes force liquidation order information for all symbols.
+func (e *Environment) WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!forceOrder@arr", e.WsURL)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
 		err := json.Unmarshal(message, &event)
@@ -654,6 +700,7 @@ func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler Er
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -661,6 +708,8 @@ func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler Er
 
 // WsDepthEvent define websocket depth book event
 type WsDepthEvent struct {
+	common.WsReceived
+
 	Event            string `json:"e"`
 	Time             int64  `json:"E"`
 	TransactionTime  int64  `json:"T"`
@@ -675,127 +724,83 @@ type WsDepthEvent struct {
 // WsDepthHandler handle websocket depth event
 type WsDepthHandler func(event *WsDepthEvent)
 
-func wsPartialDepthServe(symbol string, levels int, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func (e *Environment) wsPartialDepthServe(symbol string, levels int, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	if levels != 5 && levels != 10 && levels != 20 {
 		return nil, nil, errors.New("Invalid levels")
 	}
 	levelsStr := fmt.Sprintf("%d", levels)
-	return wsDepthServe(symbol, levelsStr, rate, handler, errHandler)
+	return e.wsDepthServe(symbol, levelsStr, rate, handler, errHandler)
 }
 
 // WsPartialDepthServe serve websocket partial depth handler.
 func WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsPartialDepthServe(symbol, levels, nil, handler, errHandler)
+	return DefaultEnvironment().WsPartialDepthServe(symbol, levels, handler, errHandler)
+}
+
+// WsPartialDepthServe serve websocket partial depth handler.
+func (e *Environment) WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsPartialDepthServe(symbol, levels, nil, handler, errHandler)
 }
 
 // WsPartialDepthServeWithRate serve websocket partial depth handler with rate.
 func WsPartialDepthServeWithRate(symbol string, levels int, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsPartialDepthServe(symbol, levels, &rate, handler, errHandler)
+	return DefaultEnvironment().WsPartialDepthServeWithRate(symbol, levels, rate, handler, errHandler)
+}
+
+// WsPartialDepthServeWithRate serve websocket partial depth handler with rate.
+func (e *Environment) WsPartialDepthServeWithRate(symbol string, levels int, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsPartialDepthServe(symbol, levels, &rate, handler, errHandler)
 }
 
 // WsDiffDepthServe serve websocket diff. depth handler.
 func WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsDepthServe(symbol, "", nil, handler, errHandler)
+	return DefaultEnvironment().WsDiffDepthServe(symbol, handler, errHandler)
+}
+
+// WsDiffDepthServe serve websocket diff. depth handler.
+func (e *Environment) WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsDepthServe(symbol, "", nil, handler, errHandler)
 }
 
-// WsCombinedDepthServe is similar to WsPartialDepthServe, but it for multiple symbols
+// WsCombinedDepthServe serve the partial depth streams of several symbols,
+// symbolLevels maps each symbol to its levels, "5", "10" or "20", optionally
+// followed by the update speed, e.g. "20@500ms"
 func WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+	return DefaultEnvironment().WsCombinedDepthServe(symbolLevels, handler, errHandler)
+}
+
+// WsCombinedDepthServe serve the partial depth streams of several symbols,
+// symbolLevels maps each symbol to its levels, "5", "10" or "20", optionally
+// followed by the update speed, e.g. "20@500ms"
+func (e *Environment) WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	streams := make([]string, 0, len(symbolLevels))
 	for s, l := range symbolLevels {
-		endpoint += fmt.Sprintf("%s@depth%s", strings.ToLower(s), l) + "/"
+		streams = append(streams, fmt.Sprintf("%s@depth%s", strings.ToLower(s), l))
 	}
-	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
-	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-		event := new(WsDepthEvent)
-		data := j.Get("data").MustMap()
-		event.Event = data["e"].(string)
-		event.Time, _ = data["E"].(json.Number).Int64()
-		event.TransactionTime, _ = data["T"].(json.Number).Int64()
-		event.Symbol = data["s"].(string)
-		event.FirstUpdateID, _ = data["U"].(json.Number).Int64()
-		event.LastUpdateID, _ = data["u"].(json.Number).Int64()
-		event.PrevLastUpdateID, _ = data["pu"].(json.Number).Int64()
-		bidsLen := len(data["b"].([]interface{}))
-		event.Bids = make([]Bid, bidsLen)
-		for i := 0; i < bidsLen; i++ {
-			item := data["b"].([]interface{})[i].([]interface{})
-			event.Bids[i] = Bid{
-				Price:    item[0].(string),
-				Quantity: item[1].(string),
-			}
-		}
-		asksLen := len(data["a"].([]interface{}))
-		event.Asks = make([]Ask, asksLen)
-		for i := 0; i < asksLen; i++ {
-			item := data["a"].([]interface{})[i].([]interface{})
-			event.Asks[i] = Ask{
-				Price:    item[0].(string),
-				Quantity: item[1].(string),
-			}
-		}
-		handler(event)
-	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return wsCombinedServe(e, streams, handler, errHandler)
 }
 
 // WsCombinedDiffDepthServe is similar to WsDiffDepthServe, but it for multiple symbols
 func WsCombinedDiffDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
-	for _, s := range symbols {
-		endpoint += fmt.Sprintf("%s@depth", strings.ToLower(s)) + "/"
-	}
-	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
-	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-		event := new(WsDepthEvent)
-		data := j.Get("data").MustMap()
-		event.Event = data["e"].(string)
-		event.Time, _ = data["E"].(json.Number).Int64()
-		event.TransactionTime, _ = data["T"].(json.Number).Int64()
-		event.Symbol = data["s"].(string)
-		event.FirstUpdateID, _ = data["U"].(json.Number).Int64()
-		event.LastUpdateID, _ = data["u"].(json.Number).Int64()
-		event.PrevLastUpdateID, _ = data["pu"].(json.Number).Int64()
-		bidsLen := len(data["b"].([]interface{}))
-		event.Bids = make([]Bid, bidsLen)
-		for i := 0; i < bidsLen; i++ {
-			item := data["b"].([]interface{})[i].([]interface{})
-			event.Bids[i] = Bid{
-				Price:    item[0].(string),
-				Quantity: item[1].(string),
-			}
-		}
-		asksLen := len(data["a"].([]interface{}))
-		event.Asks = make([]Ask, asksLen)
-		for i := 0; i < asksLen; i++ {
-			item := data["a"].([]interface{})[i].([]interface{})
-			event.Asks[i] = Ask{
-				Price:    item[0].(string),
-				Quantity: item[1].(string),
-			}
-		}
-		handler(event)
-	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return DefaultEnvironment().WsCombinedDiffDepthServe(symbols, handler, errHandler)
+}
+
+// WsCombinedDiffDepthServe is similar to WsDiffDepthServe, but it for multiple symbols
+func (e *Environment) WsCombinedDiffDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedServe(e, combinedStreams(symbols, "@depth"), handler, errHandler)
 }
 
 // WsDiffDepthServeWithRate serve websocket diff. depth handler with rate.
 func WsDiffDepthServeWithRate(symbol string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsDepthServe(symbol, "", &rate, handler, errHandler)
+	return DefaultEnvironment().WsDiffDepthServeWithRate(symbol, rate, handler, errHandler)
 }
 
-func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+// WsDiffDepthServeWithRate serve websocket diff. depth handler with rate.
+func (e *Environment) WsDiffDepthServeWithRate(symbol string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsDepthServe(symbol, "", &rate, handler, errHandler)
+}
+
+func (e *Environment) wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	var rateStr string
 	if rate != nil {
 		switch *rate {
@@ -809,8 +814,8 @@ func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsD
 			return nil, nil, errors.New("Invalid rate")
 		}
 	}
-	endpoint := fmt.Sprintf("%s/%s@depth%s%s", getWsEndpoint(), strings.ToLower(symbol), levels, rateStr)
-	cfg := newWsConfig(endpoint)
+	endpoint := fmt.Sprintf("%s/%s@depth%s%s", e.WsURL, strings.ToLower(symbol), levels, rateStr)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -843,6 +848,7 @@ func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsD
 				Quantity: item.GetIndex(1).MustString(),
 			}
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -850,6 +856,8 @@ func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsD
 
 // WsBLVTInfoEvent define websocket BLVT info event
 type WsBLVTInfoEvent struct {
+	common.WsReceived
+
 	Event          string         `json:"e"`
 	Time           int64          `json:"E"`
 	Symbol         string         `json:"s"`
@@ -872,8 +880,13 @@ type WsBLVTInfoHandler func(event *WsBLVTInfoEvent)
 
 // WsBLVTInfoServe serve BLVT info stream
 func WsBLVTInfoServe(name string, handler WsBLVTInfoHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@tokenNav", getWsEndpoint(), strings.ToUpper(name))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsBLVTInfoServe(name, handler, errHandler)
+}
+
+// WsBLVTInfoServe serve BLVT info stream
+func (e *Environment) WsBLVTInfoServe(name string, handler WsBLVTInfoHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@tokenNav", e.WsURL, strings.ToUpper(name))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBLVTInfoEvent)
 		err := json.Unmarshal(message, &event)
@@ -881,6 +894,7 @@ func WsBLVTInfoServe(name string, handler WsBLVTInfoHandler, errHandler ErrHandl
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -888,6 +902,8 @@ func WsBLVTInfoServe(name string, handler WsBLVTInfoHandler, errHandler ErrHandl
 
 // WsBLVTKlineEvent define BLVT kline event
 type WsBLVTKlineEvent struct {
+	common.WsReceived
+
 	Event  string      `json:"e"`
 	Time   int64       `json:"E"`
 	Symbol string      `json:"s"`
@@ -915,8 +931,13 @@ type WsBLVTKlineHandler func(event *WsBLVTKlineEvent)
 
 // WsBLVTKlineServe serve BLVT kline stream
 func WsBLVTKlineServe(name string, interval string, handler WsBLVTKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@nav_Kline_%s", getWsEndpoint(), strings.ToUpper(name), interval)
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsBLVTKlineServe(name, interval, handler, errHandler)
+}
+
+// WsBLVTKlineServe serve BLVT kline stream
+func (e *Environment) WsBLVTKlineServe(name string, interval string, handler WsBLVTKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@nav_Kline_%s", e.WsURL, strings.ToUpper(name), interval)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBLVTKlineEvent)
 		err := json.Unmarshal(message, event)
@@ -924,6 +945,7 @@ func WsBLVTKlineServe(name string, interval string, handler WsBLVTKlineHandler,
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -931,6 +953,8 @@ func WsBLVTKlineServe(name string, interval string, handler WsBLVTKlineHandler,
 
 // WsCompositeIndexEvent websocket composite index event
 type WsCompositeIndexEvent struct {
+	common.WsReceived
+
 	Event       string          `json:"e"`
 	Time        int64           `json:"E"`
 	Symbol      string          `json:"s"`
@@ -950,8 +974,13 @@ type WsCompositeIndexHandler func(event *WsCompositeIndexEvent)
 
 // WsCompositiveIndexServe serve composite index information for index symbols
 func WsCompositiveIndexServe(symbol string, handler WsCompositeIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@compositeIndex", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsCompositiveIndexServe(symbol, handler, errHandler)
+}
+
+// WsCompositiveIndexServe serve composite index information for index symbols
+func (e *Environment) WsCompositiveIndexServe(symbol string, handler WsCompositeIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@compositeIndex", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsCompositeIndexEvent)
 		err := json.Unmarshal(message, event)
@@ -959,6 +988,7 @@ func WsCompositiveIndexServe(symbol string, handler WsCompositeIndexHandler, err
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -966,6 +996,8 @@ func WsCompositiveIndexServe(symbol string, handler WsCompositeIndexHandler, err
 
 // WsUserDataEvent define user data event
 type WsUserDataEvent struct {
+	common.WsReceived
+
 	Event               UserDataEventType     `json:"e"`
 	Time                int64                 `json:"E"`
 	CrossWalletBalance  string                `json:"cw"`
@@ -1050,8 +1082,13 @@ type WsUserDataHandler func(event *WsUserDataEvent)
 
 // WsUserDataServe serve user data handler with listen key
 func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsUserDataServe(listenKey, handler, errHandler)
+}
+
+// WsUserDataServe serve user data handler with listen key
+func (e *Environment) WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", e.WsURL, listenKey)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsUserDataEvent)
 		err := json.Unmarshal(message, event)
@@ -1059,6 +1096,7 @@ func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler Err
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
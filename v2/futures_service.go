@@ -34,16 +34,16 @@ func (s *FuturesTransferService) Type(transferType FuturesTransferType) *Futures
 // Do send request
 func (s *FuturesTransferService) Do(ctx context.Context, opts ...RequestOption) (res *TransactionResponse, err error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: "/sapi/v1/futures/transfer",
-		secType:  secTypeSigned,
+		Method:   http.MethodPost,
+		Endpoint: "/sapi/v1/futures/transfer",
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"asset":  s.asset,
 		"amount": s.amount,
 		"type":   s.transferType,
 	}
-	r.setFormParams(m)
+	r.SetFormParams(m)
 	res = new(TransactionResponse)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -99,22 +99,22 @@ func (s *ListFuturesTransferService) Size(size int64) *ListFuturesTransferServic
 // Do send request
 func (s *ListFuturesTransferService) Do(ctx context.Context, opts ...RequestOption) (res *FuturesTransferHistory, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/futures/transfer",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/futures/transfer",
+		SecType:  secTypeSigned,
 	}
-	r.setParams(params{
+	r.SetParams(params{
 		"asset":     s.asset,
 		"startTime": s.startTime,
 	})
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.current != nil {
-		r.setParam("current", *s.current)
+		r.SetParam("current", *s.current)
 	}
 	if s.size != nil {
-		r.setParam("size", *s.size)
+		r.SetParam("size", *s.size)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
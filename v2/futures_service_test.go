@@ -24,7 +24,7 @@ func (s *futuresTransferTestSuite) TestTransfer() {
 	amount := "1.000"
 	transferType := FuturesTransferTypeToFutures
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"asset":  asset,
 			"amount": amount,
 			"type":   transferType,
@@ -63,7 +63,7 @@ func (s *futuresTransferTestSuite) TestListFuturesTransfer() {
 	asset := "USDT"
 	startTime := int64(1555056425000)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"asset":     asset,
 			"startTime": startTime,
 		})
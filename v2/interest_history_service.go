@@ -57,25 +57,25 @@ func (s *InterestHistoryService) Size(size int32) *InterestHistoryService {
 // Do sends the request.
 func (s *InterestHistoryService) Do(ctx context.Context) (*InterestHistory, error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/lending/union/interestHistory",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/lending/union/interestHistory",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("lendingType", s.lendingType)
+	r.SetParam("lendingType", s.lendingType)
 	if s.asset != nil {
-		r.setParam("asset", *s.asset)
+		r.SetParam("asset", *s.asset)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.current != nil {
-		r.setParam("current", *s.current)
+		r.SetParam("current", *s.current)
 	}
 	if s.size != nil {
-		r.setParam("size", *s.size)
+		r.SetParam("size", *s.size)
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
@@ -39,7 +39,7 @@ func (s *interestHistoryServiceTestSuite) TestInterestHistory() {
 
 	lendingType := LendingTypeFlexible
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"lendingType": lendingType,
 		})
 		s.assertRequestEqual(e, r)
@@ -0,0 +1,96 @@
+package orderbook
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/pooyakn/go-binance/v2/common"
+)
+
+// levels are the price levels of a side of a book, sorted from the best price
+type levels struct {
+	// desc sorts the prices in descending order, for the bids
+	desc   bool
+	prices []float64
+	levels []common.PriceLevel
+}
+
+// search returns the index of price, or the index where it would be inserted
+func (l *levels) search(price float64) int {
+	return sort.Search(len(l.prices), func(i int) bool {
+		if l.desc {
+			return l.prices[i] <= price
+		}
+		return l.prices[i] >= price
+	})
+}
+
+// set replaces the quantity of a level, a zero quantity removes the level
+func (l *levels) set(level common.PriceLevel) error {
+	price, quantity, err := level.Parse()
+	if err != nil {
+		return err
+	}
+	i := l.search(price)
+	found := i < len(l.prices) && l.prices[i] == price
+	switch {
+	case quantity == 0 && found:
+		l.prices = append(l.prices[:i], l.prices[i+1:]...)
+		l.levels = append(l.levels[:i], l.levels[i+1:]...)
+	case quantity == 0:
+	case found:
+		l.levels[i] = level
+	default:
+		l.prices = append(l.prices, 0)
+		copy(l.prices[i+1:], l.prices[i:])
+		l.prices[i] = price
+		l.levels = append(l.levels, common.PriceLevel{})
+		copy(l.levels[i+1:], l.levels[i:])
+		l.levels[i] = level
+	}
+	return nil
+}
+
+// reset replaces every level
+func (l *levels) reset(levels []common.PriceLevel) error {
+	l.prices = l.prices[:0]
+	l.levels = l.levels[:0]
+	for _, level := range levels {
+		if err := l.set(level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// top returns a copy of the depth best levels, or of every level when depth
+// is not positive
+func (l *levels) top(depth int) []common.PriceLevel {
+	if depth <= 0 || depth > len(l.levels) {
+		depth = len(l.levels)
+	}
+	res := make([]common.PriceLevel, depth)
+	copy(res, l.levels)
+	return res
+}
+
+// best returns the best level
+func (l *levels) best() (common.PriceLevel, bool) {
+	if len(l.levels) == 0 {
+		return common.PriceLevel{}, false
+	}
+	return l.levels[0], true
+}
+
+// quantity returns the quantity of the level at price, "0" when there is none
+func (l *levels) quantity(price string) (string, error) {
+	p, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return "", err
+	}
+	i := l.search(p)
+	if i < len(l.prices) && l.prices[i] == p {
+		return l.levels[i].Quantity, nil
+	}
+	return "0", nil
+}
@@ -0,0 +1,161 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/pooyakn/go-binance/v2/common"
+)
+
+// ServeFunc starts the diff depth stream of a book with ctx, passing its
+// events to push, and returns a channel closed once the stream stopped
+type ServeFunc func(ctx context.Context, push func(d *Diff), errHandler func(err error)) (doneC chan struct{}, err error)
+
+// Managed is a book run along with its diff depth stream, as the OrderBook of
+// every product. Before Start, or after Start failed, the book is empty and
+// stopped: Done is closed, Synced is false and Close does nothing.
+type Managed struct {
+	mu     sync.Mutex
+	book   *Book
+	cancel context.CancelFunc
+}
+
+// ErrBookStarted is returned by Start while the book runs
+var ErrBookStarted = errors.New("order book already started")
+
+// stoppedC is the channel returned by Done while no book is started
+var stoppedC = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
+// Start runs a book with cfg, synchronised with the stream started by serve,
+// until Close is called, ctx is done or the stream stopped. It returns
+// ErrBookStarted until Done of the previous book is closed.
+func (m *Managed) Start(ctx context.Context, cfg Config, serve ServeFunc) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.book != nil {
+		select {
+		case <-m.book.Done():
+		default:
+			return ErrBookStarted
+		}
+	}
+	if cfg.ErrHandler == nil {
+		cfg.ErrHandler = func(err error) {}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	book := New(cfg)
+	go book.Run(ctx)
+	doneC, err := serve(ctx, book.Push, cfg.ErrHandler)
+	if err != nil {
+		cancel()
+		return err
+	}
+	m.book, m.cancel = book, cancel
+	go func() {
+		<-doneC
+		cancel()
+	}()
+	return nil
+}
+
+// started returns the started book, nil if none
+func (m *Managed) started() *Book {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.book
+}
+
+// Close stops the stream of the book
+func (m *Managed) Close() {
+	m.mu.Lock()
+	cancel := m.cancel
+	m.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// Done returns a channel closed once the book stopped
+func (m *Managed) Done() <-chan struct{} {
+	if b := m.started(); b != nil {
+		return b.Done()
+	}
+	return stoppedC
+}
+
+// Synced reports whether the book is synchronised with the stream
+func (m *Managed) Synced() bool {
+	if b := m.started(); b != nil {
+		return b.Synced()
+	}
+	return false
+}
+
+// WaitSynced waits until the book is synchronised
+func (m *Managed) WaitSynced(ctx context.Context) error {
+	if b := m.started(); b != nil {
+		return b.WaitSynced(ctx)
+	}
+	return ErrBookStopped
+}
+
+// LastUpdateID returns the id of the last update applied to the book
+func (m *Managed) LastUpdateID() int64 {
+	if b := m.started(); b != nil {
+		return b.LastUpdateID()
+	}
+	return 0
+}
+
+// BestBid returns the highest bid, false when there is none
+func (m *Managed) BestBid() (common.PriceLevel, bool) {
+	if b := m.started(); b != nil {
+		return b.BestBid()
+	}
+	return common.PriceLevel{}, false
+}
+
+// BestAsk returns the lowest ask, false when there is none
+func (m *Managed) BestAsk() (common.PriceLevel, bool) {
+	if b := m.started(); b != nil {
+		return b.BestAsk()
+	}
+	return common.PriceLevel{}, false
+}
+
+// Bids returns the depth highest bids, or every bid when depth is not positive
+func (m *Managed) Bids(depth int) []common.PriceLevel {
+	if b := m.started(); b != nil {
+		return b.Bids(depth)
+	}
+	return nil
+}
+
+// Asks returns the depth lowest asks, or every ask when depth is not positive
+func (m *Managed) Asks(depth int) []common.PriceLevel {
+	if b := m.started(); b != nil {
+		return b.Asks(depth)
+	}
+	return nil
+}
+
+// BidQuantity returns the quantity bid at price, "0" when there is none
+func (m *Managed) BidQuantity(price string) (string, error) {
+	if b := m.started(); b != nil {
+		return b.BidQuantity(price)
+	}
+	return "0", nil
+}
+
+// AskQuantity returns the quantity asked at price, "0" when there is none
+func (m *Managed) AskQuantity(price string) (string, error) {
+	if b := m.started(); b != nil {
+		return b.AskQuantity(price)
+	}
+	return "0", nil
+}
@@ -0,0 +1,375 @@
+// Package orderbook maintains local order books from a depth snapshot and the
+// diffs of a depth stream, with the synchronisation rules of every product.
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/common"
+)
+
+// Diff is an event of a diff depth stream
+type Diff struct {
+	// FirstUpdateID and LastUpdateID are the U and u fields of the event
+	FirstUpdateID int64
+	LastUpdateID  int64
+	// PrevLastUpdateID is the pu field of the futures events
+	PrevLastUpdateID int64
+	Bids             []common.PriceLevel
+	Asks             []common.PriceLevel
+}
+
+// Snapshot is a depth snapshot of the REST API
+type Snapshot struct {
+	LastUpdateID int64
+	Bids         []common.PriceLevel
+	Asks         []common.PriceLevel
+}
+
+// Config define the settings of a book
+type Config struct {
+	Symbol string
+	// Snapshot fetches a depth snapshot of the symbol
+	Snapshot func(ctx context.Context) (*Snapshot, error)
+	// PrevUpdateID checks the continuity of the diffs with their pu field, as
+	// documented for the futures streams, instead of their U field
+	PrevUpdateID bool
+	// RetryDelay is the delay before fetching a snapshot again, once failed
+	// or older than the buffered diffs
+	RetryDelay time.Duration
+	// MaxBuffered is the maximum number of diffs buffered while fetching a
+	// snapshot, defaultMaxBuffered when not positive
+	MaxBuffered int
+	// OnUpdate is called with every change of the book once synchronised
+	OnUpdate   func(update *common.OrderBookUpdate)
+	ErrHandler func(err error)
+	Logger     common.Logger
+}
+
+// diffBufferSize is the number of diffs queued between the stream and the book
+const diffBufferSize = 1024
+
+// defaultMaxBuffered is the default maximum number of diffs buffered while
+// fetching a snapshot
+const defaultMaxBuffered = 10000
+
+// Book is a local order book, synchronised by the goroutine of Run
+type Book struct {
+	cfg   Config
+	diffC chan *Diff
+	snapC chan *Snapshot
+	doneC chan struct{}
+
+	mu           sync.RWMutex
+	bids         levels
+	asks         levels
+	lastUpdateID int64
+	synced       bool
+	syncedC      chan struct{}
+
+	// owned by the goroutine of Run
+	buffer   []*Diff
+	fetching bool
+	// first is true until the first diff following the snapshot is applied
+	first bool
+}
+
+// New init a book with cfg
+func New(cfg Config) *Book {
+	if cfg.Logger == nil {
+		cfg.Logger = common.NewNopLogger()
+	}
+	if cfg.MaxBuffered <= 0 {
+		cfg.MaxBuffered = defaultMaxBuffered
+	}
+	return &Book{
+		cfg:     cfg,
+		diffC:   make(chan *Diff, diffBufferSize),
+		snapC:   make(chan *Snapshot),
+		doneC:   make(chan struct{}),
+		bids:    levels{desc: true},
+		syncedC: make(chan struct{}),
+	}
+}
+
+// Push queues a diff of the stream, it blocks while the queue is full
+func (b *Book) Push(d *Diff) {
+	select {
+	case b.diffC <- d:
+	case <-b.doneC:
+	}
+}
+
+// Run synchronises the book with the pushed diffs until ctx is done. The
+// first snapshot is fetched once the first diff is received.
+func (b *Book) Run(ctx context.Context) {
+	defer func() {
+		b.setSynced(false)
+		close(b.doneC)
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-b.diffC:
+			b.handleDiff(ctx, d)
+		case s := <-b.snapC:
+			b.handleSnapshot(ctx, s)
+		}
+	}
+}
+
+// Done returns a channel closed once Run returned
+func (b *Book) Done() <-chan struct{} {
+	return b.doneC
+}
+
+func (b *Book) handleDiff(ctx context.Context, d *Diff) {
+	if !b.Synced() {
+		b.buffer = append(b.buffer, d)
+		if dropped := len(b.buffer) - b.cfg.MaxBuffered; dropped > 0 {
+			// the oldest diffs are dropped, the snapshot being fetched is
+			// then older than the buffered diffs and fetched again
+			b.cfg.Logger.Warn("order book buffer full", "symbol", b.cfg.Symbol, "dropped", dropped)
+			n := copy(b.buffer, b.buffer[dropped:])
+			b.buffer = b.buffer[:n]
+		}
+		if !b.fetching {
+			b.resync(ctx, 0)
+		}
+		return
+	}
+	applied, ok := b.apply(d)
+	if !ok {
+		b.cfg.Logger.Warn("order book out of sync", "symbol", b.cfg.Symbol,
+			"lastUpdateId", b.LastUpdateID(), "firstUpdateId", d.FirstUpdateID, "prevLastUpdateId", d.PrevLastUpdateID)
+		b.setSynced(false)
+		b.buffer = []*Diff{d}
+		b.resync(ctx, 0)
+		return
+	}
+	if applied && b.cfg.OnUpdate != nil {
+		b.cfg.OnUpdate(&common.OrderBookUpdate{
+			Symbol:       b.cfg.Symbol,
+			LastUpdateID: d.LastUpdateID,
+			Bids:         d.Bids,
+			Asks:         d.Asks,
+		})
+	}
+}
+
+func (b *Book) handleSnapshot(ctx context.Context, s *Snapshot) {
+	b.fetching = false
+	b.mu.Lock()
+	err := b.bids.reset(s.Bids)
+	if err == nil {
+		err = b.asks.reset(s.Asks)
+	}
+	b.lastUpdateID = s.LastUpdateID
+	b.mu.Unlock()
+	if err != nil {
+		b.cfg.ErrHandler(err)
+		b.resync(ctx, b.cfg.RetryDelay)
+		return
+	}
+	b.first = true
+	buffer := b.buffer
+	b.buffer = nil
+	for i, d := range buffer {
+		if _, ok := b.apply(d); !ok {
+			// the snapshot is older than the buffered diffs
+			b.buffer = buffer[i:]
+			b.resync(ctx, b.cfg.RetryDelay)
+			return
+		}
+	}
+	b.setSynced(true)
+	if b.cfg.OnUpdate != nil {
+		b.mu.RLock()
+		update := &common.OrderBookUpdate{
+			Symbol:       b.cfg.Symbol,
+			LastUpdateID: b.lastUpdateID,
+			Snapshot:     true,
+			Bids:         b.bids.top(0),
+			Asks:         b.asks.top(0),
+		}
+		b.mu.RUnlock()
+		b.cfg.OnUpdate(update)
+	}
+}
+
+// apply applies d when it follows the last update, it returns false when
+// updates were missed, and whether d was applied rather than outdated
+func (b *Book) apply(d *Diff) (applied, ok bool) {
+	b.mu.Lock()
+	if !b.follows(d) {
+		outdated := b.outdated(d)
+		b.mu.Unlock()
+		return false, outdated
+	}
+	var err error
+	for _, level := range d.Bids {
+		if e := b.bids.set(level); e != nil {
+			err = e
+		}
+	}
+	for _, level := range d.Asks {
+		if e := b.asks.set(level); e != nil {
+			err = e
+		}
+	}
+	b.lastUpdateID = d.LastUpdateID
+	b.first = false
+	b.mu.Unlock()
+	if err != nil {
+		b.cfg.ErrHandler(err)
+	}
+	return true, true
+}
+
+// outdated reports whether d only holds updates already applied
+func (b *Book) outdated(d *Diff) bool {
+	if b.cfg.PrevUpdateID {
+		return b.first && d.LastUpdateID < b.lastUpdateID
+	}
+	return d.LastUpdateID <= b.lastUpdateID
+}
+
+// follows reports whether d is the next diff to apply
+func (b *Book) follows(d *Diff) bool {
+	switch {
+	case b.outdated(d):
+		return false
+	case b.cfg.PrevUpdateID && b.first:
+		return d.FirstUpdateID <= b.lastUpdateID
+	case b.cfg.PrevUpdateID:
+		return d.PrevLastUpdateID == b.lastUpdateID
+	default:
+		return d.FirstUpdateID <= b.lastUpdateID+1
+	}
+}
+
+// resync fetches a snapshot after delay
+func (b *Book) resync(ctx context.Context, delay time.Duration) {
+	b.fetching = true
+	go b.fetch(ctx, delay)
+}
+
+func (b *Book) fetch(ctx context.Context, delay time.Duration) {
+	for {
+		if delay > 0 {
+			t := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return
+			case <-t.C:
+			}
+		}
+		s, err := b.cfg.Snapshot(ctx)
+		if err == nil {
+			select {
+			case b.snapC <- s:
+			case <-ctx.Done():
+			}
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		b.cfg.ErrHandler(err)
+		delay = b.cfg.RetryDelay
+	}
+}
+
+func (b *Book) setSynced(synced bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if synced == b.synced {
+		return
+	}
+	b.synced = synced
+	if synced {
+		close(b.syncedC)
+	} else {
+		b.syncedC = make(chan struct{})
+	}
+}
+
+// Synced reports whether the book is synchronised with the stream, it is not
+// once stopped
+func (b *Book) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// ErrBookStopped is returned by WaitSynced once the book stopped
+var ErrBookStopped = errors.New("order book stopped")
+
+// WaitSynced waits until the book is synchronised, ctx is done or the book stopped
+func (b *Book) WaitSynced(ctx context.Context) error {
+	b.mu.RLock()
+	syncedC := b.syncedC
+	b.mu.RUnlock()
+	select {
+	case <-syncedC:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.doneC:
+		return ErrBookStopped
+	}
+}
+
+// LastUpdateID returns the id of the last update applied to the book
+func (b *Book) LastUpdateID() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.lastUpdateID
+}
+
+// Bids returns the depth best bids, or every bid when depth is not positive
+func (b *Book) Bids(depth int) []common.PriceLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.bids.top(depth)
+}
+
+// Asks returns the depth best asks, or every ask when depth is not positive
+func (b *Book) Asks(depth int) []common.PriceLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.asks.top(depth)
+}
+
+// BestBid returns the highest bid
+func (b *Book) BestBid() (common.PriceLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.bids.best()
+}
+
+// BestAsk returns the lowest ask
+func (b *Book) BestAsk() (common.PriceLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.asks.best()
+}
+
+// BidQuantity returns the quantity bid at price
+func (b *Book) BidQuantity(price string) (string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.bids.quantity(price)
+}
+
+// AskQuantity returns the quantity asked at price
+func (b *Book) AskQuantity(price string) (string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.asks.quantity(price)
+}
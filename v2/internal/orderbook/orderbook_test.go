@@ -0,0 +1,286 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type orderBookTestSuite struct {
+	suite.Suite
+	*recorder
+	cancel      context.CancelFunc
+	maxBuffered int
+}
+
+// recorder records the calls of a book, a new one is used by every test as
+// the goroutines of the book may outlive the test
+type recorder struct {
+	snapshots chan *Snapshot
+
+	mu      sync.Mutex
+	fetches int
+	updates []*common.OrderBookUpdate
+}
+
+func TestOrderBook(t *testing.T) {
+	suite.Run(t, new(orderBookTestSuite))
+}
+
+func (s *orderBookTestSuite) SetupTest() {
+	s.recorder = &recorder{snapshots: make(chan *Snapshot, 10)}
+	s.maxBuffered = 0
+}
+
+func (s *orderBookTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func (s *orderBookTestSuite) start(prevUpdateID bool) *Book {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	r := s.recorder
+	b := New(Config{
+		Symbol: "BTCUSDT",
+		Snapshot: func(ctx context.Context) (*Snapshot, error) {
+			r.mu.Lock()
+			r.fetches++
+			r.mu.Unlock()
+			select {
+			case snap := <-r.snapshots:
+				return snap, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		},
+		PrevUpdateID: prevUpdateID,
+		RetryDelay:   time.Millisecond,
+		MaxBuffered:  s.maxBuffered,
+		OnUpdate: func(update *common.OrderBookUpdate) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.updates = append(r.updates, update)
+		},
+		ErrHandler: func(err error) {
+			s.Fail(err.Error())
+		},
+		Logger: common.NewNopLogger(),
+	})
+	go b.Run(ctx)
+	return b
+}
+
+func (s *orderBookTestSuite) waitSynced(b *Book) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	s.Require().NoError(b.WaitSynced(ctx))
+}
+
+func (s *orderBookTestSuite) waitUpdates(n int) {
+	s.Require().Eventually(func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.updates) == n
+	}, time.Second, time.Millisecond)
+}
+
+func level(price, quantity string) common.PriceLevel {
+	return common.PriceLevel{Price: price, Quantity: quantity}
+}
+
+func (s *orderBookTestSuite) TestSpot() {
+	b := s.start(false)
+	b.Push(&Diff{FirstUpdateID: 9, LastUpdateID: 10, Bids: []common.PriceLevel{level("99", "5")}})
+	b.Push(&Diff{FirstUpdateID: 11, LastUpdateID: 12, Asks: []common.PriceLevel{level("101", "0"), level("102", "3")}})
+	s.False(b.Synced())
+
+	// the first diff is outdated, the second one follows the snapshot
+	s.snapshots <- &Snapshot{
+		LastUpdateID: 10,
+		Bids:         []common.PriceLevel{level("100", "1"), level("98", "2")},
+		Asks:         []common.PriceLevel{level("101", "1"), level("103", "4")},
+	}
+	s.waitSynced(b)
+	s.waitUpdates(1)
+	s.True(s.updates[0].Snapshot)
+	s.Equal(int64(12), b.LastUpdateID())
+	s.Equal([]common.PriceLevel{level("100", "1"), level("98", "2")}, b.Bids(0))
+	s.Equal([]common.PriceLevel{level("102", "3")}, b.Asks(1))
+	ask, ok := b.BestAsk()
+	s.True(ok)
+	s.Equal(level("102", "3"), ask)
+	quantity, err := b.AskQuantity("103.000")
+	s.Require().NoError(err)
+	s.Equal("4", quantity)
+	quantity, err = b.BidQuantity("99")
+	s.Require().NoError(err)
+	s.Equal("0", quantity)
+
+	b.Push(&Diff{FirstUpdateID: 13, LastUpdateID: 13, Bids: []common.PriceLevel{level("100", "0")}})
+	s.waitUpdates(2)
+	s.False(s.updates[1].Snapshot)
+	bid, _ := b.BestBid()
+	s.Equal(level("98", "2"), bid)
+}
+
+func (s *orderBookTestSuite) TestSpotGap() {
+	b := s.start(false)
+	b.Push(&Diff{FirstUpdateID: 11, LastUpdateID: 11})
+	s.snapshots <- &Snapshot{LastUpdateID: 10}
+	s.waitSynced(b)
+
+	b.Push(&Diff{FirstUpdateID: 13, LastUpdateID: 14, Bids: []common.PriceLevel{level("100", "1")}})
+	s.Require().Eventually(func() bool {
+		return !b.Synced()
+	}, time.Second, time.Millisecond)
+
+	// the snapshot is older than the buffered diff, and fetched again
+	s.snapshots <- &Snapshot{LastUpdateID: 11}
+	s.snapshots <- &Snapshot{LastUpdateID: 13, Bids: []common.PriceLevel{level("100", "2")}}
+	s.waitSynced(b)
+	s.Equal(int64(14), b.LastUpdateID())
+	s.Equal([]common.PriceLevel{level("100", "1")}, b.Bids(0))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Equal(3, s.fetches)
+}
+
+func (s *orderBookTestSuite) TestBufferFull() {
+	s.maxBuffered = 2
+	b := s.start(false)
+	for id := int64(11); id <= 14; id++ {
+		b.Push(&Diff{FirstUpdateID: id, LastUpdateID: id, Bids: []common.PriceLevel{level("100", strconv.FormatInt(id, 10))}})
+	}
+	s.Require().Eventually(func() bool {
+		return len(b.diffC) == 0
+	}, time.Second, time.Millisecond)
+
+	// only the last diffs are buffered, the snapshot is older and fetched again
+	s.snapshots <- &Snapshot{LastUpdateID: 10}
+	s.snapshots <- &Snapshot{LastUpdateID: 12}
+	s.waitSynced(b)
+	s.Equal(int64(14), b.LastUpdateID())
+	s.Equal([]common.PriceLevel{level("100", "14")}, b.Bids(0))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Equal(2, s.fetches)
+}
+
+func (s *orderBookTestSuite) TestFutures() {
+	b := s.start(true)
+	b.Push(&Diff{FirstUpdateID: 5, LastUpdateID: 8, PrevLastUpdateID: 4})
+	b.Push(&Diff{FirstUpdateID: 9, LastUpdateID: 12, PrevLastUpdateID: 8, Bids: []common.PriceLevel{level("100", "1")}})
+	s.snapshots <- &Snapshot{LastUpdateID: 10}
+	s.waitSynced(b)
+	s.Equal(int64(12), b.LastUpdateID())
+
+	b.Push(&Diff{FirstUpdateID: 13, LastUpdateID: 15, PrevLastUpdateID: 12, Bids: []common.PriceLevel{level("99", "1")}})
+	s.waitUpdates(2)
+	s.Len(b.Bids(0), 2)
+
+	// pu does not match the last update
+	b.Push(&Diff{FirstUpdateID: 17, LastUpdateID: 18, PrevLastUpdateID: 16})
+	s.Require().Eventually(func() bool {
+		return !b.Synced()
+	}, time.Second, time.Millisecond)
+	s.snapshots <- &Snapshot{LastUpdateID: 17}
+	s.waitSynced(b)
+	s.Equal(int64(18), b.LastUpdateID())
+	s.Empty(b.Bids(0))
+}
+
+func (s *orderBookTestSuite) TestStop() {
+	b := s.start(false)
+	s.cancel()
+	select {
+	case <-b.Done():
+	case <-time.After(time.Second):
+		s.FailNow("book not stopped")
+	}
+	s.Equal(ErrBookStopped, b.WaitSynced(context.Background()))
+	b.Push(&Diff{FirstUpdateID: 1, LastUpdateID: 1})
+}
+
+func (s *orderBookTestSuite) TestManagedNotStarted() {
+	var m Managed
+	m.Close()
+	select {
+	case <-m.Done():
+	default:
+		s.Fail("not started book not stopped")
+	}
+	s.False(m.Synced())
+	s.Equal(ErrBookStopped, m.WaitSynced(context.Background()))
+	s.Zero(m.LastUpdateID())
+	_, ok := m.BestBid()
+	s.False(ok)
+	_, ok = m.BestAsk()
+	s.False(ok)
+	s.Empty(m.Bids(0))
+	s.Empty(m.Asks(0))
+	quantity, err := m.BidQuantity("100")
+	s.NoError(err)
+	s.Equal("0", quantity)
+
+	err = m.Start(context.Background(), Config{Symbol: "BTCUSDT"}, func(ctx context.Context, push func(d *Diff), errHandler func(err error)) (chan struct{}, error) {
+		return nil, errors.New("dial failed")
+	})
+	s.Error(err)
+	m.Close()
+	s.False(m.Synced())
+	<-m.Done()
+}
+
+func (s *orderBookTestSuite) TestManaged() {
+	var m Managed
+	diffC := make(chan *Diff)
+	serve := func(ctx context.Context, push func(d *Diff), errHandler func(err error)) (chan struct{}, error) {
+		doneC := make(chan struct{})
+		go func() {
+			defer close(doneC)
+			for {
+				select {
+				case d := <-diffC:
+					push(d)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return doneC, nil
+	}
+	err := m.Start(context.Background(), Config{
+		Symbol: "BTCUSDT",
+		Snapshot: func(ctx context.Context) (*Snapshot, error) {
+			return &Snapshot{LastUpdateID: 1, Bids: []common.PriceLevel{level("100", "1")}}, nil
+		},
+		RetryDelay: time.Millisecond,
+	}, serve)
+	s.Require().NoError(err)
+	diffC <- &Diff{FirstUpdateID: 2, LastUpdateID: 2}
+	s.Require().NoError(m.WaitSynced(context.Background()))
+	s.Equal(int64(2), m.LastUpdateID())
+	bid, ok := m.BestBid()
+	s.True(ok)
+	s.Equal(level("100", "1"), bid)
+
+	s.Equal(ErrBookStarted, m.Start(context.Background(), Config{}, serve))
+
+	m.Close()
+	select {
+	case <-m.Done():
+	case <-time.After(time.Second):
+		s.FailNow("book not stopped")
+	}
+	s.False(m.Synced())
+
+	// a stopped book can be started again
+	s.Require().NoError(m.Start(context.Background(), Config{}, serve))
+	m.Close()
+}
@@ -0,0 +1,318 @@
+// Package transport holds the REST plumbing shared by every product client:
+// request building, signing, sending and error decoding.
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pooyakn/go-binance/v2/common"
+)
+
+// Redefining the standard package
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// Query keys set by the transport
+const (
+	TimestampKey  = "timestamp"
+	SignatureKey  = "signature"
+	RecvWindowKey = "recvWindow"
+)
+
+// DoFunc sends an HTTP request and returns an HTTP response
+type DoFunc func(req *http.Request) (*http.Response, error)
+
+// Client define the settings used to build and send a request.
+// Product clients hand their own settings over on every call, so that changes
+// made to their exported fields are always taken into account.
+type Client struct {
+	APIKey     string
+	SecretKey  string
+	BaseURL    string
+	UserAgent  string
+	HTTPClient *http.Client
+	Debug      bool
+	Logger     common.Logger
+	// StdLogger is used when Logger is nil
+	StdLogger   *log.Logger
+	TimeOffset  int64
+	Signer      common.Signer
+	RateLimiter *common.RateLimiter
+	RetryPolicy *common.RetryPolicy
+	TimeSync    *common.TimeSync
+	Middlewares []common.Middleware
+	Do          DoFunc
+}
+
+// CurrentTimestamp returns the current Unix timestamp in milliseconds
+func CurrentTimestamp() int64 {
+	return FormatTimestamp(time.Now())
+}
+
+// FormatTimestamp formats a time into Unix timestamp in milliseconds, as requested by Binance.
+func FormatTimestamp(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// Sign signs payload with the configured Signer, falling back to HMAC-SHA256
+// with the secret key when no Signer is set
+func (c *Client) Sign(payload []byte) (string, error) {
+	if c.Signer != nil {
+		return c.Signer.Sign(payload)
+	}
+	return common.NewHMACSigner(c.SecretKey).Sign(payload)
+}
+
+// timeOffset returns the offset of the local clock from the server clock in
+// milliseconds, measured by the TimeSync if any
+func (c *Client) timeOffset() int64 {
+	if c.TimeSync != nil {
+		return c.TimeSync.Offset()
+	}
+	return c.TimeOffset
+}
+
+// debug logs at debug level when the Debug mode is on, secrets must be redacted by the caller
+func (c *Client) debug(msg string, args ...interface{}) {
+	if !c.Debug {
+		return
+	}
+	switch {
+	case c.Logger != nil:
+		c.Logger.Debug(msg, args...)
+	case c.StdLogger != nil:
+		common.NewStdLogger(c.StdLogger).Debug(msg, args...)
+	}
+}
+
+// ParseRequest applies the options to r, signs it if required and fills its
+// full URL, header and body
+func (c *Client) ParseRequest(r *Request, opts ...RequestOption) (err error) {
+	// set request options from user
+	for _, opt := range opts {
+		opt(r)
+	}
+	err = r.Validate()
+	if err != nil {
+		return err
+	}
+
+	fullURL := fmt.Sprintf("%s%s", c.BaseURL, r.Endpoint)
+	if r.RecvWindow > 0 {
+		r.SetParam(RecvWindowKey, r.RecvWindow)
+	}
+	if r.SecType == SecTypeSigned {
+		r.SetParam(TimestampKey, CurrentTimestamp()-c.timeOffset())
+	}
+	queryString := r.Query.Encode()
+	body := &bytes.Buffer{}
+	bodyString := r.Form.Encode()
+	header := http.Header{}
+	if r.Header != nil {
+		header = r.Header.Clone()
+	}
+	if bodyString != "" {
+		header.Set("Content-Type", "application/x-www-form-urlencoded")
+		body = bytes.NewBufferString(bodyString)
+	}
+	if r.SecType == SecTypeAPIKey || r.SecType == SecTypeSigned {
+		header.Set("X-MBX-APIKEY", c.APIKey)
+	}
+
+	if r.SecType == SecTypeSigned {
+		raw := fmt.Sprintf("%s%s", queryString, bodyString)
+		signature, err := c.Sign([]byte(raw))
+		if err != nil {
+			return err
+		}
+		v := url.Values{}
+		v.Set(SignatureKey, signature)
+		if queryString == "" {
+			queryString = v.Encode()
+		} else {
+			queryString = fmt.Sprintf("%s&%s", queryString, v.Encode())
+		}
+	}
+	if queryString != "" {
+		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
+	}
+	c.debug("request parsed", "url", common.RedactURL(fullURL), "body", common.RedactBody(bodyString))
+
+	r.FullURL = fullURL
+	r.Header = header
+	r.Body = body
+	return nil
+}
+
+// CallAPI sends r and returns the response body and header, retrying it as
+// allowed by the RetryPolicy.
+// A response with a status code of 400 or above is decoded into a *common.APIError.
+func (c *Client) CallAPI(ctx context.Context, r *Request, opts ...RequestOption) (data []byte, header *http.Header, err error) {
+	// set request options from user once, the request is parsed again on every attempt
+	for _, opt := range opts {
+		opt(r)
+	}
+	return c.call(ctx, r, c.send)
+}
+
+// attemptFunc makes a single attempt of r, the status code is 0 when no response was received
+type attemptFunc func(ctx context.Context, r *Request, attempt int) (data []byte, header *http.Header, statusCode int, err error)
+
+// call makes the attempts of r with send, syncing the server time again after
+// an invalid timestamp error and retrying as allowed by the RetryPolicy
+func (c *Client) call(ctx context.Context, r *Request, send attemptFunc) (data []byte, header *http.Header, err error) {
+	resynced := false
+	for attempt := 1; ; attempt++ {
+		var statusCode int
+		data, header, statusCode, err = send(ctx, r, attempt)
+		if err != nil && !resynced && c.TimeSync != nil && r.SecType == SecTypeSigned && errors.Is(err, common.ErrInvalidTimestamp) {
+			// the clock drifted since the last sync, sync it now and try once more
+			resynced = true
+			if serr := c.TimeSync.Sync(ctx); serr == nil {
+				c.debug("server time resynced", "endpoint", r.Endpoint, "drift", c.TimeSync.Drift())
+				attempt--
+				continue
+			}
+		}
+		if err == nil || c.RetryPolicy == nil {
+			return data, header, err
+		}
+		delay, retry := c.RetryPolicy.Retry(&common.RetryAttempt{
+			Attempt:    attempt,
+			Method:     r.Method,
+			Order:      r.isOrder(),
+			StatusCode: statusCode,
+			Header:     *header,
+			Err:        err,
+		})
+		if !retry {
+			return data, header, err
+		}
+		c.debug("request failed, retrying", "endpoint", r.Endpoint, "attempt", attempt, "delay", delay, "error", err)
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return data, header, err
+		case <-t.C:
+		}
+	}
+}
+
+// send makes a single attempt of r, the status code is 0 when no response was received
+func (c *Client) send(ctx context.Context, r *Request, attempt int) (data []byte, header *http.Header, statusCode int, err error) {
+	weight := r.weight()
+	// wait before signing, so that the timestamp is not stale when sent
+	if c.RateLimiter != nil {
+		err = c.RateLimiter.Wait(ctx, weight, r.isOrder())
+		if err != nil {
+			return []byte{}, &http.Header{}, 0, err
+		}
+	}
+	err = c.ParseRequest(r)
+	if err != nil {
+		return []byte{}, &http.Header{}, 0, err
+	}
+	req, err := http.NewRequest(r.Method, r.FullURL, r.Body)
+	if err != nil {
+		return []byte{}, &http.Header{}, 0, err
+	}
+	req.Header = r.Header
+	info := &common.RequestInfo{
+		Method:   r.Method,
+		Endpoint: r.Endpoint,
+		Query:    r.Query,
+		Header:   req.Header,
+		Signed:   r.SecType == SecTypeSigned,
+		Weight:   weight,
+		Attempt:  attempt,
+	}
+	ctx, err = c.beforeSend(ctx, info)
+	if err != nil {
+		return []byte{}, &http.Header{}, 0, err
+	}
+	start := time.Now()
+	defer func() {
+		c.afterReceive(ctx, c.Middlewares, info, &common.ResponseInfo{
+			StatusCode: statusCode,
+			Header:     *header,
+			Latency:    time.Since(start),
+			UsedWeight: common.UsedWeight(*header),
+			Err:        err,
+		})
+	}()
+	req = req.WithContext(ctx)
+	c.debug("request", "method", req.Method, "url", common.RedactURL(r.FullURL), "header", common.RedactHeader(req.Header))
+	f := c.Do
+	if f == nil {
+		f = c.HTTPClient.Do
+	}
+	res, err := f(req)
+	if err != nil {
+		return []byte{}, &http.Header{}, 0, err
+	}
+	data, err = io.ReadAll(res.Body)
+	if err != nil {
+		return []byte{}, &http.Header{}, 0, err
+	}
+	defer func() {
+		cerr := res.Body.Close()
+		// Only overwrite the retured error if the original error was nil and an
+		// error occurred while closing the body.
+		if err == nil && cerr != nil {
+			err = cerr
+		}
+	}()
+	if c.RateLimiter != nil {
+		c.RateLimiter.Update(res.StatusCode, res.Header, data)
+	}
+	c.debug("response", "endpoint", r.Endpoint, "status", res.StatusCode, "header", res.Header, "body", common.RedactBody(string(data)))
+
+	if res.StatusCode >= http.StatusBadRequest {
+		apiErr := new(common.APIError)
+		e := json.Unmarshal(data, apiErr)
+		if e != nil {
+			c.debug("failed to unmarshal json", "error", e)
+		}
+		apiErr.StatusCode = res.StatusCode
+		apiErr.Method = r.Method
+		apiErr.Endpoint = r.Endpoint
+		apiErr.Header = res.Header
+		return nil, &res.Header, res.StatusCode, apiErr
+	}
+	return data, &res.Header, res.StatusCode, nil
+}
+
+// beforeSend calls the BeforeSend hooks of the middlewares in order, when one
+// fails the AfterReceive hooks of the previous ones are called with its error
+func (c *Client) beforeSend(ctx context.Context, req *common.RequestInfo) (context.Context, error) {
+	for i, m := range c.Middlewares {
+		if m.BeforeSend == nil {
+			continue
+		}
+		var err error
+		ctx, err = m.BeforeSend(ctx, req)
+		if err != nil {
+			c.afterReceive(ctx, c.Middlewares[:i], req, &common.ResponseInfo{Err: err})
+			return ctx, err
+		}
+	}
+	return ctx, nil
+}
+
+// afterReceive calls the AfterReceive hooks of the middlewares in reverse order
+func (c *Client) afterReceive(ctx context.Context, middlewares []common.Middleware, req *common.RequestInfo, res *common.ResponseInfo) {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i].AfterReceive != nil {
+			middlewares[i].AfterReceive(ctx, req, res)
+		}
+	}
+}
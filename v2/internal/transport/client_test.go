@@ -0,0 +1,454 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type clientTestSuite struct {
+	suite.Suite
+	client *Client
+	req    *http.Request
+	res    *http.Response
+}
+
+func TestClient(t *testing.T) {
+	suite.Run(t, new(clientTestSuite))
+}
+
+func (s *clientTestSuite) SetupTest() {
+	s.req = nil
+	s.res = &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString("{}"))}
+	s.client = &Client{
+		APIKey:    "dummyAPIKey",
+		SecretKey: "dummySecretKey",
+		BaseURL:   "https://api.binance.com",
+		Do: func(req *http.Request) (*http.Response, error) {
+			s.req = req
+			return s.res, nil
+		},
+	}
+}
+
+func (s *clientTestSuite) mockResponse(data string, statusCode int) {
+	s.res = &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"X-Mbx-Used-Weight-1m": []string{"10"}},
+		Body:       io.NopCloser(bytes.NewBufferString(data)),
+	}
+}
+
+func (s *clientTestSuite) TestParseRequestSigned() {
+	r := &Request{
+		Method:   http.MethodPost,
+		Endpoint: "/api/v3/order",
+		SecType:  SecTypeSigned,
+	}
+	r.SetParam("symbol", "BTCUSDT")
+	r.SetFormParam("side", "BUY")
+	err := s.client.ParseRequest(r, WithRecvWindow(5000))
+	s.Require().NoError(err)
+
+	u, err := url.Parse(r.FullURL)
+	s.Require().NoError(err)
+	q := u.Query()
+	s.Equal("/api/v3/order", u.Path)
+	s.Equal("BTCUSDT", q.Get("symbol"))
+	s.Equal("5000", q.Get(RecvWindowKey))
+	s.NotEmpty(q.Get(TimestampKey))
+	s.Len(q.Get(SignatureKey), 64)
+	s.Equal("dummyAPIKey", r.Header.Get("X-MBX-APIKEY"))
+	s.Equal("application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+}
+
+func (s *clientTestSuite) TestParseRequestNone() {
+	r := &Request{
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/ping",
+	}
+	err := s.client.ParseRequest(r)
+	s.Require().NoError(err)
+	s.Equal("https://api.binance.com/api/v3/ping", r.FullURL)
+	s.Empty(r.Header.Get("X-MBX-APIKEY"))
+}
+
+func (s *clientTestSuite) TestSetParamSlice() {
+	r := &Request{}
+	r.SetParam("symbols", []string{"BTCUSDT", "ETHUSDT"})
+	s.Equal(`["BTCUSDT","ETHUSDT"]`, r.Query.Get("symbols"))
+}
+
+func (s *clientTestSuite) TestCallAPI() {
+	s.mockResponse(`{"serverTime":1499827319559}`, http.StatusOK)
+	r := &Request{
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/time",
+	}
+	data, header, err := s.client.CallAPI(context.Background(), r, WithHeader("X-Test", "1", true))
+	s.Require().NoError(err)
+	s.Equal(`{"serverTime":1499827319559}`, string(data))
+	s.Equal("10", header.Get("X-MBX-USED-WEIGHT-1M"))
+	s.Equal("1", s.req.Header.Get("X-Test"))
+}
+
+func (s *clientTestSuite) TestCallAPIError() {
+	s.mockResponse(`{"code":-1121,"msg":"Invalid symbol."}`, http.StatusBadRequest)
+	r := &Request{
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/depth",
+	}
+	_, _, err := s.client.CallAPI(context.Background(), r)
+	s.Require().Error(err)
+	s.True(common.IsAPIError(err))
+	apiErr := err.(*common.APIError)
+	s.Equal(int64(-1121), apiErr.Code)
+	s.Equal("Invalid symbol.", apiErr.Message)
+	s.Equal(http.StatusBadRequest, apiErr.StatusCode)
+	s.Equal("/api/v3/depth", apiErr.Endpoint)
+	s.Equal("10", apiErr.UsedWeight())
+	s.True(errors.Is(err, common.ErrInvalidSymbol))
+}
+
+func (s *clientTestSuite) TestParseRequestCustomSigner() {
+	s.client.SecretKey = ""
+	s.client.Signer = common.SignerFunc(func(payload []byte) (string, error) {
+		return "sig+" + string(payload), nil
+	})
+	r := &Request{
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/account",
+		SecType:  SecTypeSigned,
+	}
+	err := s.client.ParseRequest(r)
+	s.Require().NoError(err)
+
+	u, err := url.Parse(r.FullURL)
+	s.Require().NoError(err)
+	q := u.Query()
+	s.Equal("sig+timestamp="+q.Get(TimestampKey), q.Get(SignatureKey))
+}
+
+func (s *clientTestSuite) TestCallAPIRateLimiter() {
+	s.client.RateLimiter = common.NewRateLimiter()
+	s.mockResponse(`{}`, http.StatusOK)
+	r := &Request{
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/depth",
+	}
+	_, _, err := s.client.CallAPI(context.Background(), r)
+	s.Require().NoError(err)
+	s.Equal(int64(10), s.client.RateLimiter.Used(common.RateLimitTypeRequestWeight, time.Minute))
+
+	s.mockResponse(`{"code":-1003,"msg":"Way too many requests; IP banned until 4102444800000."}`, http.StatusTeapot)
+	_, _, err = s.client.CallAPI(context.Background(), &Request{Method: http.MethodGet, Endpoint: "/api/v3/depth"})
+	s.Require().Error(err)
+	s.True(common.IsAPIError(err))
+
+	s.req = nil
+	_, _, err = s.client.CallAPI(context.Background(), &Request{Method: http.MethodGet, Endpoint: "/api/v3/depth"})
+	s.Require().Error(err)
+	s.IsType(&common.RateLimitError{}, err)
+	s.Nil(s.req)
+}
+
+func (s *clientTestSuite) TestCallAPIRateLimiterWeight() {
+	s.client.RateLimiter = common.NewRateLimiter(common.RateLimit{
+		RateLimitType: common.RateLimitTypeRequestWeight,
+		Interval:      common.RateLimitIntervalMinute,
+		IntervalNum:   1,
+		Limit:         6000,
+	})
+	_, _, err := s.client.CallAPI(context.Background(), &Request{Method: http.MethodGet, Endpoint: "/api/v3/depth", Weight: 50})
+	s.Require().NoError(err)
+	s.Equal(int64(50), s.client.RateLimiter.Used(common.RateLimitTypeRequestWeight, time.Minute))
+
+	_, _, err = s.client.CallAPI(context.Background(), &Request{Method: http.MethodGet, Endpoint: "/api/v3/time"})
+	s.Require().NoError(err)
+	s.Equal(int64(51), s.client.RateLimiter.Used(common.RateLimitTypeRequestWeight, time.Minute))
+
+	_, _, err = s.client.CallAPI(context.Background(), &Request{Method: http.MethodGet, Endpoint: "/api/v3/depth"}, WithWeight(250))
+	s.Require().NoError(err)
+	s.Equal(int64(301), s.client.RateLimiter.Used(common.RateLimitTypeRequestWeight, time.Minute))
+}
+
+func (s *clientTestSuite) TestCallAPIEndpointWeight() {
+	s.client.RateLimiter = common.NewRateLimiter(common.RateLimit{
+		RateLimitType: common.RateLimitTypeRequestWeight,
+		Interval:      common.RateLimitIntervalMinute,
+		IntervalNum:   1,
+		Limit:         6000,
+	})
+	used := func() int64 {
+		return s.client.RateLimiter.Used(common.RateLimitTypeRequestWeight, time.Minute)
+	}
+	_, _, err := s.client.CallAPI(context.Background(), &Request{Method: http.MethodGet, Endpoint: "/api/v3/ticker/24hr"})
+	s.Require().NoError(err)
+	s.Equal(int64(80), used())
+
+	r := &Request{Method: http.MethodGet, Endpoint: "/api/v3/ticker/24hr"}
+	r.SetParam("symbol", "BTCUSDT")
+	_, _, err = s.client.CallAPI(context.Background(), r)
+	s.Require().NoError(err)
+	s.Equal(int64(82), used())
+
+	_, _, err = s.client.CallAPI(context.Background(), &Request{Method: http.MethodGet, Endpoint: "/sapi/v1/accountSnapshot", SecType: SecTypeSigned})
+	s.Require().NoError(err)
+	s.Equal(int64(2482), used())
+}
+
+func TestRequestWeight(t *testing.T) {
+	klines := func(limit string) *Request {
+		r := &Request{Method: http.MethodGet, Endpoint: "/fapi/v1/klines"}
+		r.SetParam("symbol", "BTCUSDT")
+		if limit != "" {
+			r.SetParam("limit", limit)
+		}
+		return r
+	}
+	openOrders := &Request{Method: http.MethodGet, Endpoint: "/api/v3/openOrders", Form: url.Values{"symbol": {"BTCUSDT"}}}
+	for _, tc := range []struct {
+		name   string
+		r      *Request
+		weight int64
+	}{
+		{"unlisted", &Request{Method: http.MethodGet, Endpoint: "/api/v3/time"}, 1},
+		{"account", &Request{Method: http.MethodGet, Endpoint: "/api/v3/account"}, 20},
+		{"other method", &Request{Method: http.MethodPost, Endpoint: "/api/v3/account"}, 1},
+		{"set", &Request{Method: http.MethodGet, Endpoint: "/api/v3/account", Weight: 3}, 3},
+		{"form symbol", openOrders, 6},
+		{"no symbol", &Request{Method: http.MethodGet, Endpoint: "/fapi/v1/openOrders"}, 40},
+		{"default limit", klines(""), 5},
+		{"small limit", klines("50"), 1},
+		{"large limit", klines("1500"), 10},
+		{"trailing space", &Request{Method: http.MethodGet, Endpoint: "/api/v3/openOrderList "}, 6},
+	} {
+		if w := tc.r.weight(); w != tc.weight {
+			t.Errorf("%s: weight %d, want %d", tc.name, w, tc.weight)
+		}
+	}
+}
+
+func (s *clientTestSuite) TestCallAPISignsAfterRateLimiterWait() {
+	s.client.RateLimiter = common.NewRateLimiter(common.RateLimit{
+		RateLimitType: common.RateLimitTypeRequestWeight,
+		Interval:      common.RateLimitIntervalSecond,
+		IntervalNum:   1,
+		Limit:         1,
+	})
+	r := &Request{Method: http.MethodGet, Endpoint: "/api/v3/account", SecType: SecTypeSigned}
+	_, _, err := s.client.CallAPI(context.Background(), r)
+	s.Require().NoError(err)
+	// the second request waits for the next window of the limit
+	window := time.Now().Truncate(time.Second).Add(time.Second)
+
+	s.res = &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString("{}"))}
+	r = &Request{Method: http.MethodGet, Endpoint: "/api/v3/account", SecType: SecTypeSigned}
+	_, _, err = s.client.CallAPI(context.Background(), r)
+	s.Require().NoError(err)
+	timestamp, err := strconv.ParseInt(s.req.URL.Query().Get(TimestampKey), 10, 64)
+	s.Require().NoError(err)
+	s.GreaterOrEqual(timestamp, FormatTimestamp(window))
+}
+
+func (s *clientTestSuite) TestRequestIsOrder() {
+	s.True((&Request{Method: http.MethodPost, Endpoint: "/api/v3/order"}).isOrder())
+	s.True((&Request{Method: http.MethodPost, Endpoint: "/fapi/v1/batchOrders"}).isOrder())
+	s.False((&Request{Method: http.MethodGet, Endpoint: "/api/v3/order"}).isOrder())
+	s.False((&Request{Method: http.MethodPost, Endpoint: "/api/v3/userDataStream"}).isOrder())
+}
+
+func (s *clientTestSuite) TestCallAPIRetry() {
+	s.client.RetryPolicy = &common.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	var timestamps []string
+	responses := []*http.Response{
+		{StatusCode: http.StatusBadGateway, Body: io.NopCloser(bytes.NewBufferString(`{}`))},
+		{StatusCode: http.StatusBadRequest, Body: io.NopCloser(bytes.NewBufferString(`{"code":-1021,"msg":"Timestamp for this request is outside of the recvWindow."}`))},
+		{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{"balances":[]}`))},
+	}
+	s.client.Do = func(req *http.Request) (*http.Response, error) {
+		timestamps = append(timestamps, req.URL.Query().Get(TimestampKey))
+		s.Len(req.URL.Query()[SignatureKey], 1)
+		s.Equal([]string{"1"}, req.Header.Values("X-Test"))
+		res := responses[0]
+		responses = responses[1:]
+		return res, nil
+	}
+	r := &Request{
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/account",
+		SecType:  SecTypeSigned,
+	}
+	data, _, err := s.client.CallAPI(context.Background(), r, WithHeader("X-Test", "1", false))
+	s.Require().NoError(err)
+	s.Equal(`{"balances":[]}`, string(data))
+	s.Len(timestamps, 3)
+}
+
+func (s *clientTestSuite) TestCallAPINoRetryOrder() {
+	s.client.RetryPolicy = &common.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	calls := 0
+	s.client.Do = func(req *http.Request) (*http.Response, error) {
+		calls++
+		return nil, errors.New("read: connection reset by peer")
+	}
+	r := &Request{
+		Method:   http.MethodPost,
+		Endpoint: "/api/v3/order",
+		SecType:  SecTypeSigned,
+	}
+	_, _, err := s.client.CallAPI(context.Background(), r)
+	s.Error(err)
+	s.Equal(1, calls)
+}
+
+func (s *clientTestSuite) TestCallAPIResyncOnInvalidTimestamp() {
+	var serverAhead int64
+	s.client.TimeSync = common.NewTimeSync(func(ctx context.Context) (int64, error) {
+		return CurrentTimestamp() + serverAhead, nil
+	})
+	s.Require().NoError(s.client.TimeSync.Sync(context.Background()))
+
+	// the server clock moves 5s ahead after the sync
+	serverAhead = 5000
+	calls := 0
+	s.client.Do = func(req *http.Request) (*http.Response, error) {
+		calls++
+		timestamp, err := strconv.ParseInt(req.URL.Query().Get(TimestampKey), 10, 64)
+		s.Require().NoError(err)
+		if CurrentTimestamp()+serverAhead-timestamp > 1000 {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"code":-1021,"msg":"Timestamp for this request is outside of the recvWindow."}`)),
+			}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{}`))}, nil
+	}
+	r := &Request{
+		Method:   http.MethodPost,
+		Endpoint: "/api/v3/order",
+		SecType:  SecTypeSigned,
+	}
+	_, _, err := s.client.CallAPI(context.Background(), r)
+	s.Require().NoError(err)
+	s.Equal(2, calls)
+	s.InDelta(-5000, s.client.TimeSync.Offset(), 100)
+}
+
+type ctxKey string
+
+func (s *clientTestSuite) TestCallAPIMiddlewares() {
+	var calls []string
+	var responses []*common.ResponseInfo
+	s.client.Middlewares = []common.Middleware{
+		{
+			BeforeSend: func(ctx context.Context, req *common.RequestInfo) (context.Context, error) {
+				calls = append(calls, "before 1")
+				s.Equal(http.MethodGet, req.Method)
+				s.Equal("/api/v3/account", req.Endpoint)
+				s.True(req.Signed)
+				s.Equal(int64(20), req.Weight)
+				s.Equal(1, req.Attempt)
+				s.Empty(req.Query.Get(SignatureKey))
+				req.Header.Set("Traceparent", "00-trace")
+				return context.WithValue(ctx, ctxKey("span"), "span 1"), nil
+			},
+			AfterReceive: func(ctx context.Context, req *common.RequestInfo, res *common.ResponseInfo) {
+				calls = append(calls, "after 1")
+				s.Equal("span 1", ctx.Value(ctxKey("span")))
+				responses = append(responses, res)
+			},
+		},
+		{
+			AfterReceive: func(ctx context.Context, req *common.RequestInfo, res *common.ResponseInfo) {
+				calls = append(calls, "after 2")
+			},
+		},
+	}
+	s.mockResponse(`{"code":-2015,"msg":"Invalid API-key, IP, or permissions for action."}`, http.StatusUnauthorized)
+	r := &Request{
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/account",
+		SecType:  SecTypeSigned,
+	}
+	_, _, err := s.client.CallAPI(context.Background(), r)
+	s.Require().Error(err)
+	s.Equal([]string{"before 1", "after 2", "after 1"}, calls)
+	s.Equal("00-trace", s.req.Header.Get("Traceparent"))
+	s.Equal("span 1", s.req.Context().Value(ctxKey("span")))
+	s.Require().Len(responses, 1)
+	s.Equal(http.StatusUnauthorized, responses[0].StatusCode)
+	s.Equal(int64(10), responses[0].UsedWeight)
+	s.True(errors.Is(responses[0].Err, common.ErrInvalidAPIKey))
+}
+
+func (s *clientTestSuite) TestCallAPIMiddlewareAbort() {
+	abortErr := errors.New("blocked by policy")
+	var res *common.ResponseInfo
+	s.client.Middlewares = []common.Middleware{
+		{
+			AfterReceive: func(ctx context.Context, req *common.RequestInfo, r *common.ResponseInfo) {
+				res = r
+			},
+		},
+		{
+			BeforeSend: func(ctx context.Context, req *common.RequestInfo) (context.Context, error) {
+				return ctx, abortErr
+			},
+		},
+	}
+	_, _, err := s.client.CallAPI(context.Background(), &Request{Method: http.MethodPost, Endpoint: "/api/v3/order"})
+	s.Equal(abortErr, err)
+	s.Nil(s.req)
+	s.Require().NotNil(res)
+	s.Equal(abortErr, res.Err)
+	s.Equal(0, res.StatusCode)
+}
+
+func (s *clientTestSuite) TestCallAPIDebugRedacted() {
+	var buf bytes.Buffer
+	s.client.Debug = true
+	s.client.Logger = common.NewStdLogger(log.New(&buf, "", 0))
+	r := &Request{
+		Method:   http.MethodPost,
+		Endpoint: "/api/v3/order",
+		SecType:  SecTypeSigned,
+	}
+	r.SetFormParam("symbol", "BTCUSDT")
+	_, _, err := s.client.CallAPI(context.Background(), r)
+	s.Require().NoError(err)
+
+	s.NotEmpty(s.req.URL.Query().Get(SignatureKey))
+	s.Contains(buf.String(), "symbol=BTCUSDT")
+	s.Contains(buf.String(), "signature="+common.Redacted)
+	s.NotContains(buf.String(), s.req.URL.Query().Get(SignatureKey))
+	s.NotContains(buf.String(), "dummyAPIKey")
+}
+
+func (s *clientTestSuite) TestCallAPIDebugStdLogger() {
+	var buf bytes.Buffer
+	s.client.Debug = true
+	s.client.StdLogger = log.New(&buf, "", 0)
+	_, _, err := s.client.CallAPI(context.Background(), &Request{Method: http.MethodGet, Endpoint: "/api/v3/ping"})
+	s.Require().NoError(err)
+	s.Contains(buf.String(), "DEBUG request method=GET url=https://api.binance.com/api/v3/ping")
+
+	// the structured logger takes precedence
+	buf.Reset()
+	var structured bytes.Buffer
+	s.client.Logger = common.NewStdLogger(log.New(&structured, "", 0))
+	_, _, err = s.client.CallAPI(context.Background(), &Request{Method: http.MethodGet, Endpoint: "/api/v3/ping"})
+	s.Require().NoError(err)
+	s.Empty(buf.String())
+	s.NotEmpty(structured.String())
+}
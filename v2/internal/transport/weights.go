@@ -0,0 +1,156 @@
+package transport
+
+import (
+	"strconv"
+	"strings"
+)
+
+// endpointWeight define the request weight of an endpoint, as documented by
+// Binance. The IP weights are listed, the UID weights are counted by the
+// order rate limits.
+type endpointWeight struct {
+	// weight is the weight of the requests with a symbol
+	weight int64
+	// noSymbol is the weight of the requests without symbol, e.g. for every
+	// symbol or a list of symbols, weight when 0
+	noSymbol int64
+	// byLimit returns the weight for the limit parameter, when set
+	byLimit func(limit int64) int64
+}
+
+// klineWeight returns the weight of the futures and delivery klines for the limit
+func klineWeight(limit int64) int64 {
+	switch {
+	case limit < 100:
+		return 1
+	case limit < 500:
+		return 2
+	case limit <= 1000:
+		return 5
+	default:
+		return 10
+	}
+}
+
+// endpointWeights holds the request weights of the endpoints by method and
+// path, the endpoints not listed weigh 1
+var endpointWeights = map[string]endpointWeight{
+	// spot
+	"GET /api/v3/exchangeInfo":             {weight: 20},
+	"GET /api/v3/trades":                   {weight: 25},
+	"GET /api/v3/historicalTrades":         {weight: 25},
+	"GET /api/v3/aggTrades":                {weight: 2},
+	"GET /api/v3/klines":                   {weight: 2},
+	"GET /api/v3/uiKlines":                 {weight: 2},
+	"GET /api/v3/avgPrice":                 {weight: 2},
+	"GET /api/v3/ticker/24hr":              {weight: 2, noSymbol: 80},
+	"GET /api/v3/ticker/price":             {weight: 2, noSymbol: 4},
+	"GET /api/v3/ticker/bookTicker":        {weight: 2, noSymbol: 4},
+	"GET /api/v3/ticker":                   {weight: 4, noSymbol: 200},
+	"GET /api/v3/account":                  {weight: 20},
+	"GET /api/v3/myTrades":                 {weight: 20},
+	"GET /api/v3/order":                    {weight: 4},
+	"GET /api/v3/openOrders":               {weight: 6, noSymbol: 80},
+	"GET /api/v3/allOrders":                {weight: 20},
+	"GET /api/v3/orderList":                {weight: 4},
+	"GET /api/v3/allOrderList":             {weight: 20},
+	"GET /api/v3/openOrderList":            {weight: 6},
+	"GET /api/v3/rateLimit/order":          {weight: 40},
+	"POST /api/v3/userDataStream":          {weight: 2},
+	"PUT /api/v3/userDataStream":           {weight: 2},
+	"DELETE /api/v3/userDataStream":        {weight: 2},
+	"GET /sapi/v1/accountSnapshot":         {weight: 2400},
+	"GET /sapi/v1/asset/assetDividend":     {weight: 10},
+	"GET /sapi/v1/capital/config/getall":   {weight: 10},
+	"GET /sapi/v1/capital/deposit/address": {weight: 10},
+	"GET /sapi/v1/futures/transfer":        {weight: 10},
+	"GET /sapi/v1/margin/account":          {weight: 10},
+	"GET /sapi/v1/margin/isolated/account": {weight: 10},
+	"GET /sapi/v1/margin/order":            {weight: 10},
+	"GET /sapi/v1/margin/openOrders":       {weight: 10},
+	"GET /sapi/v1/margin/allOrders":        {weight: 200},
+	"GET /sapi/v1/margin/myTrades":         {weight: 10},
+	"GET /sapi/v1/margin/loan":             {weight: 10},
+	"GET /sapi/v1/margin/repay":            {weight: 10},
+	"GET /sapi/v1/margin/maxBorrowable":    {weight: 50},
+	"GET /sapi/v1/margin/maxTransferable":  {weight: 50},
+	"GET /sapi/v1/margin/orderList":        {weight: 10},
+	"GET /sapi/v1/margin/allOrderList":     {weight: 200},
+	"GET /sapi/v1/margin/openOrderList":    {weight: 10},
+
+	// futures
+	"GET /fapi/v1/trades":            {weight: 5},
+	"GET /fapi/v1/historicalTrades":  {weight: 20},
+	"GET /fapi/v1/aggTrades":         {weight: 20},
+	"GET /fapi/v1/klines":            {weight: 5, byLimit: klineWeight},
+	"GET /fapi/v1/continuousKlines":  {weight: 5, byLimit: klineWeight},
+	"GET /fapi/v1/indexPriceKlines":  {weight: 5, byLimit: klineWeight},
+	"GET /fapi/v1/markPriceKlines":   {weight: 5, byLimit: klineWeight},
+	"GET /fapi/v1/ticker/24hr":       {weight: 1, noSymbol: 40},
+	"GET /fapi/v1/ticker/price":      {weight: 1, noSymbol: 2},
+	"GET /fapi/v1/ticker/bookTicker": {weight: 2, noSymbol: 5},
+	"GET /fapi/v2/account":           {weight: 5},
+	"GET /fapi/v2/balance":           {weight: 5},
+	"GET /fapi/v2/positionRisk":      {weight: 5},
+	"GET /fapi/v1/userTrades":        {weight: 5},
+	"GET /fapi/v1/allOrders":         {weight: 5},
+	"GET /fapi/v1/openOrders":        {weight: 1, noSymbol: 40},
+	"GET /fapi/v1/income":            {weight: 30},
+	"GET /fapi/v1/forceOrders":       {weight: 20, noSymbol: 50},
+	"GET /fapi/v1/allForceOrders":    {weight: 20},
+	"GET /fapi/v1/commissionRate":    {weight: 20},
+	"GET /fapi/v1/positionSide/dual": {weight: 30},
+	"GET /fapi/v1/multiAssetsMargin": {weight: 30},
+	"POST /fapi/v1/batchOrders":      {weight: 5},
+
+	// delivery
+	"GET /dapi/v1/trades":            {weight: 5},
+	"GET /dapi/v1/historicalTrades":  {weight: 20},
+	"GET /dapi/v1/aggTrades":         {weight: 20},
+	"GET /dapi/v1/klines":            {weight: 5, byLimit: klineWeight},
+	"GET /dapi/v1/continuousKlines":  {weight: 5, byLimit: klineWeight},
+	"GET /dapi/v1/indexPriceKlines":  {weight: 5, byLimit: klineWeight},
+	"GET /dapi/v1/markPriceKlines":   {weight: 5, byLimit: klineWeight},
+	"GET /dapi/v1/premiumIndex":      {weight: 10},
+	"GET /dapi/v1/ticker/24hr":       {weight: 1, noSymbol: 40},
+	"GET /dapi/v1/ticker/price":      {weight: 1, noSymbol: 2},
+	"GET /dapi/v1/ticker/bookTicker": {weight: 2, noSymbol: 5},
+	"GET /dapi/v1/account":           {weight: 5},
+	"GET /dapi/v1/userTrades":        {weight: 20, noSymbol: 40},
+	"GET /dapi/v1/allOrders":         {weight: 20, noSymbol: 40},
+	"GET /dapi/v1/openOrders":        {weight: 1, noSymbol: 40},
+	"GET /dapi/v1/income":            {weight: 20},
+	"GET /dapi/v1/forceOrders":       {weight: 20, noSymbol: 50},
+	"GET /dapi/v1/commissionRate":    {weight: 20},
+	"GET /dapi/v1/positionSide/dual": {weight: 30},
+	"POST /dapi/v1/batchOrders":      {weight: 5},
+}
+
+// param returns the value of a query or form parameter of the request
+func (r *Request) param(key string) string {
+	if v := r.Query.Get(key); v != "" {
+		return v
+	}
+	return r.Form.Get(key)
+}
+
+// weight returns the request weight reserved by the RateLimiter, Weight when
+// set, otherwise the weight of the endpoint
+func (r *Request) weight() int64 {
+	if r.Weight > 0 {
+		return r.Weight
+	}
+	w, ok := endpointWeights[r.Method+" "+strings.TrimSpace(r.Endpoint)]
+	if !ok {
+		return 1
+	}
+	if w.byLimit != nil {
+		if limit, err := strconv.ParseInt(r.param("limit"), 10, 64); err == nil {
+			return w.byLimit(limit)
+		}
+	}
+	if w.noSymbol > 0 && r.param("symbol") == "" {
+		return w.noSymbol
+	}
+	return w.weight
+}
@@ -0,0 +1,220 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/pooyakn/go-binance/v2/internal/wsconn"
+)
+
+// WsAPIMethods maps the routes of REST endpoints, e.g. "POST /api/v3/order",
+// to the equivalent WebSocket API methods, e.g. "order.place"
+type WsAPIMethods map[string]string
+
+// wsAPIIntegerParams are the parameters sent as JSON numbers to the WebSocket API
+var wsAPIIntegerParams = map[string]bool{
+	TimestampKey:    true,
+	RecvWindowKey:   true,
+	"orderId":       true,
+	"orderListId":   true,
+	"limit":         true,
+	"fromId":        true,
+	"startTime":     true,
+	"endTime":       true,
+	"trailingDelta": true,
+	"strategyId":    true,
+	"strategyType":  true,
+}
+
+// CallWsAPI sends r over the WebSocket API connection, as the method mapped
+// to its route, and returns the result of the response. The request is signed
+// unless the session of the connection is authenticated. As with CallAPI, the
+// request goes through the middlewares and is retried as allowed by the
+// RetryPolicy.
+// A response with a status code of 400 or above is decoded into a *common.APIError.
+func (c *Client) CallWsAPI(ctx context.Context, conn *wsconn.APIConn, methods WsAPIMethods, r *Request, opts ...RequestOption) ([]byte, error) {
+	for _, opt := range opts {
+		opt(r)
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	method, ok := methods[r.Method+" "+r.Endpoint]
+	if !ok {
+		return nil, fmt.Errorf("%s %s is not available on the WebSocket API", r.Method, r.Endpoint)
+	}
+	data, _, err := c.call(ctx, r, func(ctx context.Context, r *Request, attempt int) ([]byte, *http.Header, int, error) {
+		return c.sendWsAPI(ctx, conn, method, r, attempt)
+	})
+	return data, err
+}
+
+// sendWsAPI makes a single attempt of r as method, the status code is 0 when
+// no response was received
+func (c *Client) sendWsAPI(ctx context.Context, conn *wsconn.APIConn, method string, r *Request, attempt int) (data []byte, header *http.Header, statusCode int, err error) {
+	header = &http.Header{}
+	weight := r.weight()
+	// wait before signing, so that the timestamp is not stale when sent
+	if c.RateLimiter != nil {
+		if err = c.RateLimiter.Wait(ctx, weight, r.isOrder()); err != nil {
+			return nil, header, 0, err
+		}
+	}
+	params, err := c.WsAPIParams(r, !conn.LoggedOn())
+	if err != nil {
+		return nil, header, 0, err
+	}
+	info := &common.RequestInfo{
+		Method:   r.Method,
+		Endpoint: r.Endpoint,
+		Query:    r.Query,
+		Header:   http.Header{},
+		Signed:   r.SecType == SecTypeSigned,
+		Weight:   weight,
+		Attempt:  attempt,
+	}
+	ctx, err = c.beforeSend(ctx, info)
+	if err != nil {
+		return nil, header, 0, err
+	}
+	var usedWeight int64
+	start := time.Now()
+	defer func() {
+		c.afterReceive(ctx, c.Middlewares, info, &common.ResponseInfo{
+			StatusCode: statusCode,
+			Header:     *header,
+			Latency:    time.Since(start),
+			UsedWeight: usedWeight,
+			Err:        err,
+		})
+	}()
+	c.debug("ws-api request", "method", method, "params", common.RedactBody(encodeWsAPIParams(params)))
+	res, err := conn.Request(ctx, method, params)
+	if err != nil {
+		return nil, header, 0, err
+	}
+	c.debug("ws-api response", "method", method, "status", res.Status, "body", common.RedactBody(string(res.Raw)))
+	usedWeight = wsAPIUsedWeight(res.RateLimits)
+	if c.RateLimiter != nil {
+		c.RateLimiter.UpdateUsage(res.RateLimits)
+		if res.Error != nil {
+			c.RateLimiter.Update(res.Status, nil, res.Raw)
+		}
+	}
+	if res.Error != nil {
+		res.Error.StatusCode = res.Status
+		res.Error.Method = r.Method
+		res.Error.Endpoint = r.Endpoint
+		return nil, header, res.Status, res.Error
+	}
+	return res.Result, header, res.Status, nil
+}
+
+// wsAPIUsedWeight returns the request weight used in the current minute, as
+// reported by a WebSocket API response, 0 if missing
+func wsAPIUsedWeight(usages []common.RateLimitUsage) int64 {
+	for _, u := range usages {
+		if u.RateLimitType == common.RateLimitTypeRequestWeight && u.Duration() == time.Minute {
+			return u.Count
+		}
+	}
+	return 0
+}
+
+// WsAPILogon authenticates the session of the connection with the API key,
+// the following requests are then neither signed nor sent with the API key.
+// Binance only accepts Ed25519 keys for the session authentication.
+func (c *Client) WsAPILogon(ctx context.Context, conn *wsconn.APIConn) error {
+	params, err := c.WsAPIParams(&Request{SecType: SecTypeSigned}, true)
+	if err != nil {
+		return err
+	}
+	if err := c.wsAPISessionRequest(ctx, conn, "session.logon", params); err != nil {
+		return err
+	}
+	conn.SetLoggedOn(true)
+	return nil
+}
+
+// WsAPILogout forgets the authentication of the session of the connection
+func (c *Client) WsAPILogout(ctx context.Context, conn *wsconn.APIConn) error {
+	if err := c.wsAPISessionRequest(ctx, conn, "session.logout", nil); err != nil {
+		return err
+	}
+	conn.SetLoggedOn(false)
+	return nil
+}
+
+func (c *Client) wsAPISessionRequest(ctx context.Context, conn *wsconn.APIConn, method string, params map[string]interface{}) error {
+	res, err := conn.Request(ctx, method, params)
+	if err != nil {
+		return err
+	}
+	if res.Error != nil {
+		res.Error.StatusCode = res.Status
+		return res.Error
+	}
+	return nil
+}
+
+// WsAPIParams returns the parameters of r for the WebSocket API. With
+// authenticate, the API key is added to the requests which need it, and the
+// SIGNED requests are signed.
+func (c *Client) WsAPIParams(r *Request, authenticate bool) (map[string]interface{}, error) {
+	params := make(map[string]interface{}, len(r.Query)+len(r.Form)+3)
+	for _, values := range []map[string][]string{r.Query, r.Form} {
+		for k, v := range values {
+			if len(v) > 0 {
+				params[k] = wsAPIParam(k, v[0])
+			}
+		}
+	}
+	if r.RecvWindow > 0 {
+		params[RecvWindowKey] = r.RecvWindow
+	}
+	if r.SecType == SecTypeSigned {
+		params[TimestampKey] = CurrentTimestamp() - c.timeOffset()
+	}
+	if !authenticate || r.SecType == SecTypeNone {
+		return params, nil
+	}
+	params["apiKey"] = c.APIKey
+	if r.SecType == SecTypeSigned {
+		signature, err := c.Sign([]byte(encodeWsAPIParams(params)))
+		if err != nil {
+			return nil, err
+		}
+		params[SignatureKey] = signature
+	}
+	return params, nil
+}
+
+func wsAPIParam(key, value string) interface{} {
+	if wsAPIIntegerParams[key] {
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	}
+	return value
+}
+
+// encodeWsAPIParams encodes the parameters sorted by name, as signed for the
+// WebSocket API
+func encodeWsAPIParams(params map[string]interface{}) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf("%s=%v", k, params[k])
+	}
+	return strings.Join(pairs, "&")
+}
@@ -0,0 +1,276 @@
+// Package userstream maintains user data streams, owning the lifecycle of
+// their listen key: creation, keepalives, renewal once expired and
+// reconnections, as documented by Binance for every product.
+package userstream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/common"
+)
+
+// closeTimeout bounds the request closing the listen key once the stream stopped
+const closeTimeout = 10 * time.Second
+
+// Config define the settings of a user data stream
+type Config struct {
+	// StartListenKey creates a listen key
+	StartListenKey func(ctx context.Context) (string, error)
+	// KeepaliveListenKey extends the validity of a listen key
+	KeepaliveListenKey func(ctx context.Context, listenKey string) error
+	// CloseListenKey closes a listen key
+	CloseListenKey func(ctx context.Context, listenKey string) error
+	// Serve connects the stream of listenKey until ctx is done, doneC is
+	// closed once the connection stopped
+	Serve func(ctx context.Context, listenKey string) (doneC <-chan struct{}, err error)
+	// Snapshot is called once the stream is connected, initially and after
+	// every reconnection, to fetch the state the events may have missed
+	Snapshot func(ctx context.Context)
+	// KeepaliveInterval is the delay between two keepalives of the listen key
+	KeepaliveInterval time.Duration
+	// Reconnect is the policy of the reconnections, its MaxConnectionAge is
+	// not used as the listen key outlives the connections
+	Reconnect  *common.ReconnectPolicy
+	ErrHandler func(err error)
+	Logger     common.Logger
+}
+
+// Stream is a user data stream, maintained by a goroutine until its context is done
+type Stream struct {
+	cfg      Config
+	expiredC chan string
+	doneC    chan struct{}
+
+	mu        sync.Mutex
+	listenKey string
+}
+
+// New init a stream with cfg
+func New(cfg Config) *Stream {
+	if cfg.Reconnect == nil {
+		cfg.Reconnect = common.NewReconnectPolicy()
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = common.NewNopLogger()
+	}
+	return &Stream{
+		cfg:      cfg,
+		expiredC: make(chan string, 1),
+		doneC:    make(chan struct{}),
+	}
+}
+
+// Start creates a listen key and connects its stream, then maintains it
+// until ctx is done. The errors of the first connection are returned, the
+// stream is then stopped.
+func (s *Stream) Start(ctx context.Context) error {
+	listenKey, err := s.cfg.StartListenKey(ctx)
+	if err != nil {
+		close(s.doneC)
+		return err
+	}
+	s.setListenKey(listenKey)
+	connCtx, connCancel := context.WithCancel(ctx)
+	doneC, err := s.cfg.Serve(connCtx, listenKey)
+	if err != nil {
+		connCancel()
+		s.closeListenKey(listenKey)
+		s.setListenKey("")
+		close(s.doneC)
+		return err
+	}
+	go s.run(ctx, doneC, connCancel)
+	return nil
+}
+
+// ErrStreamStarted is returned by the products when starting a user data
+// stream while it runs
+var ErrStreamStarted = errors.New("user data stream already started")
+
+// stoppedC is the channel returned by the Done method of a nil stream
+var stoppedC = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
+// ListenKey returns the listen key of the stream, empty for a nil stream
+func (s *Stream) ListenKey() string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listenKey
+}
+
+func (s *Stream) setListenKey(listenKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listenKey = listenKey
+}
+
+// Expired renews the listen key once listenKey is reported expired by the
+// stream, e.g. with a listenKeyExpired event. The reports of previous keys are
+// ignored.
+func (s *Stream) Expired(listenKey string) {
+	if listenKey != s.ListenKey() {
+		return
+	}
+	select {
+	case s.expiredC <- listenKey:
+	default:
+	}
+}
+
+// Done returns a channel closed once the stream stopped, closed for a nil
+// stream, e.g. one not started yet
+func (s *Stream) Done() <-chan struct{} {
+	if s == nil {
+		return stoppedC
+	}
+	return s.doneC
+}
+
+func (s *Stream) run(ctx context.Context, doneC <-chan struct{}, connCancel context.CancelFunc) {
+	defer close(s.doneC)
+	defer func() {
+		connCancel()
+		s.closeListenKey(s.ListenKey())
+	}()
+
+	s.cfg.Snapshot(ctx)
+	keepalive := time.NewTicker(s.cfg.KeepaliveInterval)
+	defer keepalive.Stop()
+	for {
+		var cause error
+		renew := false
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepalive.C:
+			err := s.cfg.KeepaliveListenKey(ctx, s.ListenKey())
+			if err == nil || ctx.Err() != nil {
+				continue
+			}
+			if !errors.Is(err, common.ErrInvalidListenKey) {
+				s.cfg.ErrHandler(err)
+				continue
+			}
+			cause, renew = err, true
+		case listenKey := <-s.expiredC:
+			if listenKey != s.ListenKey() {
+				continue
+			}
+			cause, renew = errListenKeyExpired, true
+		case <-doneC:
+			if ctx.Err() != nil {
+				return
+			}
+			cause = errConnectionLost
+		}
+
+		s.cfg.Logger.Info("user data stream reconnecting", "error", cause)
+		connCancel()
+		if renew {
+			// drop the current key, the next attempt creates a new one
+			s.setListenKey("")
+		}
+		newDoneC, newCancel, ok := s.reconnect(ctx, cause)
+		if !ok {
+			return
+		}
+		doneC, connCancel = newDoneC, newCancel
+		keepalive.Reset(s.cfg.KeepaliveInterval)
+	}
+}
+
+var (
+	errListenKeyExpired = errors.New("listen key expired")
+	errConnectionLost   = errors.New("connection lost")
+)
+
+// reconnect connects the stream again, with a new listen key once the current
+// one is dropped or no longer exists, until connected, ctx is done or the
+// policy gives up
+func (s *Stream) reconnect(ctx context.Context, cause error) (<-chan struct{}, context.CancelFunc, bool) {
+	policy := s.cfg.Reconnect
+	s.stateChange(common.WsStateReconnecting, cause)
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		doneC, connCancel, err := s.connect(ctx)
+		if err == nil {
+			s.stateChange(common.WsStateConnected, nil)
+			if policy.OnGap != nil {
+				policy.OnGap(common.WsGap{Start: start, End: time.Now()})
+			}
+			s.cfg.Snapshot(ctx)
+			return doneC, connCancel, true
+		}
+		if ctx.Err() != nil {
+			return nil, nil, false
+		}
+		s.cfg.ErrHandler(err)
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			s.stateChange(common.WsStateGaveUp, err)
+			return nil, nil, false
+		}
+		timer := time.NewTimer(policy.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, false
+		case <-timer.C:
+		}
+	}
+}
+
+// connect connects the stream of the current listen key, checked with a
+// keepalive, or of a new one
+func (s *Stream) connect(ctx context.Context) (<-chan struct{}, context.CancelFunc, error) {
+	listenKey := s.ListenKey()
+	if listenKey != "" {
+		err := s.cfg.KeepaliveListenKey(ctx, listenKey)
+		if errors.Is(err, common.ErrInvalidListenKey) {
+			listenKey = ""
+		} else if err != nil {
+			return nil, nil, err
+		}
+	}
+	if listenKey == "" {
+		var err error
+		listenKey, err = s.cfg.StartListenKey(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		s.setListenKey(listenKey)
+	}
+	connCtx, connCancel := context.WithCancel(ctx)
+	doneC, err := s.cfg.Serve(connCtx, listenKey)
+	if err != nil {
+		connCancel()
+		return nil, nil, err
+	}
+	return doneC, connCancel, nil
+}
+
+func (s *Stream) stateChange(state common.WsState, err error) {
+	if s.cfg.Reconnect.OnStateChange != nil {
+		s.cfg.Reconnect.OnStateChange(state, err)
+	}
+}
+
+// closeListenKey closes listenKey once the stream stopped, ctx may be done
+func (s *Stream) closeListenKey(listenKey string) {
+	if listenKey == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	if err := s.cfg.CloseListenKey(ctx, listenKey); err != nil {
+		s.cfg.ErrHandler(err)
+	}
+}
@@ -0,0 +1,220 @@
+package userstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+// account emulates the listen keys of an account
+type account struct {
+	mu        sync.Mutex
+	next      int
+	keys      map[string]bool
+	conns     map[string]func()
+	snapshots int
+	startErr  error
+	errs      []error
+}
+
+func newAccount() *account {
+	return &account{keys: make(map[string]bool), conns: make(map[string]func())}
+}
+
+func (a *account) config() Config {
+	return Config{
+		StartListenKey: func(ctx context.Context) (string, error) {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			if a.startErr != nil {
+				return "", a.startErr
+			}
+			a.next++
+			key := fmt.Sprintf("key%d", a.next)
+			a.keys[key] = true
+			return key, nil
+		},
+		KeepaliveListenKey: func(ctx context.Context, listenKey string) error {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			if !a.keys[listenKey] {
+				return &common.APIError{Code: -1125, Message: "This listenKey does not exist."}
+			}
+			return nil
+		},
+		CloseListenKey: func(ctx context.Context, listenKey string) error {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			delete(a.keys, listenKey)
+			return nil
+		},
+		Serve: func(ctx context.Context, listenKey string) (<-chan struct{}, error) {
+			doneC := make(chan struct{})
+			var once sync.Once
+			stop := func() {
+				once.Do(func() { close(doneC) })
+			}
+			a.mu.Lock()
+			a.conns[listenKey] = stop
+			a.mu.Unlock()
+			go func() {
+				<-ctx.Done()
+				stop()
+			}()
+			return doneC, nil
+		},
+		Snapshot: func(ctx context.Context) {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			a.snapshots++
+		},
+		KeepaliveInterval: 10 * time.Millisecond,
+		Reconnect:         &common.ReconnectPolicy{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+		ErrHandler: func(err error) {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			a.errs = append(a.errs, err)
+		},
+	}
+}
+
+func (a *account) disconnect(listenKey string) {
+	a.mu.Lock()
+	stop := a.conns[listenKey]
+	a.mu.Unlock()
+	stop()
+}
+
+func (a *account) expire(listenKey string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.keys, listenKey)
+}
+
+func (a *account) snapshotCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.snapshots
+}
+
+type userStreamTestSuite struct {
+	suite.Suite
+}
+
+func TestUserStream(t *testing.T) {
+	suite.Run(t, new(userStreamTestSuite))
+}
+
+func (s *userStreamTestSuite) waitDone(stream *Stream) {
+	select {
+	case <-stream.Done():
+	case <-time.After(time.Second):
+		s.FailNow("stream not stopped")
+	}
+}
+
+func (s *userStreamTestSuite) TestKeepalive() {
+	a := newAccount()
+	stream := New(a.config())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Require().NoError(stream.Start(ctx))
+	s.Equal("key1", stream.ListenKey())
+
+	// the key is renewed once a keepalive reports that it no longer exists
+	a.expire("key1")
+	s.Require().Eventually(func() bool {
+		return stream.ListenKey() == "key2"
+	}, time.Second, time.Millisecond)
+	s.Eventually(func() bool {
+		return a.snapshotCount() == 2
+	}, time.Second, time.Millisecond)
+
+	// the key is kept once the connection is lost
+	a.disconnect("key2")
+	s.Require().Eventually(func() bool {
+		return a.snapshotCount() == 3
+	}, time.Second, time.Millisecond)
+	s.Equal("key2", stream.ListenKey())
+
+	cancel()
+	s.waitDone(stream)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s.Empty(a.keys)
+	s.Empty(a.errs)
+}
+
+func (s *userStreamTestSuite) TestExpired() {
+	a := newAccount()
+	cfg := a.config()
+	cfg.KeepaliveInterval = time.Hour
+	stream := New(cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Require().NoError(stream.Start(ctx))
+
+	stream.Expired("unknown")
+	stream.Expired("key1")
+	s.Require().Eventually(func() bool {
+		return stream.ListenKey() == "key2"
+	}, time.Second, time.Millisecond)
+	cancel()
+	s.waitDone(stream)
+}
+
+func (s *userStreamTestSuite) TestGiveUp() {
+	a := newAccount()
+	cfg := a.config()
+	cfg.Reconnect.MaxAttempts = 2
+	var states []common.WsState
+	cfg.Reconnect.OnStateChange = func(state common.WsState, err error) {
+		states = append(states, state)
+	}
+	stream := New(cfg)
+	s.Require().NoError(stream.Start(context.Background()))
+
+	errStart := errors.New("start failed")
+	a.mu.Lock()
+	a.startErr = errStart
+	a.mu.Unlock()
+	a.expire("key1")
+	s.waitDone(stream)
+	s.Equal([]common.WsState{common.WsStateReconnecting, common.WsStateGaveUp}, states)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s.Equal([]error{errStart, errStart}, a.errs)
+}
+
+func (s *userStreamTestSuite) TestStartFailure() {
+	a := newAccount()
+	cfg := a.config()
+	errServe := errors.New("dial failed")
+	cfg.Serve = func(ctx context.Context, listenKey string) (<-chan struct{}, error) {
+		return nil, errServe
+	}
+	stream := New(cfg)
+	s.Equal(errServe, stream.Start(context.Background()))
+	s.waitDone(stream)
+	s.Empty(stream.ListenKey())
+	a.mu.Lock()
+	s.Empty(a.keys, "listen key not closed")
+	a.mu.Unlock()
+
+	a.startErr = errors.New("start failed")
+	stream = New(a.config())
+	s.Error(stream.Start(context.Background()))
+	s.waitDone(stream)
+}
+
+func (s *userStreamTestSuite) TestNilStream() {
+	var stream *Stream
+	s.Empty(stream.ListenKey())
+	s.waitDone(stream)
+}
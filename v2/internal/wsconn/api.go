@@ -0,0 +1,127 @@
+package wsconn
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/pooyakn/go-binance/v2/common"
+)
+
+// APIResponse is a response of the WebSocket API
+type APIResponse struct {
+	ID         string                  `json:"id"`
+	Status     int                     `json:"status"`
+	Result     json.RawMessage         `json:"result"`
+	Error      *common.APIError        `json:"error"`
+	RateLimits []common.RateLimitUsage `json:"rateLimits"`
+	// Raw is the whole response
+	Raw []byte `json:"-"`
+}
+
+// APIConn is a connection to the WebSocket API, correlating the responses to
+// the requests with their id
+type APIConn struct {
+	stream *stream
+
+	writeMu sync.Mutex
+
+	mu       sync.Mutex
+	nextID   int64
+	pending  map[string]chan *APIResponse
+	loggedOn bool
+}
+
+// DialAPI connects to the WebSocket API endpoint of cfg. With a reconnect
+// policy, the connection is established again once lost, without the
+// authentication of the previous session.
+func DialAPI(cfg *Config, errHandler func(err error)) (*APIConn, error) {
+	c := &APIConn{pending: make(map[string]chan *APIResponse)}
+	st, err := serve(cfg, c.handle, errHandler, func(*websocket.Conn) error {
+		c.SetLoggedOn(false)
+		return nil
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.stream = st
+	return c, nil
+}
+
+func (c *APIConn) handle(message []byte) {
+	res := &APIResponse{Raw: message}
+	if err := json.Unmarshal(message, res); err != nil || res.ID == "" {
+		return
+	}
+	c.mu.Lock()
+	resC := c.pending[res.ID]
+	delete(c.pending, res.ID)
+	c.mu.Unlock()
+	if resC != nil {
+		resC <- res
+	}
+}
+
+// Request sends a request and waits for its response. The error of the
+// response is not returned as an error. It returns ErrSessionClosed when the
+// connection is closed, before sending or while waiting, and
+// ErrConnectionClosed when the connection is lost before the response is
+// received, the next requests being sent once reconnected.
+func (c *APIConn) Request(ctx context.Context, method string, params map[string]interface{}) (*APIResponse, error) {
+	select {
+	case <-c.stream.doneC:
+		return nil, ErrSessionClosed
+	default:
+	}
+	resC := make(chan *APIResponse, 1)
+	c.mu.Lock()
+	c.nextID++
+	id := strconv.FormatInt(c.nextID, 10)
+	c.pending[id] = resC
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	req := struct {
+		ID     string                 `json:"id"`
+		Method string                 `json:"method"`
+		Params map[string]interface{} `json:"params,omitempty"`
+	}{id, method, params}
+	conn := c.stream.current()
+	c.writeMu.Lock()
+	err := conn.WriteJSON(req)
+	c.writeMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return waitResponse(ctx, resC, c.stream, conn)
+}
+
+// LoggedOn reports whether the session of the connection is authenticated
+func (c *APIConn) LoggedOn() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.loggedOn
+}
+
+// SetLoggedOn records whether the session of the connection is authenticated
+func (c *APIConn) SetLoggedOn(loggedOn bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loggedOn = loggedOn
+}
+
+// Close closes the connection
+func (c *APIConn) Close() {
+	c.stream.stop()
+}
+
+// Done returns a channel closed once the connection is closed, or gave up reconnecting
+func (c *APIConn) Done() <-chan struct{} {
+	return c.stream.doneC
+}
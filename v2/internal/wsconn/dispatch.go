@@ -0,0 +1,324 @@
+package wsconn
+
+import (
+	"encoding/json"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/common"
+)
+
+// defaultQueueSize is the size of the dispatch queues when not set by the policy
+const defaultQueueSize = 1024
+
+// dispatchItem is a message waiting for its handler
+type dispatchItem struct {
+	stream  string
+	key     string
+	message []byte
+	handler func(message []byte)
+	// queued is the time the first message of the key was queued, received
+	// the time the message was
+	queued   time.Time
+	received time.Time
+}
+
+// dispatcher passes the messages of a stream to their handler from its own
+// goroutine, through a queue bounded by the dispatch policy
+type dispatcher struct {
+	policy *common.DispatchPolicy
+	// name is the stream name of the messages pushed without one
+	name string
+	size int
+	// clock is set to the time a message was received before it is handled
+	clock *ReceiveClock
+
+	mu     sync.Mutex
+	queue  []*dispatchItem
+	closed bool
+
+	// readyC is signalled once a message is queued or the dispatcher closed,
+	// roomC once a message is dequeued
+	readyC chan struct{}
+	roomC  chan struct{}
+	doneC  chan struct{}
+}
+
+// newDispatcher starts a dispatcher with policy, nil when policy is nil
+func newDispatcher(policy *common.DispatchPolicy, name string, clock *ReceiveClock) *dispatcher {
+	if policy == nil {
+		return nil
+	}
+	size := policy.QueueSize
+	if size <= 0 {
+		size = defaultQueueSize
+	}
+	d := &dispatcher{
+		policy: policy,
+		name:   name,
+		size:   size,
+		clock:  clock,
+		readyC: make(chan struct{}, 1),
+		roomC:  make(chan struct{}, 1),
+		doneC:  make(chan struct{}),
+	}
+	go d.run()
+	return d
+}
+
+// dispatchers passes the messages of every stream to their handler from a
+// dispatcher of their own, so that a slow handler only delays its stream. The
+// dispatchers are started on the first message of their stream.
+type dispatchers struct {
+	policy *common.DispatchPolicy
+	// clock returns the clock of a stream, set before its messages are handled
+	clock func(stream string) *ReceiveClock
+
+	mu      sync.Mutex
+	streams map[string]*dispatcher
+	closed  bool
+}
+
+// newDispatchers init the dispatchers of policy, nil when policy is nil
+func newDispatchers(policy *common.DispatchPolicy, clock func(stream string) *ReceiveClock) *dispatchers {
+	if policy == nil {
+		return nil
+	}
+	return &dispatchers{
+		policy:  policy,
+		clock:   clock,
+		streams: make(map[string]*dispatcher),
+	}
+}
+
+// push queues the message of the stream for handler, on the dispatcher of
+// the stream
+func (ds *dispatchers) push(stream string, message []byte, handler func(message []byte)) {
+	ds.mu.Lock()
+	d, ok := ds.streams[stream]
+	if !ok && !ds.closed {
+		d = newDispatcher(ds.policy, stream, ds.clock(stream))
+		ds.streams[stream] = d
+	}
+	ds.mu.Unlock()
+	if d == nil {
+		if ds.policy.Metrics != nil {
+			ds.policy.Metrics.Update(stream, func(stats *common.DispatchStats) {
+				stats.Received++
+				stats.Dropped++
+			})
+		}
+		return
+	}
+	d.push(stream, message, handler)
+}
+
+// remove stops the dispatcher of the stream once its queued messages are handled
+func (ds *dispatchers) remove(stream string) {
+	if ds == nil {
+		return
+	}
+	ds.mu.Lock()
+	d := ds.streams[stream]
+	delete(ds.streams, stream)
+	ds.mu.Unlock()
+	if d != nil {
+		go d.close()
+	}
+}
+
+// close waits until the queued messages of every stream are handled
+func (ds *dispatchers) close() {
+	if ds == nil {
+		return
+	}
+	ds.mu.Lock()
+	ds.closed = true
+	streams := ds.streams
+	ds.streams = nil
+	ds.mu.Unlock()
+	for _, d := range streams {
+		d.close()
+	}
+}
+
+// streamName returns the name of the stream of endpoint used by the metrics,
+// e.g. "btcusdt@depth" or the streams of a combined endpoint
+func streamName(endpoint string) string {
+	u, err := url.Parse(common.RedactURL(endpoint))
+	if err != nil {
+		return endpoint
+	}
+	if streams := u.Query().Get("streams"); streams != "" {
+		return streams
+	}
+	return path.Base(u.Path)
+}
+
+func signal(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
+
+func (d *dispatcher) metrics(stream string, f func(stats *common.DispatchStats)) {
+	if d.policy.Metrics != nil {
+		d.policy.Metrics.Update(stream, f)
+	}
+}
+
+// coalesceKey returns the key of the message for OverflowCoalesce, empty
+// when it is not coalesced
+func (d *dispatcher) coalesceKey(stream string, message []byte) string {
+	if d.policy.Overflow != common.OverflowCoalesce {
+		return ""
+	}
+	var key string
+	if d.policy.CoalesceKey != nil {
+		key = d.policy.CoalesceKey(message)
+		if key == "" {
+			return ""
+		}
+	} else {
+		var event struct {
+			Stream string `json:"stream"`
+			Symbol string `json:"s"`
+			Data   struct {
+				Symbol string `json:"s"`
+			} `json:"data"`
+		}
+		// the arrays of the all market streams are not coalesced
+		if json.Unmarshal(message, &event) != nil {
+			return ""
+		}
+		key = event.Symbol
+		if event.Stream != "" {
+			key = event.Stream + "\x00" + event.Data.Symbol
+		}
+	}
+	return stream + "\x00" + key
+}
+
+// push queues the message of the stream for handler, applying the overflow
+// policy once the queue is full
+func (d *dispatcher) push(stream string, message []byte, handler func(message []byte)) {
+	if stream == "" {
+		stream = d.name
+	}
+	now := time.Now()
+	item := &dispatchItem{
+		stream:   stream,
+		key:      d.coalesceKey(stream, message),
+		message:  message,
+		handler:  handler,
+		queued:   now,
+		received: now,
+	}
+	d.metrics(stream, func(stats *common.DispatchStats) {
+		stats.Received++
+	})
+
+	d.mu.Lock()
+	if item.key != "" {
+		for i, queued := range d.queue {
+			if queued.key == item.key {
+				// the delay is counted from the replaced message
+				item.queued = queued.queued
+				d.queue[i] = item
+				d.mu.Unlock()
+				d.metrics(stream, func(stats *common.DispatchStats) {
+					stats.Coalesced++
+				})
+				return
+			}
+		}
+	}
+	for len(d.queue) >= d.size && !d.closed {
+		switch d.policy.Overflow {
+		case common.OverflowDropNewest:
+			d.mu.Unlock()
+			d.metrics(stream, func(stats *common.DispatchStats) {
+				stats.Dropped++
+			})
+			return
+		case common.OverflowDropOldest, common.OverflowCoalesce:
+			dropped := d.queue[0]
+			d.queue[0] = nil
+			d.queue = d.queue[1:]
+			d.metrics(dropped.stream, func(stats *common.DispatchStats) {
+				stats.Queued--
+				stats.Dropped++
+			})
+		default:
+			d.mu.Unlock()
+			<-d.roomC
+			d.mu.Lock()
+		}
+	}
+	if d.closed {
+		d.mu.Unlock()
+		d.metrics(stream, func(stats *common.DispatchStats) {
+			stats.Dropped++
+		})
+		return
+	}
+	d.queue = append(d.queue, item)
+	d.metrics(stream, func(stats *common.DispatchStats) {
+		stats.Queued++
+	})
+	d.mu.Unlock()
+	signal(d.readyC)
+}
+
+// run calls the handlers of the queued messages, until closed and the queue
+// is empty
+func (d *dispatcher) run() {
+	defer close(d.doneC)
+	for {
+		d.mu.Lock()
+		if len(d.queue) == 0 {
+			closed := d.closed
+			d.mu.Unlock()
+			if closed {
+				return
+			}
+			<-d.readyC
+			continue
+		}
+		item := d.queue[0]
+		d.queue[0] = nil
+		d.queue = d.queue[1:]
+		d.mu.Unlock()
+		signal(d.roomC)
+
+		start := time.Now()
+		d.metrics(item.stream, func(stats *common.DispatchStats) {
+			stats.Queued--
+			stats.QueueDelay = start.Sub(item.queued)
+		})
+		d.clock.set(item.received)
+		item.handler(item.message)
+		latency := time.Since(start)
+		d.metrics(item.stream, func(stats *common.DispatchStats) {
+			stats.Handled++
+			stats.HandlerLatency += latency
+			if latency > stats.MaxHandlerLatency {
+				stats.MaxHandlerLatency = latency
+			}
+		})
+	}
+}
+
+// close waits until the queued messages are handled, the messages pushed
+// meanwhile are dropped
+func (d *dispatcher) close() {
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+	signal(d.readyC)
+	signal(d.roomC)
+	<-d.doneC
+}
@@ -0,0 +1,247 @@
+package wsconn
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type dispatchTestSuite struct {
+	suite.Suite
+
+	mu       sync.Mutex
+	handled  []string
+	unblockC chan struct{}
+}
+
+func TestDispatch(t *testing.T) {
+	suite.Run(t, new(dispatchTestSuite))
+}
+
+func (s *dispatchTestSuite) SetupTest() {
+	s.handled = nil
+	s.unblockC = make(chan struct{})
+}
+
+// handler blocks until unblockC is closed, then records the messages
+func (s *dispatchTestSuite) handler(message []byte) {
+	<-s.unblockC
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handled = append(s.handled, string(message))
+}
+
+// pushBlocked pushes a first message, which blocks the handler, then the
+// messages
+func (s *dispatchTestSuite) pushBlocked(d *dispatcher, messages ...string) {
+	d.push("", []byte(`{"s":"FIRST"}`), s.handler)
+	s.Require().Eventually(func() bool {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return len(d.queue) == 0
+	}, time.Second, time.Millisecond)
+	for _, m := range messages {
+		d.push("", []byte(m), s.handler)
+	}
+}
+
+// finish unblocks the handler and returns the handled messages, except the first
+func (s *dispatchTestSuite) finish(d *dispatcher) []string {
+	close(s.unblockC)
+	d.close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Require().NotEmpty(s.handled)
+	return s.handled[1:]
+}
+
+func (s *dispatchTestSuite) TestDropNewest() {
+	metrics := common.NewDispatchMetrics()
+	d := newDispatcher(&common.DispatchPolicy{QueueSize: 2, Overflow: common.OverflowDropNewest, Metrics: metrics}, "stream", nil)
+	s.pushBlocked(d, `{"s":"A"}`, `{"s":"B"}`, `{"s":"C"}`)
+
+	stats, ok := metrics.Stream("stream")
+	s.True(ok)
+	s.Equal(2, stats.Queued)
+	s.Equal(int64(4), stats.Received)
+	s.Equal(int64(1), stats.Dropped)
+
+	s.Equal([]string{`{"s":"A"}`, `{"s":"B"}`}, s.finish(d))
+	stats, _ = metrics.Stream("stream")
+	s.Equal(0, stats.Queued)
+	s.Equal(int64(3), stats.Handled)
+	s.True(stats.MaxHandlerLatency > 0)
+	s.True(stats.AvgHandlerLatency() <= stats.MaxHandlerLatency)
+}
+
+func (s *dispatchTestSuite) TestDropOldest() {
+	metrics := common.NewDispatchMetrics()
+	d := newDispatcher(&common.DispatchPolicy{QueueSize: 2, Overflow: common.OverflowDropOldest, Metrics: metrics}, "stream", nil)
+	s.pushBlocked(d, `{"s":"A"}`, `{"s":"B"}`, `{"s":"C"}`)
+	s.Equal([]string{`{"s":"B"}`, `{"s":"C"}`}, s.finish(d))
+	s.Equal(int64(1), metrics.Total().Dropped)
+}
+
+func (s *dispatchTestSuite) TestCoalesce() {
+	metrics := common.NewDispatchMetrics()
+	d := newDispatcher(&common.DispatchPolicy{QueueSize: 2, Overflow: common.OverflowCoalesce, Metrics: metrics}, "stream", nil)
+	s.pushBlocked(d,
+		`{"s":"A","v":1}`,
+		`{"s":"B","v":1}`,
+		`{"s":"A","v":2}`,
+		`[{"s":"A"}]`,
+		`{"stream":"a@ticker","data":{"s":"A","v":3}}`,
+	)
+	// the array drops the oldest message, as it can not be coalesced
+	s.Equal([]string{`[{"s":"A"}]`, `{"stream":"a@ticker","data":{"s":"A","v":3}}`}, s.finish(d))
+	stats, _ := metrics.Stream("stream")
+	s.Equal(int64(1), stats.Coalesced)
+	s.Equal(int64(2), stats.Dropped)
+}
+
+func (s *dispatchTestSuite) TestCoalesceReceiveTime() {
+	clock := new(ReceiveClock)
+	metrics := common.NewDispatchMetrics()
+	d := newDispatcher(&common.DispatchPolicy{Overflow: common.OverflowCoalesce, Metrics: metrics}, "stream", clock)
+	received := make(map[string]time.Time)
+	handler := func(message []byte) {
+		<-s.unblockC
+		received[string(message)] = clock.Time()
+	}
+	d.push("", []byte(`{"s":"FIRST"}`), handler)
+	d.push("", []byte(`{"s":"A","v":1}`), handler)
+	time.Sleep(10 * time.Millisecond)
+	pushed := time.Now()
+	d.push("", []byte(`{"s":"A","v":2}`), handler)
+	close(s.unblockC)
+	d.close()
+
+	// the coalesced message keeps its own receive time, the delay is
+	// counted from the replaced one
+	s.Len(received, 2)
+	s.False(received[`{"s":"A","v":2}`].Before(pushed))
+	stats, _ := metrics.Stream("stream")
+	s.True(stats.QueueDelay >= 10*time.Millisecond, stats.QueueDelay)
+}
+
+func (s *dispatchTestSuite) TestCoalesceKey() {
+	d := newDispatcher(&common.DispatchPolicy{
+		QueueSize:   10,
+		Overflow:    common.OverflowCoalesce,
+		CoalesceKey: func(message []byte) string { return string(message[:1]) },
+	}, "stream", nil)
+	s.pushBlocked(d, "a1", "b1", "a2", "a3")
+	s.Equal([]string{"a3", "b1"}, s.finish(d))
+}
+
+func (s *dispatchTestSuite) TestBlock() {
+	d := newDispatcher(&common.DispatchPolicy{QueueSize: 1}, "stream", nil)
+	s.pushBlocked(d, "A")
+	pushedC := make(chan struct{})
+	go func() {
+		d.push("", []byte("B"), s.handler)
+		close(pushedC)
+	}()
+	select {
+	case <-pushedC:
+		s.FailNow("push not blocked by the full queue")
+	case <-time.After(20 * time.Millisecond):
+	}
+	// the handler makes room for the blocked push
+	close(s.unblockC)
+	<-pushedC
+	d.close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Equal([]string{`{"s":"FIRST"}`, "A", "B"}, s.handled)
+}
+
+func (s *dispatchTestSuite) TestServe() {
+	srv := binancetest.NewServer()
+	defer srv.Close()
+	metrics := common.NewDispatchMetrics()
+	cfg := &Config{
+		Endpoint: srv.WsURL(binancetest.Spot) + "/" + tradeStream,
+		Logger:   common.NewNopLogger(),
+		Dispatch: &common.DispatchPolicy{QueueSize: 10, Overflow: common.OverflowDropNewest, Metrics: metrics},
+	}
+	doneC, stopC, err := Serve(cfg, s.handler, func(err error) {})
+	s.Require().NoError(err)
+	s.Require().Eventually(func() bool {
+		return srv.Subscribers(binancetest.Spot, tradeStream) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	// the reads go on while the handler is blocked
+	for i := 0; i < 20; i++ {
+		_, err := srv.Publish(binancetest.Spot, tradeStream, map[string]int{"t": i})
+		s.Require().NoError(err)
+	}
+	s.Require().Eventually(func() bool {
+		stats, _ := metrics.Stream(tradeStream)
+		return stats.Received == 20
+	}, time.Second, 5*time.Millisecond)
+	// 10 messages are queued, and the first one may be held by the handler
+	stats, _ := metrics.Stream(tradeStream)
+	s.True(stats.Dropped == 9 || stats.Dropped == 10, fmt.Sprintf("%+v", stats))
+
+	// the queued messages are handled before doneC is closed
+	close(stopC)
+	close(s.unblockC)
+	<-doneC
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Len(s.handled, 20-int(stats.Dropped))
+}
+
+func (s *dispatchTestSuite) TestSessionStreams() {
+	srv := binancetest.NewServer()
+	defer srv.Close()
+	metrics := common.NewDispatchMetrics()
+	cfg := &Config{
+		Endpoint: strings.TrimSuffix(srv.CombinedURL(binancetest.Spot), "?streams="),
+		Logger:   common.NewNopLogger(),
+		Dispatch: &common.DispatchPolicy{QueueSize: 10, Overflow: common.OverflowDropNewest, Metrics: metrics},
+		Clock:    new(ReceiveClock),
+	}
+	session, err := NewSession(cfg, func(message []byte) {}, func(err error) {})
+	s.Require().NoError(err)
+	defer session.Close()
+	const otherStream = "ethusdt@trade"
+	otherC := make(chan []byte, 10)
+	s.Require().NoError(session.Subscribe(context.Background(), []string{tradeStream}, s.handler))
+	s.Require().NoError(session.Subscribe(context.Background(), []string{otherStream}, func(data []byte) {
+		otherC <- data
+	}))
+
+	for i := 0; i < 3; i++ {
+		_, err := srv.Publish(binancetest.Spot, tradeStream, map[string]int{"t": i})
+		s.Require().NoError(err)
+	}
+	_, err = srv.Publish(binancetest.Spot, otherStream, map[string]int{"t": 0})
+	s.Require().NoError(err)
+
+	// the other stream is handled while the handler of the first one is blocked
+	select {
+	case <-otherC:
+	case <-time.After(time.Second):
+		s.Fail("other stream not handled")
+	}
+	s.False(cfg.Clock.Stream(otherStream).Time().IsZero())
+	stats, ok := metrics.Stream(otherStream)
+	s.True(ok)
+	s.Equal(int64(1), stats.Received)
+	s.Require().Eventually(func() bool {
+		stats, _ := metrics.Stream(tradeStream)
+		return stats.Received == 3
+	}, time.Second, 5*time.Millisecond)
+	_, ok = metrics.Stream("")
+	s.False(ok)
+	close(s.unblockC)
+}
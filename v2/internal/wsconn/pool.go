@@ -0,0 +1,326 @@
+package wsconn
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/common"
+)
+
+// Pool spreads streams over as many sessions as needed to respect the
+// limits of Binance. The sessions are opened as streams are subscribed, and
+// closed once they have no stream left. The streams of a session which stops,
+// e.g. without a reconnect policy or once it gave up reconnecting, are
+// subscribed again on the other sessions.
+type Pool struct {
+	cfg        *Config
+	limits     common.StreamPoolLimits
+	handler    func(stream string, data []byte)
+	unrouted   func(message []byte)
+	errHandler func(err error)
+	ctx        context.Context
+	cancel     context.CancelFunc
+
+	// subMu serialises the subscriptions
+	subMu sync.Mutex
+
+	mu       sync.Mutex
+	sessions []*poolSession
+	streams  map[string]*poolSession
+}
+
+// poolSession is a session of a pool along with its streams
+type poolSession struct {
+	session *Session
+	streams map[string]bool
+	// closing is true once closed by the pool
+	closing bool
+}
+
+// NewPool init a pool of sessions to the combined stream endpoint of cfg,
+// whose events are passed to handler along with their stream name. The pool
+// stops once closed or the context of cfg is done.
+func NewPool(cfg *Config, limits common.StreamPoolLimits, handler func(stream string, data []byte), unrouted func(message []byte), errHandler func(err error)) *Pool {
+	if limits.MaxStreams <= 0 {
+		limits.MaxStreams = math.MaxInt32
+	}
+	ctx, cancel := context.WithCancel(cfg.context())
+	sessionCfg := *cfg
+	sessionCfg.Context = ctx
+	return &Pool{
+		cfg:        &sessionCfg,
+		limits:     limits,
+		handler:    handler,
+		unrouted:   unrouted,
+		errHandler: errHandler,
+		ctx:        ctx,
+		cancel:     cancel,
+		streams:    make(map[string]*poolSession),
+	}
+}
+
+// Subscribe subscribes to the streams, on the sessions with room left first,
+// then on new sessions. The streams already subscribed are ignored.
+func (p *Pool) Subscribe(ctx context.Context, streams []string) error {
+	p.subMu.Lock()
+	defer p.subMu.Unlock()
+	if p.ctx.Err() != nil {
+		return ErrSessionClosed
+	}
+
+	p.mu.Lock()
+	pending := make([]string, 0, len(streams))
+	seen := make(map[string]bool, len(streams))
+	for _, stream := range streams {
+		if p.streams[stream] == nil && !seen[stream] {
+			seen[stream] = true
+			pending = append(pending, stream)
+		}
+	}
+	p.mu.Unlock()
+
+	for len(pending) > 0 {
+		ps, room, err := p.available()
+		if err != nil {
+			return err
+		}
+		if room > len(pending) {
+			room = len(pending)
+		}
+		batch := pending[:room]
+		handlers := make(map[string]func(data []byte), len(batch))
+		for _, stream := range batch {
+			stream := stream
+			handlers[stream] = func(data []byte) {
+				p.handler(stream, data)
+			}
+		}
+		if err := ps.session.subscribe(ctx, batch, handlers); err != nil {
+			p.closeIfEmpty(ps)
+			return err
+		}
+		p.mu.Lock()
+		for _, stream := range batch {
+			ps.streams[stream] = true
+			p.streams[stream] = ps
+		}
+		p.mu.Unlock()
+		pending = pending[room:]
+	}
+	return nil
+}
+
+// available returns the first session with room left, along with the number
+// of streams it can subscribe, or a new session
+func (p *Pool) available() (*poolSession, int, error) {
+	p.mu.Lock()
+	for _, ps := range p.sessions {
+		if room := p.limits.MaxStreams - len(ps.streams); room > 0 && !ps.closing {
+			p.mu.Unlock()
+			return ps, room, nil
+		}
+	}
+	p.mu.Unlock()
+
+	session, err := newSession(p.cfg, p.unrouted, p.errHandler, newLimiter(p.limits.MessageRate))
+	if err != nil {
+		return nil, 0, err
+	}
+	ps := &poolSession{session: session, streams: make(map[string]bool)}
+	p.mu.Lock()
+	p.sessions = append(p.sessions, ps)
+	p.mu.Unlock()
+	go p.watch(ps)
+	return ps, p.limits.MaxStreams, nil
+}
+
+// Unsubscribe unsubscribes from the streams, the sessions left without
+// streams are closed
+func (p *Pool) Unsubscribe(ctx context.Context, streams []string) error {
+	p.subMu.Lock()
+	defer p.subMu.Unlock()
+
+	p.mu.Lock()
+	bySession := make(map[*poolSession][]string)
+	var order []*poolSession
+	for _, stream := range streams {
+		ps := p.streams[stream]
+		if ps == nil {
+			continue
+		}
+		if bySession[ps] == nil {
+			order = append(order, ps)
+		}
+		bySession[ps] = append(bySession[ps], stream)
+	}
+	p.mu.Unlock()
+
+	for _, ps := range order {
+		batch := bySession[ps]
+		if err := ps.session.Unsubscribe(ctx, batch); err != nil {
+			return err
+		}
+		p.mu.Lock()
+		for _, stream := range batch {
+			delete(ps.streams, stream)
+			delete(p.streams, stream)
+		}
+		p.mu.Unlock()
+		p.closeIfEmpty(ps)
+	}
+	return nil
+}
+
+// closeIfEmpty closes ps once it has no stream left
+func (p *Pool) closeIfEmpty(ps *poolSession) {
+	p.mu.Lock()
+	if len(ps.streams) > 0 {
+		p.mu.Unlock()
+		return
+	}
+	ps.closing = true
+	p.removeLocked(ps)
+	p.mu.Unlock()
+	ps.session.Close()
+}
+
+func (p *Pool) removeLocked(ps *poolSession) {
+	for i, s := range p.sessions {
+		if s == ps {
+			p.sessions = append(p.sessions[:i], p.sessions[i+1:]...)
+			return
+		}
+	}
+}
+
+// watch subscribes the streams of ps again on the other sessions once it
+// stopped, unless closed by the pool
+func (p *Pool) watch(ps *poolSession) {
+	<-ps.session.Done()
+	p.mu.Lock()
+	if ps.closing || p.ctx.Err() != nil {
+		p.mu.Unlock()
+		return
+	}
+	p.removeLocked(ps)
+	streams := make([]string, 0, len(ps.streams))
+	for stream := range ps.streams {
+		streams = append(streams, stream)
+		delete(p.streams, stream)
+	}
+	p.mu.Unlock()
+	sort.Strings(streams)
+	p.cfg.logger().Info("websocket pool rebalancing", "streams", len(streams))
+	p.rebalance(streams)
+}
+
+// rebalance subscribes the streams of a stopped session again, until
+// subscribed or the pool is closed
+func (p *Pool) rebalance(streams []string) {
+	policy := p.cfg.Reconnect
+	if policy == nil {
+		policy = common.NewReconnectPolicy()
+	}
+	for attempt := 1; ; attempt++ {
+		err := p.Subscribe(p.ctx, streams)
+		if err == nil || p.ctx.Err() != nil {
+			return
+		}
+		p.errHandler(fmt.Errorf("resubscribing %d streams: %w", len(streams), err))
+		timer := time.NewTimer(policy.Backoff(attempt))
+		select {
+		case <-p.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// Streams returns the subscribed streams, sorted
+func (p *Pool) Streams() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	streams := make([]string, 0, len(p.streams))
+	for stream := range p.streams {
+		streams = append(streams, stream)
+	}
+	sort.Strings(streams)
+	return streams
+}
+
+// Connections returns the number of sessions of the pool
+func (p *Pool) Connections() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.sessions)
+}
+
+// Close closes the sessions of the pool
+func (p *Pool) Close() {
+	p.cancel()
+	p.mu.Lock()
+	sessions := p.sessions
+	p.sessions = nil
+	for _, ps := range sessions {
+		ps.closing = true
+	}
+	p.mu.Unlock()
+	for _, ps := range sessions {
+		ps.session.Close()
+	}
+}
+
+// Done returns a channel closed once the pool is closed
+func (p *Pool) Done() <-chan struct{} {
+	return p.ctx.Done()
+}
+
+// limiter spaces events to respect a rate per second
+type limiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// limiterMargin widens the second of a limiter, as the events may reach
+// Binance closer to each other than they were sent
+const limiterMargin = 100 * time.Millisecond
+
+// newLimiter returns a limiter of rate events per second, nil when rate is not positive
+func newLimiter(rate int) *limiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &limiter{interval: (time.Second + limiterMargin) / time.Duration(rate)}
+}
+
+// wait waits until the next event is allowed, or ctx is done
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
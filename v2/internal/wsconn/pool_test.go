@@ -0,0 +1,180 @@
+package wsconn
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type poolTestSuite struct {
+	suite.Suite
+	srv *binancetest.Server
+
+	mu     sync.Mutex
+	events map[string]int
+	errs   []error
+}
+
+func TestPool(t *testing.T) {
+	suite.Run(t, new(poolTestSuite))
+}
+
+func (s *poolTestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.srv.MaxStreams = 3
+	s.srv.MessageRate = 2
+	s.events = make(map[string]int)
+	s.errs = nil
+}
+
+func (s *poolTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *poolTestSuite) newPool(p *common.ReconnectPolicy) *Pool {
+	cfg := &Config{
+		Endpoint:  strings.TrimSuffix(s.srv.CombinedURL(binancetest.Spot), "?streams="),
+		Reconnect: p,
+		Logger:    common.NewNopLogger(),
+	}
+	return NewPool(cfg, common.StreamPoolLimits{MaxStreams: 3, MessageRate: 2}, func(stream string, data []byte) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.events[stream]++
+	}, func(message []byte) {
+		s.Failf("unrouted message", "%s", message)
+	}, func(err error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.errs = append(s.errs, err)
+	})
+}
+
+func streamNames(n int) []string {
+	streams := make([]string, n)
+	for i := range streams {
+		streams[i] = fmt.Sprintf("sym%d@trade", i)
+	}
+	return streams
+}
+
+// receiveAll publishes an event to every stream and waits until received
+func (s *poolTestSuite) receiveAll(streams []string) {
+	s.mu.Lock()
+	want := make(map[string]int, len(streams))
+	for _, stream := range streams {
+		want[stream] = s.events[stream] + 1
+	}
+	s.mu.Unlock()
+	for _, stream := range streams {
+		s.Require().Eventually(func() bool {
+			return s.srv.Subscribers(binancetest.Spot, stream) == 1
+		}, time.Second, 5*time.Millisecond)
+		_, err := s.srv.Publish(binancetest.Spot, stream, map[string]string{"e": "trade"})
+		s.Require().NoError(err)
+	}
+	s.Require().Eventually(func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for stream, n := range want {
+			if s.events[stream] != n {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (s *poolTestSuite) TestSubscribe() {
+	pool := s.newPool(nil)
+	defer pool.Close()
+	ctx := context.Background()
+
+	streams := streamNames(7)
+	s.Require().NoError(pool.Subscribe(ctx, streams))
+	s.Equal(3, pool.Connections())
+	s.Len(pool.Streams(), 7)
+	s.receiveAll(streams)
+
+	// already subscribed streams are ignored
+	s.Require().NoError(pool.Subscribe(ctx, streams[:2]))
+	s.Equal(3, pool.Connections())
+
+	// the connection of the last stream is closed once unsubscribed
+	s.Require().NoError(pool.Unsubscribe(ctx, []string{streams[6]}))
+	s.Equal(2, pool.Connections())
+	s.Equal(0, s.srv.Subscribers(binancetest.Spot, streams[6]))
+
+	// the room left is used first
+	s.Require().NoError(pool.Unsubscribe(ctx, []string{streams[0]}))
+	s.Require().NoError(pool.Subscribe(ctx, []string{"new@trade"}))
+	s.Equal(2, pool.Connections())
+	s.receiveAll([]string{"new@trade"})
+}
+
+func (s *poolTestSuite) TestMessageRate() {
+	pool := s.newPool(nil)
+	defer pool.Close()
+	ctx := context.Background()
+
+	// the server closes the connections receiving more than 2 requests per second
+	streams := streamNames(3)
+	for _, stream := range streams {
+		s.Require().NoError(pool.Subscribe(ctx, []string{stream}))
+	}
+	s.Require().NoError(pool.Unsubscribe(ctx, []string{streams[0]}))
+	s.Equal(1, pool.Connections())
+	s.receiveAll(streams[1:])
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Empty(s.errs)
+}
+
+func (s *poolTestSuite) TestRebalance() {
+	pool := s.newPool(nil)
+	defer pool.Close()
+	ctx := context.Background()
+
+	streams := streamNames(5)
+	s.Require().NoError(pool.Subscribe(ctx, streams))
+	s.Equal(2, pool.Connections())
+
+	// the connections stop without a reconnect policy, their streams are
+	// subscribed again on new connections
+	s.srv.DisconnectStreams()
+	s.Require().Eventually(func() bool {
+		for _, stream := range streams {
+			if s.srv.Subscribers(binancetest.Spot, stream) != 1 {
+				return false
+			}
+		}
+		return true
+	}, 2*time.Second, 5*time.Millisecond)
+	s.Equal(2, pool.Connections())
+	s.Equal(streams, pool.Streams())
+	s.receiveAll(streams)
+}
+
+func (s *poolTestSuite) TestClose() {
+	pool := s.newPool(common.NewReconnectPolicy())
+	ctx := context.Background()
+	s.Require().NoError(pool.Subscribe(ctx, streamNames(4)))
+	pool.Close()
+	select {
+	case <-pool.Done():
+	case <-time.After(time.Second):
+		s.FailNow("pool not closed")
+	}
+	s.Equal(0, pool.Connections())
+	s.Require().Eventually(func() bool {
+		return s.srv.Subscribers(binancetest.Spot, "sym0@trade") == 0
+	}, time.Second, 5*time.Millisecond)
+	s.Equal(ErrSessionClosed, pool.Subscribe(ctx, []string{"new@trade"}))
+}
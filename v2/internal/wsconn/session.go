@@ -0,0 +1,295 @@
+package wsconn
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pooyakn/go-binance/v2/common"
+)
+
+// ErrSessionClosed is returned by the requests of a closed session
+var ErrSessionClosed = errors.New("websocket session closed")
+
+// ErrConnectionClosed is returned by the requests pending when their
+// connection is lost, their outcome is unknown
+var ErrConnectionClosed = errors.New("websocket connection closed")
+
+// Session is a connection to the combined stream endpoint, whose streams are
+// subscribed and unsubscribed with requests while connected. The events are
+// routed to the handler of their stream, using the stream name of the
+// combined payloads.
+type Session struct {
+	stream   *stream
+	cfg      *Config
+	unrouted func(message []byte)
+	// limiter spaces the requests, nil when unlimited
+	limiter *limiter
+	// dispatchers queue the events of every stream, nil without dispatch policy
+	dispatchers *dispatchers
+
+	writeMu sync.Mutex
+
+	mu       sync.Mutex
+	nextID   int64
+	pending  map[int64]chan *sessionMessage
+	handlers map[string]func(data []byte)
+}
+
+// sessionMessage is either a response to a request or a combined stream event
+type sessionMessage struct {
+	ID     *int64           `json:"id"`
+	Result json.RawMessage  `json:"result"`
+	Error  *common.APIError `json:"error"`
+	Stream string           `json:"stream"`
+	Data   json.RawMessage  `json:"data"`
+}
+
+// NewSession connects to the combined stream endpoint of cfg, e.g.
+// wss://stream.binance.com:9443/stream. The messages which can not be routed
+// to a stream, e.g. once the combined property is disabled, are passed to
+// unrouted. With a reconnect policy, the streams are subscribed again on the
+// new connections.
+func NewSession(cfg *Config, unrouted func(message []byte), errHandler func(err error)) (*Session, error) {
+	return newSession(cfg, unrouted, errHandler, nil)
+}
+
+func newSession(cfg *Config, unrouted func(message []byte), errHandler func(err error), limiter *limiter) (*Session, error) {
+	s := &Session{
+		unrouted:    unrouted,
+		limiter:     limiter,
+		cfg:         cfg,
+		dispatchers: newDispatchers(cfg.Dispatch, cfg.Clock.Stream),
+		pending:     make(map[int64]chan *sessionMessage),
+		handlers:    make(map[string]func(data []byte)),
+	}
+	st, err := serve(cfg, s.handle, errHandler, s.resubscribe, s.dispatchers)
+	if err != nil {
+		return nil, err
+	}
+	s.stream = st
+	return s, nil
+}
+
+func (s *Session) handle(message []byte) {
+	received := time.Now()
+	m := new(sessionMessage)
+	if err := json.Unmarshal(message, m); err != nil {
+		s.unrouted(message)
+		return
+	}
+	s.mu.Lock()
+	var handler func(data []byte)
+	var pending chan *sessionMessage
+	switch {
+	case m.Stream != "" && m.Data != nil:
+		handler = s.handlers[m.Stream]
+	case m.ID != nil:
+		pending = s.pending[*m.ID]
+		delete(s.pending, *m.ID)
+	}
+	s.mu.Unlock()
+	if handler != nil {
+		s.cfg.record(m.Stream, m.Data, received)
+	}
+	switch {
+	case handler != nil && s.dispatchers != nil:
+		s.dispatchers.push(m.Stream, m.Data, handler)
+	case handler != nil:
+		s.cfg.Clock.Stream(m.Stream).set(received)
+		handler(m.Data)
+	case pending != nil:
+		pending <- m
+	case m.Stream == "" && m.ID == nil:
+		s.unrouted(message)
+	}
+}
+
+// resubscribe subscribes the streams of the session on a new connection. The
+// response is not awaited as the connection is not read yet.
+func (s *Session) resubscribe(c *websocket.Conn) error {
+	s.mu.Lock()
+	streams := make([]string, 0, len(s.handlers))
+	for stream := range s.handlers {
+		streams = append(streams, stream)
+	}
+	s.nextID++
+	id := s.nextID
+	s.mu.Unlock()
+	if len(streams) == 0 {
+		return nil
+	}
+	return c.WriteJSON(request{Method: "SUBSCRIBE", Params: stringParams(streams), ID: id})
+}
+
+type request struct {
+	Method string        `json:"method"`
+	Params []interface{} `json:"params,omitempty"`
+	ID     int64         `json:"id"`
+}
+
+func stringParams(values []string) []interface{} {
+	params := make([]interface{}, len(values))
+	for i, v := range values {
+		params[i] = v
+	}
+	return params
+}
+
+// Request sends a request on the connection and waits for its response,
+// returning its result
+func (s *Session) Request(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	select {
+	case <-s.stream.doneC:
+		return nil, ErrSessionClosed
+	default:
+	}
+	if s.limiter != nil {
+		if err := s.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	resC := make(chan *sessionMessage, 1)
+	s.mu.Lock()
+	s.nextID++
+	id := s.nextID
+	s.pending[id] = resC
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, id)
+		s.mu.Unlock()
+	}()
+
+	req := request{Method: method, Params: params, ID: id}
+	if data, err := json.Marshal(req); err == nil {
+		s.cfg.logger().Debug("websocket session request", "request", common.RedactBody(string(data)))
+	}
+	c := s.stream.current()
+	s.writeMu.Lock()
+	err := c.WriteJSON(req)
+	s.writeMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	res, err := waitResponse(ctx, resC, s.stream, c)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return res.Result, nil
+}
+
+// waitResponse waits for the response to a request sent on c, a connection
+// of st. It fails once c is no longer read or st is stopped.
+func waitResponse[T any](ctx context.Context, resC <-chan T, st *stream, c *conn) (T, error) {
+	var zero T
+	select {
+	case res := <-resC:
+		return res, nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case <-c.doneC:
+	case <-st.doneC:
+	}
+	// the response may have been read just before the connection was lost
+	select {
+	case res := <-resC:
+		return res, nil
+	default:
+	}
+	select {
+	case <-st.doneC:
+		return zero, ErrSessionClosed
+	case <-st.ctx.Done():
+		// stopped, the connection was closed by the stream
+		return zero, ErrSessionClosed
+	default:
+		return zero, ErrConnectionClosed
+	}
+}
+
+// Subscribe subscribes to the streams, whose event payloads are passed to handler
+func (s *Session) Subscribe(ctx context.Context, streams []string, handler func(data []byte)) error {
+	handlers := make(map[string]func(data []byte), len(streams))
+	for _, stream := range streams {
+		handlers[stream] = handler
+	}
+	return s.subscribe(ctx, streams, handlers)
+}
+
+// subscribe subscribes to the streams, whose event payloads are passed to
+// their handler
+func (s *Session) subscribe(ctx context.Context, streams []string, handlers map[string]func(data []byte)) error {
+	s.mu.Lock()
+	previous := make(map[string]func(data []byte), len(streams))
+	for _, stream := range streams {
+		previous[stream] = s.handlers[stream]
+		s.handlers[stream] = handlers[stream]
+	}
+	s.mu.Unlock()
+	_, err := s.Request(ctx, "SUBSCRIBE", stringParams(streams)...)
+	if err != nil {
+		s.mu.Lock()
+		for stream, h := range previous {
+			if h == nil {
+				delete(s.handlers, stream)
+			} else {
+				s.handlers[stream] = h
+			}
+		}
+		s.mu.Unlock()
+	}
+	return err
+}
+
+// Unsubscribe unsubscribes from the streams
+func (s *Session) Unsubscribe(ctx context.Context, streams []string) error {
+	if _, err := s.Request(ctx, "UNSUBSCRIBE", stringParams(streams)...); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	for _, stream := range streams {
+		delete(s.handlers, stream)
+	}
+	s.mu.Unlock()
+	for _, stream := range streams {
+		s.dispatchers.remove(stream)
+		s.cfg.Clock.remove(stream)
+	}
+	return nil
+}
+
+// ListSubscriptions returns the streams subscribed on the connection
+func (s *Session) ListSubscriptions(ctx context.Context) ([]string, error) {
+	data, err := s.Request(ctx, "LIST_SUBSCRIPTIONS")
+	if err != nil {
+		return nil, err
+	}
+	var streams []string
+	if err := json.Unmarshal(data, &streams); err != nil {
+		return nil, err
+	}
+	return streams, nil
+}
+
+// SetProperty sets a property of the connection, e.g. "combined"
+func (s *Session) SetProperty(ctx context.Context, name string, value interface{}) error {
+	_, err := s.Request(ctx, "SET_PROPERTY", name, value)
+	return err
+}
+
+// Close closes the connection
+func (s *Session) Close() {
+	s.stream.stop()
+}
+
+// Done returns a channel closed once the session is closed, or gave up reconnecting
+func (s *Session) Done() <-chan struct{} {
+	return s.stream.doneC
+}
@@ -0,0 +1,129 @@
+package wsconn
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ReceiveClock holds the receive time of the message being handled by a
+// stream, so that the handler decoding it can stamp the events. The messages
+// of a stream are handled one at a time, from the read goroutine or from the
+// dispatcher. The streams of a session are dispatched concurrently, each of
+// them sets its own clock, returned by Stream.
+type ReceiveClock struct {
+	nanos int64
+
+	mu      sync.Mutex
+	streams map[string]*ReceiveClock
+}
+
+// Stream returns the clock of a stream of a session, nil when c is nil
+func (c *ReceiveClock) Stream(name string) *ReceiveClock {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clock, ok := c.streams[name]
+	if !ok {
+		if c.streams == nil {
+			c.streams = make(map[string]*ReceiveClock)
+		}
+		clock = new(ReceiveClock)
+		c.streams[name] = clock
+	}
+	return clock
+}
+
+// remove forgets the clock of an unsubscribed stream
+func (c *ReceiveClock) remove(name string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.streams, name)
+}
+
+func (c *ReceiveClock) set(t time.Time) {
+	if c != nil {
+		atomic.StoreInt64(&c.nanos, t.UnixNano())
+	}
+}
+
+// Time returns the receive time of the message being handled, zero before
+// the first message
+func (c *ReceiveClock) Time() time.Time {
+	nanos := atomic.LoadInt64(&c.nanos)
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// telemetryMessage holds the fields of a message giving its stream and event time
+type telemetryMessage struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+	timedEvent
+}
+
+// timedEvent holds the event time of an event, the event type is declared to
+// avoid decoding it into the event time as the keys are matched case
+// insensitively
+type timedEvent struct {
+	EventType json.RawMessage `json:"e"`
+	EventTime int64           `json:"E"`
+}
+
+// eventTime returns the event time of the payload, the one of its first
+// event for the arrays of the all market streams, zero when it has none
+func eventTime(payload []byte) time.Time {
+	var ms int64
+	if len(payload) > 0 && payload[0] == '[' {
+		var events []timedEvent
+		if json.Unmarshal(payload, &events) != nil || len(events) == 0 {
+			return time.Time{}
+		}
+		ms = events[0].EventTime
+	} else {
+		var event timedEvent
+		if json.Unmarshal(payload, &event) != nil {
+			return time.Time{}
+		}
+		ms = event.EventTime
+	}
+	return millis(ms)
+}
+
+// record records the message received at received in the telemetry of cfg,
+// the message of a combined stream is recorded under its stream name, the
+// other ones under name
+func (cfg *Config) record(name string, message []byte, received time.Time) {
+	if cfg.Telemetry == nil {
+		return
+	}
+	if len(message) == 0 || message[0] != '{' {
+		cfg.Telemetry.Record(name, received, eventTime(message))
+		return
+	}
+	var m telemetryMessage
+	if json.Unmarshal(message, &m) != nil {
+		cfg.Telemetry.Record(name, received, time.Time{})
+		return
+	}
+	if m.Stream != "" && m.Data != nil {
+		cfg.Telemetry.Record(m.Stream, received, eventTime(m.Data))
+		return
+	}
+	cfg.Telemetry.Record(name, received, millis(m.EventTime))
+}
+
+func millis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
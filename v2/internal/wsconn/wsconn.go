@@ -0,0 +1,421 @@
+// Package wsconn holds the websocket plumbing shared by every product
+// package: dialing, reading, keepalive and reconnection of the streams.
+package wsconn
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pooyakn/go-binance/v2/common"
+)
+
+// defaultReadLimit and defaultHandshakeTimeout apply unless set by the
+// options of the connection
+const (
+	defaultReadLimit        = 655350
+	defaultHandshakeTimeout = 45 * time.Second
+)
+
+// Config define the connection settings of a stream
+type Config struct {
+	Endpoint  string
+	TLSConfig *tls.Config
+	// Proxy returns the proxy URL of the connection, http.ProxyFromEnvironment is used when nil
+	Proxy func(*http.Request) (*url.URL, error)
+	// Keepalive enables sending ping messages every Timeout, the connection
+	// is closed when no pong is received within Timeout
+	Keepalive bool
+	Timeout   time.Duration
+	// Reconnect enables reconnecting the stream when set
+	Reconnect *common.ReconnectPolicy
+	Logger    common.Logger
+	// Context is used to dial the connections, the stream is stopped once it
+	// is done. context.Background() is used when nil.
+	Context context.Context
+	// Dispatch enables passing the messages to the handler from another
+	// goroutine, through a bounded queue, when set. The responses to the
+	// requests of the sessions and of the WebSocket API are not queued.
+	Dispatch *common.DispatchPolicy
+	// Options override the settings above, and the defaults of the dialer, when set
+	Options *common.WsConnOptions
+	// Telemetry records the receive time and the latency of the messages when set
+	Telemetry *common.StreamTelemetry
+	// Clock is set to the receive time of every message before it is handled, when set
+	Clock *ReceiveClock
+}
+
+func (cfg *Config) context() context.Context {
+	if cfg.Context == nil {
+		return context.Background()
+	}
+	return cfg.Context
+}
+
+// logger returns the logger of cfg, discarding every entry when nil
+func (cfg *Config) logger() common.Logger {
+	if cfg.Logger == nil {
+		return common.NewNopLogger()
+	}
+	return cfg.Logger
+}
+
+// Dial opens a connection to the endpoint of cfg, with the context of cfg
+func Dial(cfg *Config) (*websocket.Conn, error) {
+	return DialContext(cfg.context(), cfg)
+}
+
+// DialContext opens a connection to the endpoint of cfg, the dial and the
+// handshake are cancelled once ctx is done
+func DialContext(ctx context.Context, cfg *Config) (*websocket.Conn, error) {
+	o := cfg.Options
+	if o == nil {
+		o = &common.WsConnOptions{}
+	}
+	proxy := cfg.Proxy
+	if o.Proxy != nil {
+		proxy = o.Proxy
+	}
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+	tlsConfig := cfg.TLSConfig
+	if o.TLSConfig != nil {
+		tlsConfig = o.TLSConfig
+	}
+	handshakeTimeout := o.HandshakeTimeout
+	if handshakeTimeout <= 0 {
+		handshakeTimeout = defaultHandshakeTimeout
+	}
+	dialer := websocket.Dialer{
+		NetDialContext:    o.NetDialContext,
+		Proxy:             proxy,
+		HandshakeTimeout:  handshakeTimeout,
+		EnableCompression: o.EnableCompression,
+		TLSClientConfig:   tlsConfig,
+	}
+	cfg.logger().Debug("websocket connecting", "endpoint", common.RedactURL(cfg.Endpoint))
+	c, _, err := dialer.DialContext(ctx, cfg.Endpoint, nil)
+	if err != nil {
+		cfg.logger().Warn("websocket dial failed", "endpoint", common.RedactURL(cfg.Endpoint), "error", err)
+		return nil, err
+	}
+	switch {
+	case o.ReadLimit > 0:
+		c.SetReadLimit(o.ReadLimit)
+	case o.ReadLimit == 0:
+		c.SetReadLimit(defaultReadLimit)
+	}
+	switch {
+	case o.Keepalive > 0:
+		KeepAlive(c, o.Keepalive)
+	case o.Keepalive == 0 && cfg.Keepalive:
+		KeepAlive(c, cfg.Timeout)
+	}
+	return c, nil
+}
+
+// Serve connects to the endpoint of cfg and calls handler with every message
+// received, until stopC is closed by the caller or the context of cfg is
+// done. doneC is closed once the stream stopped.
+//
+// Without a reconnect policy, the stream stops on the first read error, which
+// is passed to errHandler. Otherwise the read errors are passed to errHandler
+// and the stream reconnects, until the policy gives up.
+//
+// With a dispatch policy, doneC is closed once the queued messages are handled.
+func Serve(cfg *Config, handler func(message []byte), errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+	name := streamName(cfg.Endpoint)
+	d := newDispatchers(cfg.Dispatch, func(string) *ReceiveClock { return cfg.Clock })
+	h := handler
+	handler = func(message []byte) {
+		received := time.Now()
+		cfg.record(name, message, received)
+		if d != nil {
+			d.push(name, message, h)
+			return
+		}
+		cfg.Clock.set(received)
+		h(message)
+	}
+	s, err := serve(cfg, handler, errHandler, nil, d)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.doneC, s.stopC, nil
+}
+
+// serve starts a stream, onDial is called with the new connections opened to
+// reconnect the stream, before reading them. The dispatchers d, if any, are
+// closed once the stream stopped.
+func serve(cfg *Config, handler func(message []byte), errHandler func(err error), onDial func(c *websocket.Conn) error, d *dispatchers) (*stream, error) {
+	c, err := Dial(cfg)
+	if err != nil {
+		d.close()
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(cfg.context())
+	s := &stream{
+		ctx:         ctx,
+		cancel:      cancel,
+		cfg:         cfg,
+		handler:     handler,
+		errHandler:  errHandler,
+		onDial:      onDial,
+		dispatchers: d,
+		conn:        newConn(c),
+		doneC:       make(chan struct{}),
+		stopC:       make(chan struct{}),
+	}
+	s.state(common.WsStateConnected, nil)
+	go s.waitStop()
+	go s.run()
+	return s, nil
+}
+
+// conn is a connection which can be closed by the stream, which then stops
+// reading silently
+type conn struct {
+	*websocket.Conn
+	closed chan struct{}
+	once   sync.Once
+	// doneC is closed once the connection is no longer read, the requests
+	// sent on it then get no response
+	doneC chan struct{}
+}
+
+func newConn(c *websocket.Conn) *conn {
+	return &conn{Conn: c, closed: make(chan struct{}), doneC: make(chan struct{})}
+}
+
+func (c *conn) close() {
+	c.once.Do(func() {
+		close(c.closed)
+		c.Conn.Close()
+	})
+}
+
+// read calls handler with the messages until the connection fails, or
+// returns nil once closed by close
+func (c *conn) read(handler func(message []byte)) error {
+	defer close(c.doneC)
+	for {
+		_, message, err := c.ReadMessage()
+		if err != nil {
+			select {
+			case <-c.closed:
+				return nil
+			default:
+				return err
+			}
+		}
+		handler(message)
+	}
+}
+
+type stream struct {
+	// ctx is cancelled once the stream stops, to abort its dials
+	ctx         context.Context
+	cancel      context.CancelFunc
+	cfg         *Config
+	handler     func(message []byte)
+	errHandler  func(err error)
+	onDial      func(c *websocket.Conn) error
+	dispatchers *dispatchers
+	doneC       chan struct{}
+	stopC       chan struct{}
+
+	stopOnce sync.Once
+
+	mu      sync.Mutex
+	conn    *conn
+	stopped bool
+}
+
+// stop stops the stream, like closing stopC
+func (s *stream) stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopC)
+	})
+}
+
+// waitStop closes the current connection once the caller closes stopC, or
+// the context of the stream is done
+func (s *stream) waitStop() {
+	select {
+	case <-s.stopC:
+	case <-s.ctx.Done():
+	case <-s.doneC:
+	}
+	s.cancel()
+	s.mu.Lock()
+	s.stopped = true
+	c := s.conn
+	s.mu.Unlock()
+	c.close()
+}
+
+// setConn replaces the current connection by c, and returns false when the
+// stream is stopped
+func (s *stream) setConn(c *conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		c.close()
+		return false
+	}
+	s.conn = c
+	return true
+}
+
+func (s *stream) current() *conn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn
+}
+
+func (s *stream) state(state common.WsState, err error) {
+	p := s.cfg.Reconnect
+	if p != nil && p.OnStateChange != nil {
+		p.OnStateChange(state, err)
+	}
+}
+
+func (s *stream) run() {
+	defer close(s.doneC)
+	defer s.dispatchers.close()
+	for {
+		c := s.current()
+		err := s.serveConn(c)
+		if err == nil {
+			if s.current() != c {
+				// replaced by a newer connection
+				continue
+			}
+			s.cfg.logger().Debug("websocket closed", "endpoint", common.RedactURL(s.cfg.Endpoint))
+			return
+		}
+		s.cfg.logger().Warn("websocket read failed", "endpoint", common.RedactURL(s.cfg.Endpoint), "error", err)
+		s.errHandler(err)
+		if s.cfg.Reconnect == nil || !s.reconnect(err) {
+			return
+		}
+	}
+}
+
+// serveConn reads the messages of c until it fails or is closed. The
+// connection is replaced by a new one once older than MaxConnectionAge.
+func (s *stream) serveConn(c *conn) error {
+	readC := make(chan error, 1)
+	go func() {
+		readC <- c.read(s.handler)
+	}()
+	var ageC <-chan time.Time
+	if p := s.cfg.Reconnect; p != nil && p.MaxConnectionAge > 0 {
+		t := time.NewTimer(p.MaxConnectionAge)
+		defer t.Stop()
+		ageC = t.C
+	}
+	for {
+		select {
+		case err := <-readC:
+			return err
+		case <-ageC:
+			ageC = nil
+			s.cfg.logger().Debug("websocket replacing aged connection", "endpoint", common.RedactURL(s.cfg.Endpoint))
+			nc, err := s.dial()
+			if err != nil {
+				// keep the current connection, reconnected once it drops
+				continue
+			}
+			if s.setConn(newConn(nc)) {
+				c.close()
+			}
+		}
+	}
+}
+
+// dial opens a new connection for the stream
+func (s *stream) dial() (*websocket.Conn, error) {
+	c, err := DialContext(s.ctx, s.cfg)
+	if err != nil || s.onDial == nil {
+		return c, err
+	}
+	if err := s.onDial(c); err != nil {
+		s.cfg.logger().Warn("websocket setup failed", "endpoint", common.RedactURL(s.cfg.Endpoint), "error", err)
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// reconnect connects the stream again after err, and returns false when the
+// stream is stopped or the policy gave up
+func (s *stream) reconnect(err error) bool {
+	p := s.cfg.Reconnect
+	disconnected := time.Now()
+	s.state(common.WsStateReconnecting, err)
+	for attempt := 1; p.MaxAttempts <= 0 || attempt <= p.MaxAttempts; attempt++ {
+		t := time.NewTimer(p.Backoff(attempt))
+		select {
+		case <-s.ctx.Done():
+			t.Stop()
+			return false
+		case <-t.C:
+		}
+		c, dialErr := s.dial()
+		if dialErr != nil {
+			err = dialErr
+			continue
+		}
+		if !s.setConn(newConn(c)) {
+			return false
+		}
+		s.state(common.WsStateConnected, nil)
+		if p.OnGap != nil {
+			p.OnGap(common.WsGap{Start: disconnected, End: time.Now()})
+		}
+		return true
+	}
+	s.cfg.logger().Warn("websocket reconnection gave up", "endpoint", common.RedactURL(s.cfg.Endpoint), "error", err)
+	s.state(common.WsStateGaveUp, err)
+	return false
+}
+
+// KeepAlive sends a ping message on c every timeout, and closes c when no pong
+// is received within timeout
+func KeepAlive(c *websocket.Conn, timeout time.Duration) {
+	ticker := time.NewTicker(timeout)
+
+	var mu sync.Mutex
+	lastResponse := time.Now()
+	c.SetPongHandler(func(msg string) error {
+		mu.Lock()
+		lastResponse = time.Now()
+		mu.Unlock()
+		return nil
+	})
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			deadline := time.Now().Add(10 * time.Second)
+			err := c.WriteControl(websocket.PingMessage, []byte{}, deadline)
+			if err != nil {
+				return
+			}
+			<-ticker.C
+			mu.Lock()
+			last := lastResponse
+			mu.Unlock()
+			if time.Since(last) > timeout {
+				c.Close()
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,440 @@
+package wsconn
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+const tradeStream = "btcusdt@trade"
+
+type wsconnTestSuite struct {
+	suite.Suite
+	srv *binancetest.Server
+
+	mu     sync.Mutex
+	states []common.WsState
+	gaps   []common.WsGap
+	errs   []error
+}
+
+func TestWsconn(t *testing.T) {
+	suite.Run(t, new(wsconnTestSuite))
+}
+
+func (s *wsconnTestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.states, s.gaps, s.errs = nil, nil, nil
+}
+
+func (s *wsconnTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *wsconnTestSuite) config(p *common.ReconnectPolicy) *Config {
+	if p != nil {
+		p.OnStateChange = func(state common.WsState, err error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.states = append(s.states, state)
+		}
+		p.OnGap = func(gap common.WsGap) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.gaps = append(s.gaps, gap)
+		}
+	}
+	return &Config{
+		Endpoint:  s.srv.WsURL(binancetest.Spot) + "/" + tradeStream,
+		Reconnect: p,
+		Logger:    common.NewNopLogger(),
+	}
+}
+
+func (s *wsconnTestSuite) serve(cfg *Config) (doneC, stopC chan struct{}, messages chan string) {
+	messages = make(chan string, 10)
+	doneC, stopC, err := Serve(cfg, func(message []byte) {
+		messages <- string(message)
+	}, func(err error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.errs = append(s.errs, err)
+	})
+	s.Require().NoError(err)
+	s.waitSubscribers(1)
+	return doneC, stopC, messages
+}
+
+func (s *wsconnTestSuite) waitSubscribers(n int) {
+	s.Require().Eventually(func() bool {
+		return s.srv.Subscribers(binancetest.Spot, tradeStream) == n
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (s *wsconnTestSuite) publish(id int) {
+	_, err := s.srv.Publish(binancetest.Spot, tradeStream, map[string]int{"t": id})
+	s.Require().NoError(err)
+}
+
+func (s *wsconnTestSuite) receive(messages chan string, id int) {
+	data, _ := json.Marshal(map[string]int{"t": id})
+	select {
+	case m := <-messages:
+		s.JSONEq(string(data), m)
+	case <-time.After(time.Second):
+		s.FailNow("timeout waiting for a message")
+	}
+}
+
+func (s *wsconnTestSuite) waitDone(doneC chan struct{}) {
+	select {
+	case <-doneC:
+	case <-time.After(time.Second):
+		s.FailNow("stream not stopped")
+	}
+}
+
+func (s *wsconnTestSuite) TestServe() {
+	doneC, _, messages := s.serve(s.config(nil))
+	s.publish(1)
+	s.receive(messages, 1)
+
+	s.srv.DisconnectStreams()
+	s.waitDone(doneC)
+	s.Len(s.errs, 1)
+}
+
+func (s *wsconnTestSuite) TestStop() {
+	doneC, stopC, _ := s.serve(s.config(common.NewReconnectPolicy()))
+	close(stopC)
+	s.waitDone(doneC)
+	s.Empty(s.errs)
+	s.Equal([]common.WsState{common.WsStateConnected}, s.states)
+}
+
+func (s *wsconnTestSuite) TestReconnect() {
+	p := common.NewReconnectPolicy()
+	p.InitialBackoff = 10 * time.Millisecond
+	doneC, stopC, messages := s.serve(s.config(p))
+	s.srv.DisconnectStreams()
+	s.waitSubscribers(0)
+	s.waitSubscribers(1)
+	s.publish(1)
+	s.receive(messages, 1)
+
+	close(stopC)
+	s.waitDone(doneC)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Len(s.errs, 1)
+	s.Equal([]common.WsState{common.WsStateConnected, common.WsStateReconnecting, common.WsStateConnected}, s.states)
+	s.Require().Len(s.gaps, 1)
+	s.True(s.gaps[0].End.After(s.gaps[0].Start))
+}
+
+func (s *wsconnTestSuite) TestGiveUp() {
+	p := common.NewReconnectPolicy()
+	p.InitialBackoff = time.Millisecond
+	p.MaxAttempts = 2
+	doneC, _, _ := s.serve(s.config(p))
+	s.srv.Listener.Close()
+	s.srv.DisconnectStreams()
+	s.waitDone(doneC)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Equal([]common.WsState{common.WsStateConnected, common.WsStateReconnecting, common.WsStateGaveUp}, s.states)
+	s.Empty(s.gaps)
+}
+
+func (s *wsconnTestSuite) TestMaxConnectionAge() {
+	p := common.NewReconnectPolicy()
+	p.MaxConnectionAge = 50 * time.Millisecond
+	doneC, stopC, messages := s.serve(s.config(p))
+	time.Sleep(120 * time.Millisecond)
+	s.waitSubscribers(1)
+	s.publish(1)
+	s.receive(messages, 1)
+
+	close(stopC)
+	s.waitDone(doneC)
+	s.Empty(s.errs)
+	s.Empty(s.gaps)
+}
+
+func (s *wsconnTestSuite) TestContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cfg := s.config(common.NewReconnectPolicy())
+	cfg.Context = ctx
+	doneC, _, _ := s.serve(cfg)
+	cancel()
+	s.waitDone(doneC)
+	s.Empty(s.errs)
+}
+
+func (s *wsconnTestSuite) TestContextWhileReconnecting() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cfg := s.config(common.NewReconnectPolicy())
+	cfg.Reconnect.InitialBackoff = time.Hour
+	cfg.Context = ctx
+	doneC, _, _ := s.serve(cfg)
+	s.srv.DisconnectStreams()
+	s.Require().Eventually(func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.states) == 2
+	}, time.Second, 5*time.Millisecond)
+	cancel()
+	s.waitDone(doneC)
+}
+
+func (s *wsconnTestSuite) TestDialContext() {
+	// a server which accepts the connections but never answers the handshake
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	defer l.Close()
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer c.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = DialContext(ctx, &Config{Endpoint: "ws://" + l.Addr().String(), Logger: common.NewNopLogger()})
+	s.Error(err)
+	s.Less(time.Since(start), time.Second)
+}
+
+func (s *wsconnTestSuite) TestDialWithoutLogger() {
+	_, err := Dial(&Config{Endpoint: "ws://127.0.0.1:1"})
+	s.Error(err)
+}
+
+// recordConn records the bytes written to a connection
+type recordConn struct {
+	net.Conn
+	mu      sync.Mutex
+	written []byte
+}
+
+func (c *recordConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	c.written = append(c.written, b...)
+	c.mu.Unlock()
+	return c.Conn.Write(b)
+}
+
+func (s *wsconnTestSuite) TestOptions() {
+	var rc *recordConn
+	cfg := s.config(nil)
+	cfg.Options = &common.WsConnOptions{
+		NetDialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			c, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+			rc = &recordConn{Conn: c}
+			return rc, nil
+		},
+		EnableCompression: true,
+	}
+	_, stopC, messages := s.serve(cfg)
+	defer close(stopC)
+	s.Require().NotNil(rc)
+	rc.mu.Lock()
+	s.Contains(string(rc.written), "permessage-deflate")
+	rc.mu.Unlock()
+	s.publish(1)
+	s.receive(messages, 1)
+}
+
+func (s *wsconnTestSuite) TestReadLimit() {
+	cfg := s.config(nil)
+	cfg.Options = &common.WsConnOptions{ReadLimit: 64}
+	doneC, _, messages := s.serve(cfg)
+	s.publish(1)
+	s.receive(messages, 1)
+
+	// a message larger than the read limit fails the connection
+	_, err := s.srv.Publish(binancetest.Spot, tradeStream, map[string]string{"t": strings.Repeat("x", 100)})
+	s.Require().NoError(err)
+	s.waitDone(doneC)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Require().Len(s.errs, 1)
+	s.ErrorIs(s.errs[0], websocket.ErrReadLimit)
+}
+
+func (s *wsconnTestSuite) TestProxy() {
+	// an HTTP proxy requiring credentials, tunnelling the CONNECT requests
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect || r.Header.Get("Proxy-Authorization") != "Basic "+base64.StdEncoding.EncodeToString([]byte("user:secret")) {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		c, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			upstream.Close()
+			return
+		}
+		go func() {
+			defer upstream.Close()
+			defer c.Close()
+			go io.Copy(upstream, c)
+			io.Copy(c, upstream)
+		}()
+	}))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	s.Require().NoError(err)
+
+	cfg := s.config(nil)
+	cfg.Proxy = func(*http.Request) (*url.URL, error) {
+		return nil, errors.New("proxy of the config used")
+	}
+	proxyURL.User = url.UserPassword("user", "wrong")
+	cfg.Options = &common.WsConnOptions{Proxy: http.ProxyURL(proxyURL)}
+	_, err = Dial(cfg)
+	s.Error(err)
+
+	proxyURL.User = url.UserPassword("user", "secret")
+	cfg.Options = &common.WsConnOptions{Proxy: http.ProxyURL(proxyURL)}
+	_, stopC, messages := s.serve(cfg)
+	defer close(stopC)
+	s.publish(1)
+	s.receive(messages, 1)
+}
+
+func (s *wsconnTestSuite) TestHandshakeTimeout() {
+	// a server which accepts the connections but never answers the handshake
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	defer l.Close()
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer c.Close()
+		}
+	}()
+
+	start := time.Now()
+	_, err = Dial(&Config{
+		Endpoint: "ws://" + l.Addr().String(),
+		Logger:   common.NewNopLogger(),
+		Options:  &common.WsConnOptions{HandshakeTimeout: 50 * time.Millisecond},
+	})
+	s.Error(err)
+	s.Less(time.Since(start), time.Second)
+}
+
+func (s *wsconnTestSuite) TestAPIConnectionLost() {
+	// a server dropping the connection once it receives a request
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		c.ReadMessage()
+	}))
+	defer srv.Close()
+
+	p := common.NewReconnectPolicy()
+	p.InitialBackoff = time.Minute
+	conn, err := DialAPI(&Config{
+		Endpoint:  "ws" + strings.TrimPrefix(srv.URL, "http"),
+		Reconnect: p,
+	}, func(err error) {})
+	s.Require().NoError(err)
+	defer conn.Close()
+
+	_, err = conn.Request(context.Background(), "ping", nil)
+	s.Equal(ErrConnectionClosed, err)
+	conn.Close()
+	<-conn.Done()
+	_, err = conn.Request(context.Background(), "ping", nil)
+	s.Equal(ErrSessionClosed, err)
+}
+
+func (s *wsconnTestSuite) TestTelemetry() {
+	telemetry := common.NewStreamTelemetry()
+	clock := new(ReceiveClock)
+	cfg := s.config(nil)
+	cfg.Telemetry = telemetry
+	cfg.Clock = clock
+	cfg.Dispatch = &common.DispatchPolicy{}
+	received := make(chan time.Time, 1)
+	doneC, stopC, err := Serve(cfg, func(message []byte) {
+		received <- clock.Time()
+	}, func(err error) {})
+	s.Require().NoError(err)
+	s.waitSubscribers(1)
+
+	eventTime := time.Now().Add(-time.Second).Truncate(time.Millisecond)
+	_, err = s.srv.Publish(binancetest.Spot, tradeStream, map[string]int64{"E": eventTime.UnixMilli()})
+	s.Require().NoError(err)
+	var at time.Time
+	select {
+	case at = <-received:
+	case <-time.After(time.Second):
+		s.FailNow("timeout waiting for a message")
+	}
+	close(stopC)
+	s.waitDone(doneC)
+
+	stats, ok := telemetry.Stream(tradeStream)
+	s.Require().True(ok)
+	s.Equal(int64(1), stats.Messages)
+	s.True(stats.LastEventTime.Equal(eventTime))
+	s.Equal(int64(1), stats.Latency.Count)
+	s.GreaterOrEqual(stats.Latency.Min, time.Second)
+	// the handler sees the time the message was read, not the time it was dequeued
+	s.WithinDuration(stats.LastReceived, at, 5*time.Millisecond)
+}
+
+func (s *wsconnTestSuite) TestCombinedTelemetry() {
+	telemetry := common.NewStreamTelemetry()
+	cfg := s.config(nil)
+	cfg.Endpoint = s.srv.CombinedURL(binancetest.Spot) + tradeStream + "/ethusdt@ticker"
+	cfg.Telemetry = telemetry
+	doneC, stopC, messages := s.serve(cfg)
+	_, err := s.srv.Publish(binancetest.Spot, "ethusdt@ticker", []map[string]int64{{"E": 1}})
+	s.Require().NoError(err)
+	<-messages
+	close(stopC)
+	s.waitDone(doneC)
+
+	s.Equal([]string{"ethusdt@ticker"}, telemetry.Streams())
+	stats, _ := telemetry.Stream("ethusdt@ticker")
+	s.True(stats.LastEventTime.Equal(time.UnixMilli(1)))
+}
@@ -58,29 +58,29 @@ func (s *InternalUniversalTransferService) ClientTranId(v string) *InternalUnive
 
 func (s *InternalUniversalTransferService) Do(ctx context.Context, opts ...RequestOption) (*InternalUniversalTransferResponse, error) {
 	r := &request{
-		method:   "POST",
-		endpoint: "/sapi/v1/sub-account/universalTransfer",
-		secType:  secTypeSigned,
+		Method:   "POST",
+		Endpoint: "/sapi/v1/sub-account/universalTransfer",
+		SecType:  secTypeSigned,
 	}
 	if v := s.fromEmail; v != nil {
-		r.setParam("fromEmail", *v)
+		r.SetParam("fromEmail", *v)
 	}
 	if v := s.toEmail; v != nil {
-		r.setParam("toEmail", *v)
+		r.SetParam("toEmail", *v)
 	}
-	r.setParam("asset", s.asset)
-	r.setParam("amount", s.amount)
+	r.SetParam("asset", s.asset)
+	r.SetParam("amount", s.amount)
 	if v := s.fromAccountType; v != nil {
-		r.setParam("fromAccountType", *v)
+		r.SetParam("fromAccountType", *v)
 	}
 	if v := s.toAccountType; v != nil {
-		r.setParam("toAccountType", *v)
+		r.SetParam("toAccountType", *v)
 	}
 	if v := s.clientTranId; v != nil {
-		r.setParam("clientTranId", *v)
+		r.SetParam("clientTranId", *v)
 	}
 	if v := s.symbol; v != nil {
-		r.setParam("symbol", *v)
+		r.SetParam("symbol", *v)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -150,30 +150,30 @@ func (s *InternalUniversalTransferHistoryService) ClientTranId(v string) *Intern
 
 func (s *InternalUniversalTransferHistoryService) Do(ctx context.Context, opts ...RequestOption) (res InternalUniversalTransferHistoryResponse, err error) {
 	r := &request{
-		method:   "GET",
-		endpoint: "/sapi/v1/sub-account/universalTransfer",
-		secType:  secTypeSigned,
+		Method:   "GET",
+		Endpoint: "/sapi/v1/sub-account/universalTransfer",
+		SecType:  secTypeSigned,
 	}
 	if v := s.fromEmail; v != nil {
-		r.setParam("fromEmail", *v)
+		r.SetParam("fromEmail", *v)
 	}
 	if v := s.toEmail; v != nil {
-		r.setParam("toEmail", *v)
+		r.SetParam("toEmail", *v)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.page != nil {
-		r.setParam("page", *s.page)
+		r.SetParam("page", *s.page)
 	}
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	if v := s.clientTranId; v != nil {
-		r.setParam("clientTranId", *v)
+		r.SetParam("clientTranId", *v)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -33,7 +33,7 @@ func (s *internalUniversalTransferServiceTestSuite) TestInternalUniversalTransfe
 	amount := 100.0
 	clientTranId := "testID"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"asset":           asset,
 			"amount":          amount,
 			"fromEmail":       fromEmail,
@@ -92,7 +92,7 @@ func (s *internalUniversalTransferServiceTestSuite) TestInternalUniversalTransfe
 	page := 1
 	limit := 10
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"fromEmail":    fromEmail,
 			"toEmail":      toEmail,
 			"startTime":    startTime,
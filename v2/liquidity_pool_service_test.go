@@ -22,7 +22,7 @@ func (s *liquidityPoolServiceTestSuite) TestAddLiquidityService() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"asset":    "BUSD",
 			"quantity": 1000,
 			"type":     "COMBINATION",
@@ -80,7 +80,7 @@ func (s *liquidityPoolServiceTestSuite) TestGetUserSwapRecordsService() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"quoteAsset": "BUSD",
 			"baseAsset":  "USDT",
 			"startTime":  1656726827025,
@@ -150,7 +150,7 @@ func (s *liquidityPoolServiceTestSuite) TestSwapService() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"quoteAsset": "USDT",
 			"baseAsset":  "BUSD",
 			"quoteQty":   1000,
@@ -191,7 +191,7 @@ func (s *liquidityPoolServiceTestSuite) TestGetSwapQuoteService() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"quoteAsset": "USDT",
 			"baseAsset":  "BUSD",
 			"quoteQty":   1000,
@@ -245,7 +245,7 @@ func (s *liquidityPoolServiceTestSuite) TestAddLiquidityPreviewService() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"poolId":     2,
 			"type":       LiquidityOperationTypeCombination,
 			"quoteAsset": "USDT",
@@ -312,7 +312,7 @@ func (s *liquidityPoolServiceTestSuite) TestGetLiquidityPoolDetail() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"poolId": 2,
 		})
 		s.assertRequestEqual(e, r)
@@ -452,7 +452,7 @@ func (s *liquidityPoolServiceTestSuite) TestClaimRewardService() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"type": 1,
 		})
 		s.assertRequestEqual(e, r)
@@ -488,7 +488,7 @@ func (s *liquidityPoolServiceTestSuite) TestQueryClaimedRewardHistoryService() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"type":   1,
 			"poolId": 189,
 		})
@@ -40,7 +40,7 @@ func (s *marginOrderServiceTestSuite) TestCreateOrder() {
 	price := "0.0001"
 	newClientOrderID := "myOrder1"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":           symbol,
 			"side":             side,
 			"type":             orderType,
@@ -111,7 +111,7 @@ func (s *marginOrderServiceTestSuite) TestCreateOrderFull() {
 	newClientOrderID := "myOrder1"
 	newOrderRespType := NewOrderRespTypeFULL
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":           symbol,
 			"side":             side,
 			"type":             orderType,
@@ -178,7 +178,7 @@ func (s *marginOrderServiceTestSuite) TestCancelOrder() {
 	origClientOrderID := "myOrder1"
 	newClientOrderID := "cancelMyOrder1"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":            symbol,
 			"orderId":           orderID,
 			"origClientOrderId": origClientOrderID,
@@ -236,7 +236,7 @@ func (s *marginOrderServiceTestSuite) TestGetOrder() {
 	orderID := int64(1)
 	origClientOrderID := "myOrder1"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":            symbol,
 			"orderId":           orderID,
 			"origClientOrderId": origClientOrderID,
@@ -295,7 +295,7 @@ func (s *marginOrderServiceTestSuite) TestListMarginOpenOrders() {
 	symbol := "BNBBTC"
 	recvWindow := int64(1000)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":     symbol,
 			"recvWindow": recvWindow,
 		})
@@ -361,7 +361,7 @@ func (s *marginOrderServiceTestSuite) TestListMarginOrders() {
 	startTime := int64(1556089977693)
 	endTime := int64(1556163963504)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":    symbol,
 			"startTime": startTime,
 			"endTime":   endTime,
@@ -507,7 +507,7 @@ func (s *marginOrderServiceTestSuite) TestCreateOCO() {
 	newOrderRespType := NewOrderRespTypeFULL
 	sideEffectType := SideEffectTypeMarginBuy
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":               symbol,
 			"isIsolated":           "TRUE",
 			"side":                 side,
@@ -704,7 +704,7 @@ func (s *marginOrderServiceTestSuite) TestCancelOCO() {
 	symbol := "LTCBTC"
 	listClientOrderID := "C3wyj4WVEktd7u9aVBRXcN"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":            symbol,
 			"listClientOrderId": listClientOrderID,
 		})
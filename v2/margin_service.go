@@ -35,16 +35,16 @@ func (s *MarginTransferService) Type(transferType MarginTransferType) *MarginTra
 // Do send request
 func (s *MarginTransferService) Do(ctx context.Context, opts ...RequestOption) (res *TransactionResponse, err error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: "/sapi/v1/margin/transfer",
-		secType:  secTypeSigned,
+		Method:   http.MethodPost,
+		Endpoint: "/sapi/v1/margin/transfer",
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"asset":  s.asset,
 		"amount": s.amount,
 		"type":   s.transferType,
 	}
-	r.setFormParams(m)
+	r.SetFormParams(m)
 	res = new(TransactionResponse)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -98,20 +98,20 @@ func (s *MarginLoanService) Symbol(symbol string) *MarginLoanService {
 // Do send request
 func (s *MarginLoanService) Do(ctx context.Context, opts ...RequestOption) (res *TransactionResponse, err error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: "/sapi/v1/margin/loan",
-		secType:  secTypeSigned,
+		Method:   http.MethodPost,
+		Endpoint: "/sapi/v1/margin/loan",
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"asset":  s.asset,
 		"amount": s.amount,
 	}
-	r.setFormParams(m)
+	r.SetFormParams(m)
 	if s.isIsolated {
-		r.setParam("isIsolated", "TRUE")
+		r.SetParam("isIsolated", "TRUE")
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	}
 
 	res = new(TransactionResponse)
@@ -162,20 +162,20 @@ func (s *MarginRepayService) Symbol(symbol string) *MarginRepayService {
 // Do send request
 func (s *MarginRepayService) Do(ctx context.Context, opts ...RequestOption) (res *TransactionResponse, err error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: "/sapi/v1/margin/repay",
-		secType:  secTypeSigned,
+		Method:   http.MethodPost,
+		Endpoint: "/sapi/v1/margin/repay",
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"asset":  s.asset,
 		"amount": s.amount,
 	}
-	r.setFormParams(m)
+	r.SetFormParams(m)
 	if s.isIsolated {
-		r.setParam("isIsolated", "TRUE")
+		r.SetParam("isIsolated", "TRUE")
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	}
 
 	res = new(TransactionResponse)
@@ -240,25 +240,25 @@ func (s *ListMarginLoansService) Size(size int64) *ListMarginLoansService {
 // Do send request
 func (s *ListMarginLoansService) Do(ctx context.Context, opts ...RequestOption) (res *MarginLoanResponse, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/margin/loan",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/margin/loan",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("asset", s.asset)
+	r.SetParam("asset", s.asset)
 	if s.txID != nil {
-		r.setParam("txId", *s.txID)
+		r.SetParam("txId", *s.txID)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.current != nil {
-		r.setParam("current", *s.current)
+		r.SetParam("current", *s.current)
 	}
 	if s.size != nil {
-		r.setParam("size", *s.size)
+		r.SetParam("size", *s.size)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -336,25 +336,25 @@ func (s *ListMarginRepaysService) Size(size int64) *ListMarginRepaysService {
 // Do send request
 func (s *ListMarginRepaysService) Do(ctx context.Context, opts ...RequestOption) (res *MarginRepayResponse, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/margin/repay",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/margin/repay",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("asset", s.asset)
+	r.SetParam("asset", s.asset)
 	if s.txID != nil {
-		r.setParam("txId", *s.txID)
+		r.SetParam("txId", *s.txID)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.current != nil {
-		r.setParam("current", *s.current)
+		r.SetParam("current", *s.current)
 	}
 	if s.size != nil {
-		r.setParam("size", *s.size)
+		r.SetParam("size", *s.size)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -401,13 +401,13 @@ func (s *GetIsolatedMarginAccountService) Symbols(symbols ...string) *GetIsolate
 // Do send request
 func (s *GetIsolatedMarginAccountService) Do(ctx context.Context, opts ...RequestOption) (res *IsolatedMarginAccount, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/margin/isolated/account",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/margin/isolated/account",
+		SecType:  secTypeSigned,
 	}
 
 	if len(s.symbols) > 0 {
-		r.setParam("symbols", strings.Join(s.symbols, ","))
+		r.SetParam("symbols", strings.Join(s.symbols, ","))
 	}
 
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -470,9 +470,9 @@ type GetMarginAccountService struct {
 // Do send request
 func (s *GetMarginAccountService) Do(ctx context.Context, opts ...RequestOption) (res *MarginAccount, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/margin/account",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/margin/account",
+		SecType:  secTypeSigned,
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -523,11 +523,11 @@ func (s *GetMarginAssetService) Asset(asset string) *GetMarginAssetService {
 // Do send request
 func (s *GetMarginAssetService) Do(ctx context.Context, opts ...RequestOption) (res *MarginAsset, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/margin/asset",
-		secType:  secTypeAPIKey,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/margin/asset",
+		SecType:  secTypeAPIKey,
 	}
-	r.setParam("asset", s.asset)
+	r.SetParam("asset", s.asset)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
@@ -565,11 +565,11 @@ func (s *GetMarginPairService) Symbol(symbol string) *GetMarginPairService {
 // Do send request
 func (s *GetMarginPairService) Do(ctx context.Context, opts ...RequestOption) (res *MarginPair, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/margin/pair",
-		secType:  secTypeAPIKey,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/margin/pair",
+		SecType:  secTypeAPIKey,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
@@ -601,9 +601,9 @@ type GetMarginAllPairsService struct {
 // Do send request
 func (s *GetMarginAllPairsService) Do(ctx context.Context, opts ...RequestOption) (res []*MarginAllPair, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/margin/allPairs",
-		secType:  secTypeAPIKey,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/margin/allPairs",
+		SecType:  secTypeAPIKey,
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -643,11 +643,11 @@ func (s *GetMarginPriceIndexService) Symbol(symbol string) *GetMarginPriceIndexS
 // Do send request
 func (s *GetMarginPriceIndexService) Do(ctx context.Context, opts ...RequestOption) (res *MarginPriceIndex, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/margin/priceIndex",
-		secType:  secTypeAPIKey,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/margin/priceIndex",
+		SecType:  secTypeAPIKey,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
@@ -717,25 +717,25 @@ func (s *ListMarginTradesService) FromID(fromID int64) *ListMarginTradesService
 // Do send request
 func (s *ListMarginTradesService) Do(ctx context.Context, opts ...RequestOption) (res []*TradeV3, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/margin/myTrades",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/margin/myTrades",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.fromID != nil {
-		r.setParam("fromId", *s.fromID)
+		r.SetParam("fromId", *s.fromID)
 	}
 	if s.isIsolated {
-		r.setParam("isIsolated", "TRUE")
+		r.SetParam("isIsolated", "TRUE")
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -771,13 +771,13 @@ func (s *GetMaxBorrowableService) IsolatedSymbol(isolatedSymbol string) *GetMaxB
 // Do send request
 func (s *GetMaxBorrowableService) Do(ctx context.Context, opts ...RequestOption) (res *MaxBorrowable, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/margin/maxBorrowable",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/margin/maxBorrowable",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("asset", s.asset)
+	r.SetParam("asset", s.asset)
 	if s.isolatedSymbol != "" {
-		r.setParam("isolatedSymbol", s.isolatedSymbol)
+		r.SetParam("isolatedSymbol", s.isolatedSymbol)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -811,11 +811,11 @@ func (s *GetMaxTransferableService) Asset(asset string) *GetMaxTransferableServi
 // Do send request
 func (s *GetMaxTransferableService) Do(ctx context.Context, opts ...RequestOption) (res *MaxTransferable, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/margin/maxTransferable",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/margin/maxTransferable",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("asset", s.asset)
+	r.SetParam("asset", s.asset)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
@@ -848,12 +848,12 @@ func (s *StartIsolatedMarginUserStreamService) Symbol(symbol string) *StartIsola
 // Do send request
 func (s *StartIsolatedMarginUserStreamService) Do(ctx context.Context, opts ...RequestOption) (listenKey string, err error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: "/sapi/v1/userDataStream/isolated",
-		secType:  secTypeAPIKey,
+		Method:   http.MethodPost,
+		Endpoint: "/sapi/v1/userDataStream/isolated",
+		SecType:  secTypeAPIKey,
 	}
 
-	r.setFormParam("symbol", s.symbol)
+	r.SetFormParam("symbol", s.symbol)
 
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -889,12 +889,12 @@ func (s *KeepaliveIsolatedMarginUserStreamService) ListenKey(listenKey string) *
 // Do send request
 func (s *KeepaliveIsolatedMarginUserStreamService) Do(ctx context.Context, opts ...RequestOption) (err error) {
 	r := &request{
-		method:   http.MethodPut,
-		endpoint: "/sapi/v1/userDataStream/isolated",
-		secType:  secTypeAPIKey,
+		Method:   http.MethodPut,
+		Endpoint: "/sapi/v1/userDataStream/isolated",
+		SecType:  secTypeAPIKey,
 	}
-	r.setFormParam("listenKey", s.listenKey)
-	r.setFormParam("symbol", s.symbol)
+	r.SetFormParam("listenKey", s.listenKey)
+	r.SetFormParam("symbol", s.symbol)
 
 	_, err = s.c.callAPI(ctx, r, opts...)
 	return err
@@ -923,13 +923,13 @@ func (s *CloseIsolatedMarginUserStreamService) Symbol(symbol string) *CloseIsola
 // Do send request
 func (s *CloseIsolatedMarginUserStreamService) Do(ctx context.Context, opts ...RequestOption) (err error) {
 	r := &request{
-		method:   http.MethodDelete,
-		endpoint: "/sapi/v1/userDataStream/isolated",
-		secType:  secTypeAPIKey,
+		Method:   http.MethodDelete,
+		Endpoint: "/sapi/v1/userDataStream/isolated",
+		SecType:  secTypeAPIKey,
 	}
 
-	r.setFormParam("listenKey", s.listenKey)
-	r.setFormParam("symbol", s.symbol)
+	r.SetFormParam("listenKey", s.listenKey)
+	r.SetFormParam("symbol", s.symbol)
 
 	_, err = s.c.callAPI(ctx, r, opts...)
 	return err
@@ -943,9 +943,9 @@ type StartMarginUserStreamService struct {
 // Do send request
 func (s *StartMarginUserStreamService) Do(ctx context.Context, opts ...RequestOption) (listenKey string, err error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: "/sapi/v1/userDataStream",
-		secType:  secTypeAPIKey,
+		Method:   http.MethodPost,
+		Endpoint: "/sapi/v1/userDataStream",
+		SecType:  secTypeAPIKey,
 	}
 
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -975,11 +975,11 @@ func (s *KeepaliveMarginUserStreamService) ListenKey(listenKey string) *Keepaliv
 // Do send request
 func (s *KeepaliveMarginUserStreamService) Do(ctx context.Context, opts ...RequestOption) (err error) {
 	r := &request{
-		method:   http.MethodPut,
-		endpoint: "/sapi/v1/userDataStream",
-		secType:  secTypeAPIKey,
+		Method:   http.MethodPut,
+		Endpoint: "/sapi/v1/userDataStream",
+		SecType:  secTypeAPIKey,
 	}
-	r.setFormParam("listenKey", s.listenKey)
+	r.SetFormParam("listenKey", s.listenKey)
 	_, err = s.c.callAPI(ctx, r, opts...)
 	return err
 }
@@ -999,12 +999,12 @@ func (s *CloseMarginUserStreamService) ListenKey(listenKey string) *CloseMarginU
 // Do send request
 func (s *CloseMarginUserStreamService) Do(ctx context.Context, opts ...RequestOption) (err error) {
 	r := &request{
-		method:   http.MethodDelete,
-		endpoint: "/sapi/v1/userDataStream",
-		secType:  secTypeAPIKey,
+		Method:   http.MethodDelete,
+		Endpoint: "/sapi/v1/userDataStream",
+		SecType:  secTypeAPIKey,
 	}
 
-	r.setFormParam("listenKey", s.listenKey)
+	r.SetFormParam("listenKey", s.listenKey)
 
 	_, err = s.c.callAPI(ctx, r, opts...)
 	return err
@@ -1018,9 +1018,9 @@ type GetAllMarginAssetsService struct {
 // Do send request
 func (s *GetAllMarginAssetsService) Do(ctx context.Context, opts ...RequestOption) (res []*MarginAsset, err error) {
 	r := &request{
-		method:   "GET",
-		endpoint: "/sapi/v1/margin/allAssets",
-		secType:  secTypeAPIKey,
+		Method:   "GET",
+		Endpoint: "/sapi/v1/margin/allAssets",
+		SecType:  secTypeAPIKey,
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -1042,9 +1042,9 @@ type GetIsolatedMarginAllPairsService struct {
 // Do send request
 func (s *GetIsolatedMarginAllPairsService) Do(ctx context.Context, opts ...RequestOption) (res []*IsolatedMarginAllPair, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/margin/isolated/allPairs",
-		secType:  secTypeAPIKey,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/margin/isolated/allPairs",
+		SecType:  secTypeAPIKey,
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -1109,15 +1109,15 @@ func (s *IsolatedMarginTransferService) Amount(amount string) *IsolatedMarginTra
 // Do send request
 func (s *IsolatedMarginTransferService) Do(ctx context.Context, opts ...RequestOption) (res *TransactionResponse, err error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: "/sapi/v1/margin/isolated/transfer",
-		secType:  secTypeSigned,
-	}
-	r.setFormParam("asset", s.asset)
-	r.setFormParam("symbol", s.symbol)
-	r.setFormParam("transFrom", s.transFrom)
-	r.setFormParam("transTo", s.transTo)
-	r.setFormParam("amount", s.amount)
+		Method:   http.MethodPost,
+		Endpoint: "/sapi/v1/margin/isolated/transfer",
+		SecType:  secTypeSigned,
+	}
+	r.SetFormParam("asset", s.asset)
+	r.SetFormParam("symbol", s.symbol)
+	r.SetFormParam("transFrom", s.transFrom)
+	r.SetFormParam("transTo", s.transTo)
+	r.SetFormParam("amount", s.amount)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
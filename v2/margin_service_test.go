@@ -24,7 +24,7 @@ func (s *marginTestSuite) TestTransfer() {
 	amount := "1.000"
 	transferType := MarginTransferTypeToMargin
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"asset":  asset,
 			"amount": amount,
 			"type":   transferType,
@@ -53,7 +53,7 @@ func (s *marginTestSuite) TestLoan() {
 	asset := "BTC"
 	amount := "1.000"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"asset":  asset,
 			"amount": amount,
 		})
@@ -77,7 +77,7 @@ func (s *marginTestSuite) TestRepay() {
 	asset := "BTC"
 	amount := "1.000"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"asset":  asset,
 			"amount": amount,
 		})
@@ -113,7 +113,7 @@ func (s *marginTestSuite) TestListMarginLoans() {
 	current := int64(1)
 	size := int64(10)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"asset":     asset,
 			"txId":      txID,
 			"startTime": startTime,
@@ -182,7 +182,7 @@ func (s *marginTestSuite) TestListMarginRepays() {
 	current := int64(1)
 	size := int64(10)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"asset":     asset,
 			"txId":      txID,
 			"startTime": startTime,
@@ -470,7 +470,7 @@ func (s *marginTestSuite) TestGetMarginAsset() {
 	asset := "BNB"
 	s.assertReq(func(r *request) {
 		e := newRequest()
-		e.setParam("asset", asset)
+		e.SetParam("asset", asset)
 		s.assertRequestEqual(e, r)
 	})
 	res, err := s.client.NewGetMarginAssetService().Asset(asset).Do(newContext())
@@ -511,7 +511,7 @@ func (s *marginTestSuite) TestGetMarginPair() {
 	symbol := "BTCUSDT"
 	s.assertReq(func(r *request) {
 		e := newRequest()
-		e.setParam("symbol", symbol)
+		e.SetParam("symbol", symbol)
 		s.assertRequestEqual(e, r)
 	})
 	res, err := s.client.NewGetMarginPairService().Symbol(symbol).Do(newContext())
@@ -615,7 +615,7 @@ func (s *marginTestSuite) TestGetMarginPriceIndex() {
 	symbol := "BNBBTC"
 	s.assertReq(func(r *request) {
 		e := newRequest()
-		e.setParam("symbol", symbol)
+		e.SetParam("symbol", symbol)
 		s.assertRequestEqual(e, r)
 	})
 	res, err := s.client.NewGetMarginPriceIndexService().Symbol(symbol).Do(newContext())
@@ -670,7 +670,7 @@ func (s *marginTestSuite) TestListMarginTrades() {
 	startTime := int64(1499865549590)
 	endTime := int64(1499865549590)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":    symbol,
 			"startTime": startTime,
 			"endTime":   endTime,
@@ -727,7 +727,7 @@ func (s *marginTestSuite) TestGetMaxBorrowable() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"asset": "BNBBTC",
 		})
 		s.assertRequestEqual(e, r)
@@ -755,7 +755,7 @@ func (s *marginTestSuite) TestGetMaxTransferable() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"asset": "BNBBTC",
 		})
 		s.assertRequestEqual(e, r)
@@ -798,7 +798,7 @@ func (s *marginTestSuite) TestKeepaliveMarginUserStream() {
 
 	listenKey := "dummykey"
 	s.assertReq(func(r *request) {
-		s.assertRequestEqual(newRequest().setFormParam("listenKey", listenKey), r)
+		s.assertRequestEqual(newRequest().SetFormParam("listenKey", listenKey), r)
 	})
 
 	err := s.client.NewKeepaliveMarginUserStreamService().ListenKey(listenKey).Do(newContext())
@@ -812,7 +812,7 @@ func (s *marginTestSuite) TestCloseMarginUserStream() {
 
 	listenKey := "dummykey"
 	s.assertReq(func(r *request) {
-		s.assertRequestEqual(newRequest().setFormParam("listenKey", listenKey), r)
+		s.assertRequestEqual(newRequest().SetFormParam("listenKey", listenKey), r)
 	})
 
 	err := s.client.NewCloseMarginUserStreamService().ListenKey(listenKey).Do(newContext())
@@ -894,7 +894,7 @@ func (s *marginTestSuite) TestIsolatedMarginTransferService() {
 		amount    = "1"
 	)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"asset":     asset,
 			"symbol":    symbol,
 			"transFrom": transFrom,
@@ -0,0 +1,117 @@
+package binance
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/stretchr/testify/suite"
+)
+
+type orderBookTestSuite struct {
+	suite.Suite
+	srv    *binancetest.Server
+	client *Client
+
+	mu      sync.Mutex
+	updates []*OrderBookUpdate
+}
+
+func TestOrderBook(t *testing.T) {
+	suite.Run(t, new(orderBookTestSuite))
+}
+
+func (s *orderBookTestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.client = NewClientWithEnvironment(s.srv.APIKey, s.srv.SecretKey, &Environment{
+		APIURL: s.srv.URL,
+		WsURL:  s.srv.WsURL(binancetest.Spot),
+	})
+	s.updates = nil
+}
+
+func (s *orderBookTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *orderBookTestSuite) placeOrder(side, price, quantity string) {
+	_, err := s.srv.PlaceOrder(binancetest.Spot, "BTCUSDT", side, price, quantity)
+	s.Require().NoError(err)
+}
+
+func (s *orderBookTestSuite) TestOrderBook() {
+	s.placeOrder("SELL", "101", "1")
+	s.placeOrder("BUY", "99", "2")
+
+	book := s.client.NewOrderBook("BTCUSDT").Limit(100).OnUpdate(func(update *OrderBookUpdate) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.updates = append(s.updates, update)
+	}).OnError(func(err error) {
+		s.Fail(err.Error())
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	s.Require().NoError(book.Start(ctx))
+	defer book.Close()
+	s.Require().Eventually(func() bool {
+		return s.srv.Subscribers(binancetest.Spot, "btcusdt@depth") == 1
+	}, time.Second, 5*time.Millisecond)
+
+	// the book is synchronised once the first diff is received
+	s.False(book.Synced())
+	s.placeOrder("BUY", "100", "1")
+	s.Require().NoError(book.WaitSynced(ctx))
+	bid, ok := book.BestBid()
+	s.True(ok)
+	s.Equal("100.00000000", bid.Price)
+	ask, ok := book.BestAsk()
+	s.True(ok)
+	s.Equal(Ask{Price: "101.00000000", Quantity: "1.00000000"}, ask)
+	s.Len(book.Bids(0), 2)
+
+	s.placeOrder("SELL", "99", "1.5")
+	s.Require().Eventually(func() bool {
+		return len(book.Bids(0)) == 1
+	}, time.Second, 5*time.Millisecond)
+	quantity, err := book.BidQuantity("99")
+	s.Require().NoError(err)
+	s.Equal("1.50000000", quantity)
+	s.Equal(int64(4), book.LastUpdateID())
+
+	s.Require().Eventually(func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.updates) == 2
+	}, time.Second, 5*time.Millisecond)
+	s.mu.Lock()
+	s.True(s.updates[0].Snapshot)
+	s.False(s.updates[1].Snapshot)
+	s.mu.Unlock()
+
+	book.Close()
+	select {
+	case <-book.Done():
+	case <-time.After(time.Second):
+		s.FailNow("book not stopped")
+	}
+	s.Equal(ErrOrderBookStopped, book.WaitSynced(context.Background()))
+}
+
+func (s *orderBookTestSuite) TestOrderBookNotStarted() {
+	book := s.client.NewOrderBook("BTCUSDT")
+	book.Close()
+	s.False(book.Synced())
+	s.Zero(book.LastUpdateID())
+	s.Empty(book.Bids(0))
+	<-book.Done()
+
+	s.srv.Close()
+	s.Error(book.Start(context.Background()))
+	s.False(book.Synced())
+	s.Equal(ErrOrderBookStopped, book.WaitSynced(context.Background()))
+	book.Close()
+	<-book.Done()
+}
@@ -97,9 +97,9 @@ func (s *CreateOrderService) NewOrderRespType(newOrderRespType NewOrderRespType)
 
 func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: endpoint,
-		secType:  secTypeSigned,
+		Method:   http.MethodPost,
+		Endpoint: endpoint,
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -133,7 +133,7 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 	if s.newOrderRespType != nil {
 		m["newOrderRespType"] = *s.newOrderRespType
 	}
-	r.setFormParams(m)
+	r.SetFormParams(m)
 	data, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []byte{}, err
@@ -291,9 +291,9 @@ func (s *CreateOCOService) NewOrderRespType(newOrderRespType NewOrderRespType) *
 
 func (s *CreateOCOService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: endpoint,
-		secType:  secTypeSigned,
+		Method:   http.MethodPost,
+		Endpoint: endpoint,
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"symbol":    s.symbol,
@@ -327,7 +327,7 @@ func (s *CreateOCOService) createOrder(ctx context.Context, endpoint string, opt
 	if s.newOrderRespType != nil {
 		m["newOrderRespType"] = *s.newOrderRespType
 	}
-	r.setFormParams(m)
+	r.SetFormParams(m)
 	data, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []byte{}, err
@@ -409,9 +409,9 @@ type Oco struct {
 // Do send request
 func (s *ListOpenOcoService) Do(ctx context.Context, opts ...RequestOption) (res []*Oco, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/openOrderList ",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/openOrderList ",
+		SecType:  secTypeSigned,
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -440,12 +440,12 @@ func (s *ListOpenOrdersService) Symbol(symbol string) *ListOpenOrdersService {
 // Do send request
 func (s *ListOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*Order, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/openOrders",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/openOrders",
+		SecType:  secTypeSigned,
 	}
 	if s.symbol != "" {
-		r.setParam("symbol", s.symbol)
+		r.SetParam("symbol", s.symbol)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -488,16 +488,16 @@ func (s *GetOrderService) OrigClientOrderID(origClientOrderID string) *GetOrderS
 // Do send request
 func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *Order, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/order",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/order",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.orderID != nil {
-		r.setParam("orderId", *s.orderID)
+		r.SetParam("orderId", *s.orderID)
 	}
 	if s.origClientOrderID != nil {
-		r.setParam("origClientOrderId", *s.origClientOrderID)
+		r.SetParam("origClientOrderId", *s.origClientOrderID)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -577,22 +577,22 @@ func (s *ListOrdersService) Limit(limit int) *ListOrdersService {
 // Do send request
 func (s *ListOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*Order, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/allOrders",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/allOrders",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	if s.orderID != nil {
-		r.setParam("orderId", *s.orderID)
+		r.SetParam("orderId", *s.orderID)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -642,19 +642,19 @@ func (s *CancelOrderService) NewClientOrderID(newClientOrderID string) *CancelOr
 // Do send request
 func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CancelOrderResponse, err error) {
 	r := &request{
-		method:   http.MethodDelete,
-		endpoint: "/api/v3/order",
-		secType:  secTypeSigned,
+		Method:   http.MethodDelete,
+		Endpoint: "/api/v3/order",
+		SecType:  secTypeSigned,
 	}
-	r.setFormParam("symbol", s.symbol)
+	r.SetFormParam("symbol", s.symbol)
 	if s.orderID != nil {
-		r.setFormParam("orderId", *s.orderID)
+		r.SetFormParam("orderId", *s.orderID)
 	}
 	if s.origClientOrderID != nil {
-		r.setFormParam("origClientOrderId", *s.origClientOrderID)
+		r.SetFormParam("origClientOrderId", *s.origClientOrderID)
 	}
 	if s.newClientOrderID != nil {
-		r.setFormParam("newClientOrderId", *s.newClientOrderID)
+		r.SetFormParam("newClientOrderId", *s.newClientOrderID)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -704,19 +704,19 @@ func (s *CancelOCOService) NewClientOrderID(newClientOrderID string) *CancelOCOS
 // Do send request
 func (s *CancelOCOService) Do(ctx context.Context, opts ...RequestOption) (res *CancelOCOResponse, err error) {
 	r := &request{
-		method:   http.MethodDelete,
-		endpoint: "/api/v3/orderList",
-		secType:  secTypeSigned,
+		Method:   http.MethodDelete,
+		Endpoint: "/api/v3/orderList",
+		SecType:  secTypeSigned,
 	}
-	r.setFormParam("symbol", s.symbol)
+	r.SetFormParam("symbol", s.symbol)
 	if s.listClientOrderID != "" {
-		r.setFormParam("listClientOrderId", s.listClientOrderID)
+		r.SetFormParam("listClientOrderId", s.listClientOrderID)
 	}
 	if s.orderListID != 0 {
-		r.setFormParam("orderListId", s.orderListID)
+		r.SetFormParam("orderListId", s.orderListID)
 	}
 	if s.newClientOrderID != "" {
-		r.setFormParam("newClientOrderId", s.newClientOrderID)
+		r.SetFormParam("newClientOrderId", s.newClientOrderID)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -745,11 +745,11 @@ func (s *CancelOpenOrdersService) Symbol(symbol string) *CancelOpenOrdersService
 // Do send request
 func (s *CancelOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *CancelOpenOrdersResponse, err error) {
 	r := &request{
-		method:   http.MethodDelete,
-		endpoint: "/api/v3/openOrders",
-		secType:  secTypeSigned,
+		Method:   http.MethodDelete,
+		Endpoint: "/api/v3/openOrders",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return &CancelOpenOrdersResponse{}, err
@@ -45,7 +45,7 @@ func (s *orderServiceTestSuite) TestCreateOrder() {
 	newClientOrderID := "myOrder1"
 	trailingDelta := "1000"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":           symbol,
 			"side":             side,
 			"type":             orderType,
@@ -120,7 +120,7 @@ func (s *orderServiceTestSuite) TestCreateOrderFull() {
 	newClientOrderID := "myOrder1"
 	newOrderRespType := NewOrderRespTypeFULL
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":           symbol,
 			"side":             side,
 			"type":             orderType,
@@ -265,7 +265,7 @@ func (s *orderServiceTestSuite) TestCreateOCO() {
 	limitClientOrderID := "myOrder1"
 	newOrderRespType := NewOrderRespTypeFULL
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":               symbol,
 			"side":                 side,
 			"quantity":             quantity,
@@ -417,7 +417,7 @@ func (s *orderServiceTestSuite) TestListOpenOco() {
 	defer s.assertDo()
 	recvWindow := int64(1000)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"recvWindow": recvWindow,
 		})
 		s.assertRequestEqual(e, r)
@@ -492,7 +492,7 @@ func (s *orderServiceTestSuite) TestListOpenOrders() {
 	symbol := "LTCBTC"
 	recvWindow := int64(1000)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":     symbol,
 			"recvWindow": recvWindow,
 		})
@@ -570,7 +570,7 @@ func (s *orderServiceTestSuite) TestGetOrder() {
 	orderID := int64(1)
 	origClientOrderID := "myOrder1"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":            symbol,
 			"orderId":           orderID,
 			"origClientOrderId": origClientOrderID,
@@ -631,7 +631,7 @@ func (s *orderServiceTestSuite) TestListOrders() {
 	startTime := int64(1499827319559)
 	endTime := int64(1499827319560)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":    symbol,
 			"orderId":   orderID,
 			"startTime": startTime,
@@ -721,7 +721,7 @@ func (s *orderServiceTestSuite) TestCancelOCO() {
 	symbol := "BTCUSDT"
 	listClientOrderID := "my-list-order-id"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":            symbol,
 			"listClientOrderId": listClientOrderID,
 		})
@@ -808,7 +808,7 @@ func (s *orderServiceTestSuite) TestCancelOrder() {
 	origClientOrderID := "myOrder1"
 	newClientOrderID := "cancelMyOrder1"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setFormParams(params{
+		e := newSignedRequest().SetFormParams(params{
 			"symbol":            symbol,
 			"orderId":           orderID,
 			"origClientOrderId": origClientOrderID,
@@ -919,7 +919,7 @@ func (s *orderServiceTestSuite) TestCancelOpenOrders() {
 
 	symbol := "BTCUSDT"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol": symbol,
 		})
 		s.assertRequestEqual(e, r)
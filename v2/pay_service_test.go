@@ -43,7 +43,7 @@ func (s *payServiceTestSuite) TestPayTradeHistory() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{})
+		e := newSignedRequest().SetParams(params{})
 		s.assertRequestEqual(e, r)
 	})
 
@@ -41,9 +41,9 @@ func (s *ListSavingsFlexibleProductsService) Size(size int64) *ListSavingsFlexib
 // Do send request
 func (s *ListSavingsFlexibleProductsService) Do(ctx context.Context, opts ...RequestOption) ([]*SavingsFlexibleProduct, error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/lending/daily/product/list",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/lending/daily/product/list",
+		SecType:  secTypeSigned,
 	}
 	m := params{}
 	if s.status != "" {
@@ -58,7 +58,7 @@ func (s *ListSavingsFlexibleProductsService) Do(ctx context.Context, opts ...Req
 	if s.size != 0 {
 		m["size"] = s.size
 	}
-	r.setParams(m)
+	r.SetParams(m)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
@@ -109,15 +109,15 @@ func (s *PurchaseSavingsFlexibleProductService) Amount(amount float64) *Purchase
 // Do send request
 func (s *PurchaseSavingsFlexibleProductService) Do(ctx context.Context, opts ...RequestOption) (uint64, error) {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: "/sapi/v1/lending/daily/purchase",
-		secType:  secTypeSigned,
+		Method:   http.MethodPost,
+		Endpoint: "/sapi/v1/lending/daily/purchase",
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"productId": s.productId,
 		"amount":    s.amount,
 	}
-	r.setParams(m)
+	r.SetParams(m)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return 0, err
@@ -164,9 +164,9 @@ func (s *RedeemSavingsFlexibleProductService) Type(redeemType string) *RedeemSav
 // Do send request
 func (s *RedeemSavingsFlexibleProductService) Do(ctx context.Context, opts ...RequestOption) error {
 	r := &request{
-		method:   http.MethodPost,
-		endpoint: "/sapi/v1/lending/daily/redeem",
-		secType:  secTypeSigned,
+		Method:   http.MethodPost,
+		Endpoint: "/sapi/v1/lending/daily/redeem",
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"productId": s.productId,
@@ -175,7 +175,7 @@ func (s *RedeemSavingsFlexibleProductService) Do(ctx context.Context, opts ...Re
 	if s.redeemType != "" {
 		m["type"] = s.redeemType
 	}
-	r.setParams(m)
+	r.SetParams(m)
 	_, err := s.c.callAPI(ctx, r, opts...)
 
 	return err
@@ -238,9 +238,9 @@ func (s *ListSavingsFixedAndActivityProductsService) Size(size int64) *ListSavin
 // Do send request
 func (s *ListSavingsFixedAndActivityProductsService) Do(ctx context.Context, opts ...RequestOption) ([]*SavingsFixedProduct, error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/lending/project/list",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/lending/project/list",
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"type": s.projectType,
@@ -263,7 +263,7 @@ func (s *ListSavingsFixedAndActivityProductsService) Do(ctx context.Context, opt
 	if s.size != 10 {
 		m["size"] = s.size
 	}
-	r.setParams(m)
+	r.SetParams(m)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
@@ -310,15 +310,15 @@ func (s *SavingFlexibleProductPositionsService) Asset(asset string) *SavingFlexi
 // Do send request
 func (s *SavingFlexibleProductPositionsService) Do(ctx context.Context, opts ...RequestOption) ([]*SavingFlexibleProductPosition, error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/lending/daily/token/position",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/lending/daily/token/position",
+		SecType:  secTypeSigned,
 	}
 	m := params{}
 	if s.asset != "" {
 		m["asset"] = s.asset
 	}
-	r.setParams(m)
+	r.SetParams(m)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
@@ -377,9 +377,9 @@ func (s *SavingFixedProjectPositionsService) ProjectID(projectId string) *Saving
 // Do send request
 func (s *SavingFixedProjectPositionsService) Do(ctx context.Context, opts ...RequestOption) ([]*SavingFixedProjectPosition, error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/lending/project/position/list",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/lending/project/position/list",
+		SecType:  secTypeSigned,
 	}
 	m := params{}
 	if s.asset != "" {
@@ -391,7 +391,7 @@ func (s *SavingFixedProjectPositionsService) Do(ctx context.Context, opts ...Req
 	if s.projectId != "" {
 		m["projectId"] = s.projectId
 	}
-	r.setParams(m)
+	r.SetParams(m)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
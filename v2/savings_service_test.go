@@ -48,7 +48,7 @@ func (s *savingsServiceTestSuite) TestListSavingsFlexibleProducts() {
 	s.mockDo(data, nil)
 	defer s.assertDo()
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"status":   "ALL",
 			"featured": "ALL",
 			"current":  1,
@@ -118,7 +118,7 @@ func (s *savingsServiceTestSuite) TestPurchaseSavingsFlexibleProduct() {
 	s.mockDo(data, nil)
 	defer s.assertDo()
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"productId": "BTC001",
 			"amount":    0.52,
 		})
@@ -140,7 +140,7 @@ func (s *savingsServiceTestSuite) TestReedemSavingsFlexibleProduct() {
 	s.mockDo(data, nil)
 	defer s.assertDo()
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"productId": "BTC001",
 			"amount":    0.52,
 			"type":      "FAST",
@@ -182,7 +182,7 @@ func (s *savingsServiceTestSuite) TestListSavingsFixedAndActivityProducts() {
 	s.mockDo(data, nil)
 	defer s.assertDo()
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"asset":     "USDT",
 			"type":      "ACTIVITY",
 			"status":    "ALL",
@@ -269,7 +269,7 @@ func (s *savingsServiceTestSuite) TestSavingFlexibleProductPositionsService() {
 	s.mockDo(data, nil)
 	defer s.assertDo()
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{})
+		e := newSignedRequest().SetParams(params{})
 		s.assertRequestEqual(e, r)
 	})
 
@@ -341,7 +341,7 @@ func (s *savingsServiceTestSuite) TestSavingFixedProjectPositionsService() {
 	s.mockDo(data, nil)
 	defer s.assertDo()
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"status": "HOLDING",
 		})
 		s.assertRequestEqual(e, r)
@@ -3,6 +3,9 @@ package binance
 import (
 	"context"
 	"net/http"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/common"
 )
 
 // PingService ping server
@@ -13,8 +16,8 @@ type PingService struct {
 // Do send request
 func (s *PingService) Do(ctx context.Context, opts ...RequestOption) (err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/ping",
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/ping",
 	}
 	_, err = s.c.callAPI(ctx, r, opts...)
 	return err
@@ -28,8 +31,8 @@ type ServerTimeService struct {
 // Do send request
 func (s *ServerTimeService) Do(ctx context.Context, opts ...RequestOption) (serverTime int64, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/time",
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/time",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -58,3 +61,20 @@ func (s *SetServerTimeService) Do(ctx context.Context, opts ...RequestOption) (t
 	s.c.TimeOffset = timeOffset
 	return timeOffset, nil
 }
+
+// StartTimeSync keeps the client in sync with the server clock by sampling
+// /api/v3/time every interval in the background, until ctx is done or Stop is
+// called on the returned TimeSync. SIGNED requests failing with a -1021 error
+// trigger a sync and are sent once more.
+// A zero interval uses common.DefaultTimeSyncInterval. The previous TimeSync of
+// the client is stopped, the new one replaces it once its first sync succeeded.
+func (c *Client) StartTimeSync(ctx context.Context, interval time.Duration) (*common.TimeSync, error) {
+	ts, err := common.StartTimeSync(ctx, c.TimeSync, func(ctx context.Context) (int64, error) {
+		return c.NewServerTimeService().Do(ctx)
+	}, interval)
+	if err != nil {
+		return nil, err
+	}
+	c.TimeSync = ts
+	return ts, nil
+}
@@ -1,9 +1,11 @@
 package binance
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/pooyakn/go-binance/v2/common"
 	"github.com/stretchr/testify/suite"
@@ -105,3 +107,42 @@ func (s *serverServiceTestSuite) TestSetServerTime() {
 	s.r().NotZero(s.client.TimeOffset)
 	s.r().EqualValues(timeOffset, s.client.TimeOffset)
 }
+
+func (s *serverServiceTestSuite) TestStartTimeSync() {
+	serverTime := time.Now().Add(-5 * time.Second)
+	s.client.Client.do = func(req *http.Request) (*http.Response, error) {
+		s.r().Equal("/api/v3/time", req.URL.Path)
+		data := fmt.Sprintf(`{"serverTime": %d}`, FormatTimestamp(serverTime))
+		return newHTTPResponse([]byte(data), http.StatusOK), nil
+	}
+
+	ctx, cancel := context.WithCancel(newContext())
+	defer cancel()
+	ts, err := s.client.StartTimeSync(ctx, time.Hour)
+	s.r().NoError(err)
+	defer ts.Stop()
+	s.r().Equal(ts, s.client.TimeSync)
+	s.r().InDelta(5*time.Second, ts.Drift(), float64(time.Second))
+	s.r().InDelta(5000, ts.Offset(), 1000)
+}
+
+func (s *serverServiceTestSuite) TestStartTimeSyncFailure() {
+	fail := false
+	s.client.Client.do = func(req *http.Request) (*http.Response, error) {
+		if fail {
+			return newHTTPResponse([]byte(`{"code":-1000,"msg":"An unknown error occured while processing the request."}`), http.StatusInternalServerError), nil
+		}
+		data := fmt.Sprintf(`{"serverTime": %d}`, FormatTimestamp(time.Now()))
+		return newHTTPResponse([]byte(data), http.StatusOK), nil
+	}
+	ts, err := s.client.StartTimeSync(newContext(), time.Hour)
+	s.r().NoError(err)
+	defer ts.Stop()
+
+	// the TimeSync of the client is kept when the new one does not start
+	fail = true
+	failed, err := s.client.StartTimeSync(newContext(), time.Hour)
+	s.r().Error(err)
+	s.r().Nil(failed)
+	s.r().Equal(ts, s.client.TimeSync)
+}
@@ -48,22 +48,22 @@ func (s *StakingProductPositionService) Size(size int32) *StakingProductPosition
 // Do sends the request.
 func (s *StakingProductPositionService) Do(ctx context.Context) (*StakingProductPositions, error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/staking/position",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/staking/position",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("product", s.product)
+	r.SetParam("product", s.product)
 	if s.productId != nil {
-		r.setParam("productId", *s.productId)
+		r.SetParam("productId", *s.productId)
 	}
 	if s.asset != nil {
-		r.setParam("asset", *s.asset)
+		r.SetParam("asset", *s.asset)
 	}
 	if s.current != nil {
-		r.setParam("current", *s.current)
+		r.SetParam("current", *s.current)
 	}
 	if s.size != nil {
-		r.setParam("size", *s.size)
+		r.SetParam("size", *s.size)
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
@@ -167,26 +167,26 @@ func (s *StakingHistoryService) Size(size int32) *StakingHistoryService {
 // Do sends the request.
 func (s *StakingHistoryService) Do(ctx context.Context) (*StakingHistory, error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/sapi/v1/staking/stakingRecord",
-		secType:  secTypeSigned,
+		Method:   http.MethodGet,
+		Endpoint: "/sapi/v1/staking/stakingRecord",
+		SecType:  secTypeSigned,
 	}
-	r.setParam("product", s.product)
-	r.setParam("txnType", s.transactionType)
+	r.SetParam("product", s.product)
+	r.SetParam("txnType", s.transactionType)
 	if s.asset != nil {
-		r.setParam("asset", *s.asset)
+		r.SetParam("asset", *s.asset)
 	}
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 	if s.current != nil {
-		r.setParam("current", *s.current)
+		r.SetParam("current", *s.current)
 	}
 	if s.size != nil {
-		r.setParam("size", *s.size)
+		r.SetParam("size", *s.size)
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
@@ -49,7 +49,7 @@ func (s *stakingServiceTestSuite) TestStakingProductPosition() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"product": StakingProductLockedStaking,
 		})
 		s.assertRequestEqual(e, r)
@@ -148,7 +148,7 @@ func (s *stakingServiceTestSuite) TestStakingHistory() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"product": StakingProductLockedStaking,
 			"txnType": StakingTransactionTypeSubscription,
 		})
@@ -0,0 +1,80 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/pooyakn/go-binance/v2/internal/wsconn"
+)
+
+// DefaultStreamPoolLimits are the limits of Binance on the spot stream
+// connections: 1024 streams and 5 incoming messages per second
+var DefaultStreamPoolLimits = common.StreamPoolLimits{MaxStreams: 1024, MessageRate: 5}
+
+// StreamPoolHandler handles the event payloads of a stream pool along with their stream name
+type StreamPoolHandler func(stream string, data []byte)
+
+// StreamPool spreads streams over as many connections to the combined stream
+// endpoint as needed to respect the limits of Binance, unlike the
+// WsCombined*Serve functions whose connections are rejected once they have
+// too many streams. The subscriptions are rate limited on every connection,
+// the connections are opened as needed and closed once they have no stream
+// left, and the streams of a connection which stopped are subscribed again
+// on the others. The events of every stream are passed to a single handler.
+type StreamPool struct {
+	pool *wsconn.Pool
+}
+
+// NewStreamPool init a stream pool of the default environment
+func NewStreamPool(handler StreamPoolHandler, errHandler ErrHandler) *StreamPool {
+	return DefaultEnvironment().NewStreamPool(handler, errHandler)
+}
+
+// NewStreamPool init a stream pool with DefaultStreamPoolLimits. errHandler
+// is called with the connection errors and the messages which can not be
+// routed to a stream.
+func (e *Environment) NewStreamPool(handler StreamPoolHandler, errHandler ErrHandler) *StreamPool {
+	return e.NewStreamPoolWithLimits(DefaultStreamPoolLimits, handler, errHandler)
+}
+
+// NewStreamPoolWithLimits is similar to NewStreamPool, with the limits of every connection
+func (e *Environment) NewStreamPoolWithLimits(limits common.StreamPoolLimits, handler StreamPoolHandler, errHandler ErrHandler) *StreamPool {
+	cfg := e.newWsConfig(strings.TrimSuffix(e.CombinedURL, "?streams="))
+	pool := wsconn.NewPool(cfg.connConfig(), limits, handler, func(message []byte) {
+		errHandler(fmt.Errorf("unrouted stream message: %s", message))
+	}, errHandler)
+	return &StreamPool{pool: pool}
+}
+
+// Subscribe subscribes to the streams, e.g. "btcusdt@aggTrade", opening
+// connections as needed. The streams already subscribed are ignored.
+func (p *StreamPool) Subscribe(ctx context.Context, streams ...string) error {
+	return p.pool.Subscribe(ctx, streams)
+}
+
+// Unsubscribe unsubscribes from the streams
+func (p *StreamPool) Unsubscribe(ctx context.Context, streams ...string) error {
+	return p.pool.Unsubscribe(ctx, streams)
+}
+
+// Streams returns the subscribed streams, sorted
+func (p *StreamPool) Streams() []string {
+	return p.pool.Streams()
+}
+
+// Connections returns the number of connections of the pool
+func (p *StreamPool) Connections() int {
+	return p.pool.Connections()
+}
+
+// Close closes the connections of the pool
+func (p *StreamPool) Close() {
+	p.pool.Close()
+}
+
+// Done returns a channel closed once the pool is closed
+func (p *StreamPool) Done() <-chan struct{} {
+	return p.pool.Done()
+}
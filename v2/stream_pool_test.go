@@ -0,0 +1,80 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type streamPoolTestSuite struct {
+	suite.Suite
+	srv *binancetest.Server
+	env *Environment
+}
+
+func TestStreamPool(t *testing.T) {
+	suite.Run(t, new(streamPoolTestSuite))
+}
+
+func (s *streamPoolTestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.env = &Environment{
+		APIURL:      s.srv.URL,
+		WsURL:       s.srv.WsURL(binancetest.Spot),
+		CombinedURL: s.srv.CombinedURL(binancetest.Spot),
+	}
+}
+
+func (s *streamPoolTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *streamPoolTestSuite) TestStreamPool() {
+	s.srv.MaxStreams = 2
+	events := make(chan string, 10)
+	pool := s.env.NewStreamPoolWithLimits(common.StreamPoolLimits{MaxStreams: 2}, func(stream string, data []byte) {
+		events <- stream
+	}, func(err error) {
+		s.Fail(err.Error())
+	})
+	defer pool.Close()
+
+	ctx := context.Background()
+	streams := []string{"btcusdt@aggTrade", "ethusdt@aggTrade", "bnbusdt@aggTrade"}
+	s.Require().NoError(pool.Subscribe(ctx, streams...))
+	s.Equal(2, pool.Connections())
+	s.Equal([]string{"bnbusdt@aggTrade", "btcusdt@aggTrade", "ethusdt@aggTrade"}, pool.Streams())
+
+	for _, stream := range streams {
+		s.Require().Eventually(func() bool {
+			return s.srv.Subscribers(binancetest.Spot, stream) == 1
+		}, time.Second, 5*time.Millisecond)
+		_, err := s.srv.Publish(binancetest.Spot, stream, map[string]string{"e": "aggTrade"})
+		s.Require().NoError(err)
+		select {
+		case received := <-events:
+			s.Equal(stream, received)
+		case <-time.After(time.Second):
+			s.FailNow(fmt.Sprintf("timeout waiting for an event of %s", stream))
+		}
+	}
+
+	s.Require().NoError(pool.Unsubscribe(ctx, "bnbusdt@aggTrade"))
+	s.Equal(1, pool.Connections())
+}
+
+func (s *streamPoolTestSuite) TestTooManyStreams() {
+	// the combined streams of a single connection are rejected
+	s.srv.MaxStreams = 2
+	_, _, err := s.env.WsCombinedAggTradeServe([]string{"BTCUSDT", "ETHUSDT", "BNBUSDT"}, func(event *WsAggTradeEvent) {}, func(err error) {})
+	s.Error(err)
+
+	pool := s.env.NewStreamPoolWithLimits(common.StreamPoolLimits{MaxStreams: 2}, func(stream string, data []byte) {}, func(err error) {})
+	defer pool.Close()
+	s.NoError(pool.Subscribe(context.Background(), "btcusdt@aggTrade", "ethusdt@aggTrade", "bnbusdt@aggTrade"))
+}
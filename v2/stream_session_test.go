@@ -0,0 +1,135 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type streamSessionTestSuite struct {
+	suite.Suite
+	srv *binancetest.Server
+	env *Environment
+
+	mu   sync.Mutex
+	errs []error
+}
+
+func TestStreamSession(t *testing.T) {
+	suite.Run(t, new(streamSessionTestSuite))
+}
+
+func (s *streamSessionTestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.env = &Environment{
+		APIURL:      s.srv.URL,
+		WsURL:       s.srv.WsURL(binancetest.Spot),
+		CombinedURL: s.srv.CombinedURL(binancetest.Spot),
+	}
+	s.errs = nil
+}
+
+func (s *streamSessionTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *streamSessionTestSuite) newSession() *StreamSession {
+	session, err := s.env.NewStreamSession(func(err error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.errs = append(s.errs, err)
+	})
+	s.Require().NoError(err)
+	return session
+}
+
+func (s *streamSessionTestSuite) waitSubscribers(stream string, n int) {
+	s.Require().Eventually(func() bool {
+		return s.srv.Subscribers(binancetest.Spot, stream) == n
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (s *streamSessionTestSuite) TestSubscribe() {
+	ctx := context.Background()
+	session := s.newSession()
+	defer session.Close()
+
+	trades := make(chan *WsTradeEvent, 1)
+	s.Require().NoError(session.SubscribeTrade(ctx, "BTCUSDT", func(event *WsTradeEvent) {
+		trades <- event
+	}))
+	depths := make(chan *WsDepthEvent, 2)
+	s.Require().NoError(session.SubscribeDepth(ctx, "BTCUSDT", func(event *WsDepthEvent) {
+		depths <- event
+	}))
+	streams, err := session.ListSubscriptions(ctx)
+	s.Require().NoError(err)
+	s.Equal([]string{"btcusdt@depth", "btcusdt@trade"}, streams)
+
+	_, err = s.srv.PlaceOrder(binancetest.Spot, "BTCUSDT", "SELL", "100", "1")
+	s.Require().NoError(err)
+	depth := <-depths
+	s.Equal("BTCUSDT", depth.Symbol)
+	s.Equal([]Ask{{Price: "100.00000000", Quantity: "1.00000000"}}, depth.Asks)
+
+	s.Require().NoError(session.Unsubscribe(ctx, "btcusdt@depth"))
+	s.Equal(0, s.srv.Subscribers(binancetest.Spot, "btcusdt@depth"))
+	_, err = s.srv.PlaceOrder(binancetest.Spot, "BTCUSDT", "BUY", "100", "0.5")
+	s.Require().NoError(err)
+	trade := <-trades
+	s.Equal("0.50000000", trade.Quantity)
+	s.Empty(depths)
+	s.Empty(s.errs)
+}
+
+func (s *streamSessionTestSuite) TestRequestError() {
+	session := s.newSession()
+	defer session.Close()
+	err := session.SetProperty(context.Background(), "unknown", true)
+	var apiErr *common.APIError
+	s.Require().True(errors.As(err, &apiErr))
+	s.Equal(int64(2), apiErr.Code)
+
+	s.Require().NoError(session.Subscribe(context.Background(), []string{"btcusdt@trade"}, func(data []byte) {
+		s.Fail("event routed without the combined property")
+	}))
+	s.Require().NoError(session.SetProperty(context.Background(), "combined", false))
+	_, err = s.srv.Publish(binancetest.Spot, "btcusdt@trade", map[string]string{"e": "trade"})
+	s.Require().NoError(err)
+	s.Eventually(func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.errs) == 1
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (s *streamSessionTestSuite) TestClose() {
+	session := s.newSession()
+	session.Close()
+	select {
+	case <-session.Done():
+	case <-time.After(time.Second):
+		s.FailNow("session not closed")
+	}
+	_, err := session.ListSubscriptions(context.Background())
+	s.Equal(ErrSessionClosed, err)
+	s.Empty(s.errs)
+}
+
+func (s *streamSessionTestSuite) TestResubscribe() {
+	s.env.Reconnect = common.NewReconnectPolicy()
+	s.env.Reconnect.InitialBackoff = 10 * time.Millisecond
+	session := s.newSession()
+	defer session.Close()
+	s.Require().NoError(session.Subscribe(context.Background(), []string{"btcusdt@trade"}, func(data []byte) {}))
+
+	s.srv.DisconnectStreams()
+	s.waitSubscribers("btcusdt@trade", 0)
+	s.waitSubscribers("btcusdt@trade", 1)
+}
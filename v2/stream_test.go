@@ -0,0 +1,120 @@
+package binance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type streamTestSuite struct {
+	suite.Suite
+	srv *binancetest.Server
+	env *Environment
+}
+
+func TestStream(t *testing.T) {
+	suite.Run(t, new(streamTestSuite))
+}
+
+func (s *streamTestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.env = &Environment{
+		APIURL:      s.srv.URL,
+		WsURL:       s.srv.WsURL(binancetest.Spot),
+		CombinedURL: s.srv.CombinedURL(binancetest.Spot),
+	}
+}
+
+func (s *streamTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *streamTestSuite) TestAggTradeStream() {
+	stream, err := common.NewStream(func(h func(*WsAggTradeEvent), eh func(error)) (chan struct{}, chan struct{}, error) {
+		return s.env.WsAggTradeServe("BTCUSDT", h, eh)
+	})
+	s.Require().NoError(err)
+	s.Require().Eventually(func() bool {
+		return s.srv.Subscribers(binancetest.Spot, "btcusdt@aggTrade") == 1
+	}, time.Second, 5*time.Millisecond)
+
+	_, err = s.srv.Publish(binancetest.Spot, "btcusdt@aggTrade", map[string]interface{}{
+		"e": "aggTrade",
+		"s": "BTCUSDT",
+		"a": 1,
+		"p": "100.5",
+	})
+	s.Require().NoError(err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	event, err := stream.Next(ctx)
+	s.Require().NoError(err)
+	s.Equal("BTCUSDT", event.Symbol)
+	s.Equal(int64(1), event.AggTradeID)
+	s.Equal("100.5", event.Price)
+
+	stream.Close()
+	select {
+	case <-stream.Done():
+	case <-time.After(time.Second):
+		s.FailNow("stream not stopped")
+	}
+	_, err = stream.Next(ctx)
+	s.Equal(common.ErrStreamClosed, err)
+}
+
+func (s *streamTestSuite) TestAllMarketsStatStream() {
+	stream, err := common.NewStream(func(h func(WsAllMarketsStatEvent), eh func(error)) (chan struct{}, chan struct{}, error) {
+		return s.env.WsAllMarketsStatServe(h, eh)
+	})
+	s.Require().NoError(err)
+	defer stream.Close()
+	s.Require().Eventually(func() bool {
+		return s.srv.Subscribers(binancetest.Spot, "!ticker@arr") == 1
+	}, time.Second, 5*time.Millisecond)
+
+	_, err = s.srv.Publish(binancetest.Spot, "!ticker@arr", []map[string]string{{"e": "24hrTicker", "s": "BTCUSDT"}})
+	s.Require().NoError(err)
+	select {
+	case event := <-stream.C():
+		s.Require().Len(event, 1)
+		s.Equal("BTCUSDT", event[0].Symbol)
+	case <-time.After(time.Second):
+		s.FailNow("timeout waiting for an event")
+	}
+}
+
+func (s *streamTestSuite) TestTelemetry() {
+	s.env.Telemetry = common.NewStreamTelemetry()
+	stream, err := common.NewStream(func(h func(*WsTradeEvent), eh func(error)) (chan struct{}, chan struct{}, error) {
+		return s.env.WsTradeServe("BTCUSDT", h, eh)
+	})
+	s.Require().NoError(err)
+	defer stream.Close()
+	s.Require().Eventually(func() bool {
+		return s.srv.Subscribers(binancetest.Spot, "btcusdt@trade") == 1
+	}, time.Second, 5*time.Millisecond)
+
+	published := time.Now()
+	_, err = s.srv.Publish(binancetest.Spot, "btcusdt@trade", map[string]interface{}{
+		"e": "trade",
+		"E": published.UnixMilli(),
+		"s": "BTCUSDT",
+	})
+	s.Require().NoError(err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	event, err := stream.Next(ctx)
+	s.Require().NoError(err)
+
+	stats, ok := s.env.Telemetry.Stream("btcusdt@trade")
+	s.Require().True(ok)
+	s.Equal(int64(1), stats.Messages)
+	s.Equal(event.Time, stats.LastEventTime.UnixMilli())
+	s.False(event.ReceivedAt.Before(published.Truncate(time.Millisecond)))
+	s.WithinDuration(stats.LastReceived, event.ReceivedAt, 5*time.Millisecond)
+}
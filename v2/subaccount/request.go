@@ -1,115 +1,44 @@
 package subaccount
 
 import (
-	"fmt"
-	"io"
 	"net/http"
-	"net/url"
+
+	"github.com/pooyakn/go-binance/v2/internal/transport"
 )
 
-type secType int
+type secType = transport.SecType
 
 const (
-	secTypeNone secType = iota
-	secTypeAPIKey
-	secTypeSigned // if the 'timestamp' parameter is required
+	secTypeNone   = transport.SecTypeNone
+	secTypeAPIKey = transport.SecTypeAPIKey
+	secTypeSigned = transport.SecTypeSigned // if the 'timestamp' parameter is required
 )
 
-type params map[string]interface{}
+type params = transport.Params
 
 // request define an API request
-type request struct {
-	method     string
-	endpoint   string
-	query      url.Values
-	form       url.Values
-	recvWindow int64
-	secType    secType
-	header     http.Header
-	body       io.Reader
-	fullURL    string
-}
-
-// addParam add param with key/value to query string
-func (r *request) addParam(key string, value interface{}) *request {
-	if r.query == nil {
-		r.query = url.Values{}
-	}
-	r.query.Add(key, fmt.Sprintf("%v", value))
-	return r
-}
-
-// setParam set param with key/value to query string
-func (r *request) setParam(key string, value interface{}) *request {
-	if r.query == nil {
-		r.query = url.Values{}
-	}
-	r.query.Set(key, fmt.Sprintf("%v", value))
-	return r
-}
-
-// setParams set params with key/values to query string
-func (r *request) setParams(m params) *request {
-	for k, v := range m {
-		r.setParam(k, v)
-	}
-	return r
-}
-
-// setFormParam set param with key/value to request form body
-func (r *request) setFormParam(key string, value interface{}) *request {
-	if r.form == nil {
-		r.form = url.Values{}
-	}
-	r.form.Set(key, fmt.Sprintf("%v", value))
-	return r
-}
-
-// setFormParams set params with key/values to request form body
-func (r *request) setFormParams(m params) *request {
-	for k, v := range m {
-		r.setFormParam(k, v)
-	}
-	return r
-}
-
-func (r *request) validate() (err error) {
-	if r.query == nil {
-		r.query = url.Values{}
-	}
-	if r.form == nil {
-		r.form = url.Values{}
-	}
-	return nil
-}
+type request = transport.Request
 
 // RequestOption define option type for request
-type RequestOption func(*request)
+type RequestOption = transport.RequestOption
 
 // WithRecvWindow set recvWindow param for the request
 func WithRecvWindow(recvWindow int64) RequestOption {
-	return func(r *request) {
-		r.recvWindow = recvWindow
-	}
+	return transport.WithRecvWindow(recvWindow)
+}
+
+// WithWeight set the request weight reserved by the RateLimiter, e.g. for an
+// endpoint whose weight depends on parameters the service doesn't know of
+func WithWeight(weight int64) RequestOption {
+	return transport.WithWeight(weight)
 }
 
 // WithHeader set or add a header value to the request
 func WithHeader(key, value string, replace bool) RequestOption {
-	return func(r *request) {
-		if r.header == nil {
-			r.header = http.Header{}
-		}
-		if replace {
-			r.header.Set(key, value)
-		} else {
-			r.header.Add(key, value)
-		}
-	}
+	return transport.WithHeader(key, value, replace)
 }
 
 // WithHeaders set or replace the headers of the request
 func WithHeaders(header http.Header) RequestOption {
-	return func(r *request) {
-		r.header = header.Clone()
-	}
+	return transport.WithHeaders(header)
 }
@@ -35,25 +35,25 @@ func (g *GetSubAccountListService) Limit(v int) *GetSubAccountListService {
 
 func (g *GetSubAccountListService) Do(ctx context.Context) (res *GetSubAccountListResponse, err error) {
 	r := &request{
-		method:   "GET",
-		endpoint: "/sapi/v1/sub-account/list",
-		secType:  secTypeSigned,
+		Method:   "GET",
+		Endpoint: "/sapi/v1/sub-account/list",
+		SecType:  secTypeSigned,
 	}
 
 	if g.email != nil {
-		r.setParam("email", g.email)
+		r.SetParam("email", g.email)
 	}
 
 	if g.isFreeze != nil {
-		r.setParam("isFreeze", g.isFreeze)
+		r.SetParam("isFreeze", g.isFreeze)
 	}
 
 	if g.page != nil {
-		r.setParam("page", g.page)
+		r.SetParam("page", g.page)
 	}
 
 	if g.limit != nil {
-		r.setParam("limit", g.limit)
+		r.SetParam("limit", g.limit)
 	}
 
 	data, err := g.c.callAPI(ctx, r)
@@ -32,16 +32,16 @@ func (s *TransferToSubAccountService) Amount(amount string) *TransferToSubAccoun
 
 func (s *TransferToSubAccountService) transferToSubaccount(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
 	r := &request{
-		method:   "POST",
-		endpoint: endpoint,
-		secType:  secTypeSigned,
+		Method:   "POST",
+		Endpoint: endpoint,
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"toEmail": s.toEmail,
 		"asset":   s.asset,
 		"amount":  s.amount,
 	}
-	r.setParams(m)
+	r.SetParams(m)
 	data, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []byte{}, err
@@ -95,16 +95,16 @@ func (s *SubaccountDepositAddressService) Network(network string) *SubaccountDep
 
 func (s *SubaccountDepositAddressService) subaccountDepositAddress(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
 	r := &request{
-		method:   "GET",
-		endpoint: endpoint,
-		secType:  secTypeSigned,
+		Method:   "GET",
+		Endpoint: endpoint,
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"email":   s.email,
 		"coin":    s.coin,
 		"network": s.network,
 	}
-	r.setParams(m)
+	r.SetParams(m)
 	data, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []byte{}, err
@@ -146,14 +146,14 @@ func (s *SubaccountAssetsService) Email(email string) *SubaccountAssetsService {
 
 func (s *SubaccountAssetsService) subaccountAssets(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
 	r := &request{
-		method:   "GET",
-		endpoint: endpoint,
-		secType:  secTypeSigned,
+		Method:   "GET",
+		Endpoint: endpoint,
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"email": s.email,
 	}
-	r.setParams(m)
+	r.SetParams(m)
 	data, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []byte{}, err
@@ -211,21 +211,21 @@ func (s *SubaccountSpotSummaryService) Size(size int32) *SubaccountSpotSummarySe
 
 func (s *SubaccountSpotSummaryService) subaccountSpotSummary(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
 	r := &request{
-		method:   "GET",
-		endpoint: endpoint,
-		secType:  secTypeSigned,
+		Method:   "GET",
+		Endpoint: endpoint,
+		SecType:  secTypeSigned,
 	}
 
 	if s.size != nil {
-		r.setParam("size", *s.size)
+		r.SetParam("size", *s.size)
 	}
 
 	if s.page != nil {
-		r.setParam("page", *s.page)
+		r.SetParam("page", *s.page)
 	}
 
 	if s.email != nil {
-		r.setParam("email", *s.email)
+		r.SetParam("email", *s.email)
 	}
 	data, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -291,27 +291,27 @@ func (s *SubAccountListService) Limit(v int) *SubAccountListService {
 
 func (s *SubAccountListService) Do(ctx context.Context, opts ...RequestOption) (res *SubAccountList, err error) {
 	r := &request{
-		method:   "GET",
-		endpoint: "/sapi/v1/sub-account/list",
-		secType:  secTypeSigned,
+		Method:   "GET",
+		Endpoint: "/sapi/v1/sub-account/list",
+		SecType:  secTypeSigned,
 	}
 	if s.email != nil {
-		r.setParam("email", *s.email)
+		r.SetParam("email", *s.email)
 	}
 	if s.isFreeze {
-		r.setParam("isFreeze", "true")
+		r.SetParam("isFreeze", "true")
 	} else {
-		r.setParam("isFreeze", "false")
+		r.SetParam("isFreeze", "false")
 	}
 	if s.page > 0 {
-		r.setParam("page", s.page)
+		r.SetParam("page", s.page)
 	}
 	if s.limit > 200 {
-		r.setParam("limit", 200)
+		r.SetParam("limit", 200)
 	} else if s.limit <= 0 {
-		r.setParam("limit", 10)
+		r.SetParam("limit", 10)
 	} else {
-		r.setParam("limit", s.limit)
+		r.SetParam("limit", s.limit)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -369,14 +369,14 @@ type ManagedSubAccountDepositResponse struct {
 // Do send request
 func (s *ManagedSubAccountDepositService) Do(ctx context.Context, opts ...RequestOption) (*ManagedSubAccountDepositResponse, error) {
 	r := &request{
-		method:   "POST",
-		endpoint: "/sapi/v1/managed-subaccount/deposit",
-		secType:  secTypeSigned,
+		Method:   "POST",
+		Endpoint: "/sapi/v1/managed-subaccount/deposit",
+		SecType:  secTypeSigned,
 	}
 
-	r.setParam("toEmail", s.toEmail)
-	r.setParam("asset", s.asset)
-	r.setParam("amount", s.amount)
+	r.SetParam("toEmail", s.toEmail)
+	r.SetParam("asset", s.asset)
+	r.SetParam("amount", s.amount)
 
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -429,16 +429,16 @@ type ManagedSubAccountWithdrawalResponse struct {
 // Do send request
 func (s *ManagedSubAccountWithdrawalService) Do(ctx context.Context, opts ...RequestOption) (*ManagedSubAccountWithdrawalResponse, error) {
 	r := &request{
-		method:   "POST",
-		endpoint: "/sapi/v1/managed-subaccount/withdraw",
-		secType:  secTypeSigned,
+		Method:   "POST",
+		Endpoint: "/sapi/v1/managed-subaccount/withdraw",
+		SecType:  secTypeSigned,
 	}
 
-	r.setParam("fromEmail", s.fromEmail)
-	r.setParam("asset", s.asset)
-	r.setParam("amount", s.amount)
+	r.SetParam("fromEmail", s.fromEmail)
+	r.SetParam("asset", s.asset)
+	r.SetParam("amount", s.amount)
 	if s.transferDate > 0 {
-		r.setParam("transferDate", s.transferDate)
+		r.SetParam("transferDate", s.transferDate)
 	}
 
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -478,12 +478,12 @@ type ManagedSubAccountAsset struct {
 
 func (s *ManagedSubAccountAssetsService) Do(ctx context.Context, opts ...RequestOption) ([]*ManagedSubAccountAsset, error) {
 	r := &request{
-		method:   "GET",
-		endpoint: "/sapi/v1/managed-subaccount/asset",
-		secType:  secTypeSigned,
+		Method:   "GET",
+		Endpoint: "/sapi/v1/managed-subaccount/asset",
+		SecType:  secTypeSigned,
 	}
 
-	r.setParam("email", s.email)
+	r.SetParam("email", s.email)
 
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -512,12 +512,12 @@ func (s *SubAccountFuturesAccountService) Email(v string) *SubAccountFuturesAcco
 
 func (s *SubAccountFuturesAccountService) Do(ctx context.Context, opts ...RequestOption) (res *SubAccountFuturesAccount, err error) {
 	r := &request{
-		method:   "GET",
-		endpoint: "/sapi/v1/sub-account/futures/account",
-		secType:  secTypeSigned,
+		Method:   "GET",
+		Endpoint: "/sapi/v1/sub-account/futures/account",
+		SecType:  secTypeSigned,
 	}
 	if s.email != nil {
-		r.setParam("email", *s.email)
+		r.SetParam("email", *s.email)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -31,7 +31,7 @@ func (s *subAccountServiceTestSuite) TestSubaccountDepositAddressService() {
 	network := "a_network"
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"email":   email,
 			"coin":    coin,
 			"network": network,
@@ -75,7 +75,7 @@ func (s *subAccountServiceTestSuite) TestSubAccountListService() {
 	page := 1
 	limit := 10
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"email":    email,
 			"isFreeze": isFreeze,
 			"page":     1,
@@ -118,7 +118,7 @@ func (s *subAccountServiceTestSuite) TestSubManagedSubAccountDepositService() {
 	asset := "USDT"
 	amount := 1.0
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"toEmail": email,
 			"asset":   asset,
 			"amount":  amount,
@@ -147,7 +147,7 @@ func (s *subAccountServiceTestSuite) TestSubManagedSubAccountWithdrawalService()
 	asset := "USDT"
 	amount := 1.0
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"fromEmail": email,
 			"asset":     asset,
 			"amount":    amount,
@@ -185,7 +185,7 @@ func (s *subAccountServiceTestSuite) TestSubManagedSubAccountAssetsService() {
 
 	email := "testsub@gmail.com"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{"email": email})
+		e := newSignedRequest().SetParams(params{"email": email})
 		s.assertRequestEqual(e, r)
 	})
 
@@ -252,7 +252,7 @@ func (s *subAccountServiceTestSuite) TestSubAccountFuturesService() {
 
 	email := "abc@test.com"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{"email": email})
+		e := newSignedRequest().SetParams(params{"email": email})
 		s.assertRequestEqual(e, r)
 	})
 
@@ -22,11 +22,11 @@ func (s *ListBookTickersService) Symbol(symbol string) *ListBookTickersService {
 // Do send request
 func (s *ListBookTickersService) Do(ctx context.Context, opts ...RequestOption) (res []*BookTicker, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/ticker/bookTicker",
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/ticker/bookTicker",
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	data = common.ToJSONList(data)
@@ -66,14 +66,14 @@ func (s *ListPricesService) Symbol(symbol string) *ListPricesService {
 // Do send request
 func (s *ListPricesService) Do(ctx context.Context, opts ...RequestOption) (res []*SymbolPrice, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/ticker/price",
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/ticker/price",
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	} else if s.symbols != nil {
 		s, _ := json.Marshal(s.symbols)
-		r.setParam("symbols", string(s))
+		r.SetParam("symbols", string(s))
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -122,14 +122,14 @@ func (s *ListPricesService) Symbols(symbols []string) *ListPricesService {
 // Do send request
 func (s *ListPriceChangeStatsService) Do(ctx context.Context, opts ...RequestOption) (res []*PriceChangeStats, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/ticker/24hr",
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/ticker/24hr",
 	}
 
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	} else if s.symbols != nil {
-		r.setParam("symbols", s.symbols)
+		r.SetParam("symbols", s.symbols)
 	}
 
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -185,10 +185,10 @@ func (s *AveragePriceService) Symbol(symbol string) *AveragePriceService {
 // Do send request
 func (s *AveragePriceService) Do(ctx context.Context, opts ...RequestOption) (res *AvgPrice, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/avgPrice",
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/avgPrice",
 	}
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return res, err
@@ -262,18 +262,18 @@ func (s *ListSymbolTickerService) WindowSize(windowSize string) *ListSymbolTicke
 
 func (s *ListSymbolTickerService) Do(ctx context.Context, opts ...RequestOption) (res []*SymbolTicker, err error) {
 	r := &request{
-		method:   http.MethodGet,
-		endpoint: "/api/v3/ticker",
+		Method:   http.MethodGet,
+		Endpoint: "/api/v3/ticker",
 	}
 	if s.symbol != nil {
-		r.setParam("symbol", *s.symbol)
+		r.SetParam("symbol", *s.symbol)
 	} else if s.symbols != nil {
 		s, _ := json.Marshal(s.symbols)
-		r.setParam("symbols", string(s))
+		r.SetParam("symbols", string(s))
 	}
 
 	if s.windowSize != nil {
-		r.setParam("windowSize", *s.windowSize)
+		r.SetParam("windowSize", *s.windowSize)
 	}
 
 	data, err := s.c.callAPI(ctx, r, opts...)
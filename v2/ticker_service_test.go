@@ -75,7 +75,7 @@ func (s *tickerServiceTestSuite) TestSingleBookTicker() {
 	symbol := "LTCBTC"
 
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbol", symbol)
+		e := newRequest().SetParam("symbol", symbol)
 		s.assertRequestEqual(e, r)
 	})
 
@@ -163,7 +163,7 @@ func (s *tickerServiceTestSuite) TestListPricesForMultipleSymbols() {
 	symbols[1] = symbol2
 
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbols", `["ETHUSDT","LTCBTC"]`)
+		e := newRequest().SetParam("symbols", `["ETHUSDT","LTCBTC"]`)
 		s.assertRequestEqual(e, r)
 	})
 
@@ -193,7 +193,7 @@ func (s *tickerServiceTestSuite) TestListSinglePrice() {
 
 	symbol := "LTCBTC"
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbol", symbol)
+		e := newRequest().SetParam("symbol", symbol)
 		s.assertRequestEqual(e, r)
 	})
 
@@ -242,7 +242,7 @@ func (s *tickerServiceTestSuite) TestPriceChangeStats() {
 
 	symbol := "BNBBTC"
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbol", symbol)
+		e := newRequest().SetParam("symbol", symbol)
 		s.assertRequestEqual(e, r)
 	})
 	stats, err := s.client.NewListPriceChangeStatsService().Symbol(symbol).Do(newContext())
@@ -342,7 +342,7 @@ func (s *tickerServiceTestSuite) TestMultiplePriceChangeStats() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbols", `["BNBBTC","ETHBTC"]`)
+		e := newRequest().SetParam("symbols", `["BNBBTC","ETHBTC"]`)
 		s.assertRequestEqual(e, r)
 	})
 	res, err := s.client.NewListPriceChangeStatsService().Symbols([]string{"BNBBTC", "ETHBTC"}).Do(newContext())
@@ -484,7 +484,7 @@ func (s *tickerServiceTestSuite) TestAveragePrice() {
 
 	symbol := "LTCBTC"
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbol", symbol)
+		e := newRequest().SetParam("symbol", symbol)
 		s.assertRequestEqual(e, r)
 	})
 
@@ -529,7 +529,7 @@ func (s *tickerServiceTestSuite) TestListSymbolTicker() {
 	symbol := "ETHBTC"
 	windowSize := "1m" // 1 minute
 	s.assertReq(func(r *request) {
-		e := newRequest().setParam("symbol", symbol).setParam("windowSize", windowSize)
+		e := newRequest().SetParam("symbol", symbol).SetParam("windowSize", windowSize)
 		s.assertRequestEqual(e, r)
 	})
 
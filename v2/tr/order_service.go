@@ -86,9 +86,9 @@ func (s *CreateOrderService) IcebergQuantity(icebergQuantity string) *CreateOrde
 
 func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
 	r := &request{
-		method:   "POST",
-		endpoint: endpoint,
-		secType:  secTypeSigned,
+		Method:   "POST",
+		Endpoint: endpoint,
+		SecType:  secTypeSigned,
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -120,7 +120,7 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 		m["icebergQty"] = *s.icebergQuantity
 	}
 
-	r.setFormParams(m)
+	r.SetFormParams(m)
 
 	data, err = s.c.callAPI(ctx, r, opts...)
 
@@ -160,9 +160,9 @@ type GetOrderService struct {
 }
 
 type GetOrderDetailResponse struct {
-	Code int    `json:"code"`
-	Message  string `json:"msg"`
-	Data struct {
+	Code    int    `json:"code"`
+	Message string `json:"msg"`
+	Data    struct {
 		OrderID          string          `json:"orderId"`
 		Symbol           string          `json:"symbol"`
 		SymbolType       SymbolType      `json:"symbolType"`
@@ -193,13 +193,13 @@ func (s *GetOrderService) OrderID(orderID int64) *GetOrderService {
 // Do send request
 func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *GetOrderDetailResponse, err error) {
 	r := &request{
-		method:   "GET",
-		endpoint: "/open/v1/orders/detail",
-		secType:  secTypeSigned,
+		Method:   "GET",
+		Endpoint: "/open/v1/orders/detail",
+		SecType:  secTypeSigned,
 	}
 
 	if s.orderID != nil {
-		r.setParam("orderId", *s.orderID)
+		r.SetParam("orderId", *s.orderID)
 	}
 
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -232,12 +232,12 @@ type CancelOrderResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"msg"`
 	Data    *struct {
-		OrderID string `json:"orderId"`
-		Symbol string    `json:"symbol"`
-		Type   OrderType `json:"type"`
-		Side   SideType  `json:"side"`
-		Price  string    `json:"price"`
-		OrigQty string `json:"origQty"`
+		OrderID string    `json:"orderId"`
+		Symbol  string    `json:"symbol"`
+		Type    OrderType `json:"type"`
+		Side    SideType  `json:"side"`
+		Price   string    `json:"price"`
+		OrigQty string    `json:"origQty"`
 	} `json:"data"`
 	Timestamp int64 `json:"timestamp"`
 }
@@ -251,12 +251,12 @@ func (s *CancelOrderService) OrderID(orderID int64) *CancelOrderService {
 // Do send request
 func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CancelOrderResponse, err error) {
 	r := &request{
-		method:   "POST",
-		endpoint: "/open/v1/orders/cancel",
-		secType:  secTypeSigned,
+		Method:   "POST",
+		Endpoint: "/open/v1/orders/cancel",
+		SecType:  secTypeSigned,
 	}
 
-	r.setParam("orderId", s.orderID)
+	r.SetParam("orderId", s.orderID)
 
 	data, err := s.c.callAPI(ctx, r, opts...)
 
@@ -370,39 +370,39 @@ func (s *ListOrdersService) Limit(limit int) *ListOrdersService {
 // Do send request
 func (s *ListOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *ListOrdersResponse, err error) {
 	r := &request{
-		method:   "GET",
-		endpoint: "/open/v1/orders",
-		secType:  secTypeSigned,
+		Method:   "GET",
+		Endpoint: "/open/v1/orders",
+		SecType:  secTypeSigned,
 	}
 
-	r.setParam("symbol", s.symbol)
+	r.SetParam("symbol", s.symbol)
 
 	if s.listOrdersType != nil {
-		r.setParam("type", *s.listOrdersType)
+		r.SetParam("type", *s.listOrdersType)
 	}
 
 	if s.side != nil {
-		r.setParam("side", *s.side)
+		r.SetParam("side", *s.side)
 	}
 
 	if s.startTime != nil {
-		r.setParam("startTime", *s.startTime)
+		r.SetParam("startTime", *s.startTime)
 	}
 
 	if s.endTime != nil {
-		r.setParam("endTime", *s.endTime)
+		r.SetParam("endTime", *s.endTime)
 	}
 
 	if s.fromId != nil {
-		r.setParam("fromId", *s.fromId)
+		r.SetParam("fromId", *s.fromId)
 	}
 
 	if s.direct != nil {
-		r.setParam("direct", *s.direct)
+		r.SetParam("direct", *s.direct)
 	}
 
 	if s.limit != nil {
-		r.setParam("limit", *s.limit)
+		r.SetParam("limit", *s.limit)
 	}
 
 	data, err := s.c.callAPI(ctx, r, opts...)
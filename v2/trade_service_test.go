@@ -41,7 +41,7 @@ func (s *tradeServiceTestSuite) TestListTrades() {
 	startTime := int64(1499865549590)
 	endTime := int64(1499865549590)
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"symbol":    symbol,
 			"startTime": startTime,
 			"endTime":   endTime,
@@ -97,7 +97,7 @@ func (s *tradeServiceTestSuite) TestAggregateTrades() {
 	endTime := int64(1498793709156)
 	limit := 1
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"symbol":    symbol,
 			"fromId":    fromID,
 			"startTime": startTime,
@@ -157,7 +157,7 @@ func (s *tradeServiceTestSuite) TestHistoricalTrades() {
 	limit := 3
 	fromID := int64(1)
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"symbol": symbol,
 			"limit":  limit,
 			"fromId": fromID,
@@ -200,7 +200,7 @@ func (s *tradeServiceTestSuite) TestRecentTrades() {
 	symbol := "LTCBTC"
 	limit := 3
 	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
+		e := newRequest().SetParams(params{
 			"symbol": symbol,
 			"limit":  limit,
 		})
@@ -0,0 +1,235 @@
+package binance
+
+import (
+	"context"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/internal/userstream"
+)
+
+// UserDataSnapshot is the state of the account fetched by a user data stream
+// once connected, initially and after every reconnection, to reconcile the
+// events missed in the meantime
+type UserDataSnapshot struct {
+	// Account is set for the spot user data streams
+	Account *Account
+	// MarginAccount is set for the cross margin user data streams
+	MarginAccount *MarginAccount
+	// IsolatedMarginAccount is set for the isolated margin user data streams
+	IsolatedMarginAccount *IsolatedMarginAccount
+	OpenOrders            []*Order
+}
+
+// ErrUserDataStreamStarted is returned by UserDataStream.Start while the stream runs
+var ErrUserDataStreamStarted = userstream.ErrStreamStarted
+
+// UserDataSnapshotHandler handles the snapshots of a user data stream
+type UserDataSnapshotHandler func(snapshot *UserDataSnapshot)
+
+// userDataStreamKind define the account of a user data stream
+type userDataStreamKind int
+
+const (
+	userDataStreamSpot userDataStreamKind = iota
+	userDataStreamMargin
+	userDataStreamIsolatedMargin
+)
+
+// UserDataStream maintains the user data stream of an account: it owns the
+// listen key, keeps it alive, creates a new one once expired, reconnects the
+// stream with the reconnect policy of the environment and fetches a snapshot
+// of the account and of its open orders after every connection
+type UserDataStream struct {
+	c                 *Client
+	kind              userDataStreamKind
+	symbol            string
+	handler           WsUserDataHandler
+	errHandler        ErrHandler
+	snapshotHandler   UserDataSnapshotHandler
+	keepaliveInterval time.Duration
+
+	stream *userstream.Stream
+	cancel context.CancelFunc
+}
+
+// NewUserDataStream init the user data stream of the spot account
+func (c *Client) NewUserDataStream() *UserDataStream {
+	return &UserDataStream{c: c, kind: userDataStreamSpot, keepaliveInterval: 30 * time.Minute}
+}
+
+// NewMarginUserDataStream init the user data stream of the cross margin account
+func (c *Client) NewMarginUserDataStream() *UserDataStream {
+	return &UserDataStream{c: c, kind: userDataStreamMargin, keepaliveInterval: 30 * time.Minute}
+}
+
+// NewIsolatedMarginUserDataStream init the user data stream of the isolated margin account of symbol
+func (c *Client) NewIsolatedMarginUserDataStream(symbol string) *UserDataStream {
+	return &UserDataStream{c: c, kind: userDataStreamIsolatedMargin, symbol: symbol, keepaliveInterval: 30 * time.Minute}
+}
+
+// OnEvent set the handler called with the events of the stream
+func (s *UserDataStream) OnEvent(handler WsUserDataHandler) *UserDataStream {
+	s.handler = handler
+	return s
+}
+
+// OnError set the handler called with the errors of the stream, of the listen
+// key requests and of the snapshots
+func (s *UserDataStream) OnError(errHandler ErrHandler) *UserDataStream {
+	s.errHandler = errHandler
+	return s
+}
+
+// OnSnapshot set the handler called with the snapshot fetched after every
+// connection. The events received while fetching it are handled meanwhile.
+func (s *UserDataStream) OnSnapshot(handler UserDataSnapshotHandler) *UserDataStream {
+	s.snapshotHandler = handler
+	return s
+}
+
+// KeepaliveInterval set the delay between two keepalives of the listen key, 30 minutes by default
+func (s *UserDataStream) KeepaliveInterval(interval time.Duration) *UserDataStream {
+	s.keepaliveInterval = interval
+	return s
+}
+
+// Start creates a listen key and connects its stream, with the environment
+// of the client, then maintains it until Close is called or ctx is done.
+// The listen key is closed once stopped. It returns ErrUserDataStreamStarted
+// until the previous run is Done.
+func (s *UserDataStream) Start(ctx context.Context) error {
+	select {
+	case <-s.stream.Done():
+	default:
+		return ErrUserDataStreamStarted
+	}
+	env := s.c.Environment
+	if env == nil {
+		env = DefaultEnvironment()
+	}
+	errHandler := s.errHandler
+	if errHandler == nil {
+		errHandler = func(err error) {}
+	}
+	handler := s.handler
+	if handler == nil {
+		handler = func(event *WsUserDataEvent) {}
+	}
+	// the stream reconnects itself, as the listen key may have to be renewed
+	policy := env.Reconnect
+	connEnv := *env
+	connEnv.Reconnect = nil
+
+	ctx, cancel := context.WithCancel(ctx)
+	s.stream = userstream.New(userstream.Config{
+		StartListenKey:     s.startListenKey,
+		KeepaliveListenKey: s.keepaliveListenKey,
+		CloseListenKey:     s.closeListenKey,
+		Serve: func(ctx context.Context, listenKey string) (<-chan struct{}, error) {
+			doneC, _, err := connEnv.WithContext(ctx).WsUserDataServe(listenKey, func(event *WsUserDataEvent) {
+				if event.Event == UserDataEventTypeListenKeyExpired {
+					s.stream.Expired(listenKey)
+				}
+				handler(event)
+			}, errHandler)
+			return doneC, err
+		},
+		Snapshot: func(ctx context.Context) {
+			snapshot, err := s.snapshot(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					errHandler(err)
+				}
+				return
+			}
+			if s.snapshotHandler != nil {
+				s.snapshotHandler(snapshot)
+			}
+		},
+		KeepaliveInterval: s.keepaliveInterval,
+		Reconnect:         policy,
+		ErrHandler:        errHandler,
+		Logger:            WebsocketLogger,
+	})
+	s.cancel = cancel
+	if err := s.stream.Start(ctx); err != nil {
+		cancel()
+		return err
+	}
+	return nil
+}
+
+func (s *UserDataStream) startListenKey(ctx context.Context) (string, error) {
+	switch s.kind {
+	case userDataStreamMargin:
+		return s.c.NewStartMarginUserStreamService().Do(ctx)
+	case userDataStreamIsolatedMargin:
+		return s.c.NewStartIsolatedMarginUserStreamService().Symbol(s.symbol).Do(ctx)
+	default:
+		return s.c.NewStartUserStreamService().Do(ctx)
+	}
+}
+
+func (s *UserDataStream) keepaliveListenKey(ctx context.Context, listenKey string) error {
+	switch s.kind {
+	case userDataStreamMargin:
+		return s.c.NewKeepaliveMarginUserStreamService().ListenKey(listenKey).Do(ctx)
+	case userDataStreamIsolatedMargin:
+		return s.c.NewKeepaliveIsolatedMarginUserStreamService().Symbol(s.symbol).ListenKey(listenKey).Do(ctx)
+	default:
+		return s.c.NewKeepaliveUserStreamService().ListenKey(listenKey).Do(ctx)
+	}
+}
+
+func (s *UserDataStream) closeListenKey(ctx context.Context, listenKey string) error {
+	switch s.kind {
+	case userDataStreamMargin:
+		return s.c.NewCloseMarginUserStreamService().ListenKey(listenKey).Do(ctx)
+	case userDataStreamIsolatedMargin:
+		return s.c.NewCloseIsolatedMarginUserStreamService().Symbol(s.symbol).ListenKey(listenKey).Do(ctx)
+	default:
+		return s.c.NewCloseUserStreamService().ListenKey(listenKey).Do(ctx)
+	}
+}
+
+func (s *UserDataStream) snapshot(ctx context.Context) (snapshot *UserDataSnapshot, err error) {
+	snapshot = new(UserDataSnapshot)
+	switch s.kind {
+	case userDataStreamMargin:
+		if snapshot.MarginAccount, err = s.c.NewGetMarginAccountService().Do(ctx); err != nil {
+			return nil, err
+		}
+		snapshot.OpenOrders, err = s.c.NewListMarginOpenOrdersService().Do(ctx)
+	case userDataStreamIsolatedMargin:
+		if snapshot.IsolatedMarginAccount, err = s.c.NewGetIsolatedMarginAccountService().Symbols(s.symbol).Do(ctx); err != nil {
+			return nil, err
+		}
+		snapshot.OpenOrders, err = s.c.NewListMarginOpenOrdersService().IsIsolated(true).Symbol(s.symbol).Do(ctx)
+	default:
+		if snapshot.Account, err = s.c.NewGetAccountService().Do(ctx); err != nil {
+			return nil, err
+		}
+		snapshot.OpenOrders, err = s.c.NewListOpenOrdersService().Do(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// ListenKey returns the current listen key of the stream, empty until started
+func (s *UserDataStream) ListenKey() string {
+	return s.stream.ListenKey()
+}
+
+// Close stops the stream and closes its listen key, it does nothing before Start
+func (s *UserDataStream) Close() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// Done returns a channel closed once the stream stopped, or if not started
+func (s *UserDataStream) Done() <-chan struct{} {
+	return s.stream.Done()
+}
@@ -0,0 +1,130 @@
+package binance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/stretchr/testify/suite"
+)
+
+type userDataStreamTestSuite struct {
+	suite.Suite
+	srv    *binancetest.Server
+	client *Client
+}
+
+func TestUserDataStream(t *testing.T) {
+	suite.Run(t, new(userDataStreamTestSuite))
+}
+
+func (s *userDataStreamTestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.client = NewClientWithEnvironment(s.srv.APIKey, s.srv.SecretKey, &Environment{
+		APIURL: s.srv.URL,
+		WsURL:  s.srv.WsURL(binancetest.Spot),
+	})
+}
+
+func (s *userDataStreamTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *userDataStreamTestSuite) waitSnapshot(snapshots <-chan *UserDataSnapshot) *UserDataSnapshot {
+	select {
+	case snapshot := <-snapshots:
+		return snapshot
+	case <-time.After(time.Second):
+		s.FailNow("timeout waiting for a snapshot")
+		return nil
+	}
+}
+
+func (s *userDataStreamTestSuite) waitExecutionReport(events <-chan *WsUserDataEvent) *WsUserDataEvent {
+	for {
+		select {
+		case event := <-events:
+			if event.Event == UserDataEventTypeExecutionReport {
+				return event
+			}
+		case <-time.After(time.Second):
+			s.FailNow("timeout waiting for an execution report")
+			return nil
+		}
+	}
+}
+
+func (s *userDataStreamTestSuite) createOrder(ctx context.Context, price string) {
+	_, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).
+		Quantity("0.1").Price(price).Do(ctx)
+	s.Require().NoError(err)
+}
+
+func (s *userDataStreamTestSuite) TestUserDataStream() {
+	ctx := context.Background()
+	s.srv.SetBalance(binancetest.Spot, "BTC", "1")
+	s.createOrder(ctx, "100")
+
+	events := make(chan *WsUserDataEvent, 10)
+	snapshots := make(chan *UserDataSnapshot, 10)
+	stream := s.client.NewUserDataStream().OnEvent(func(event *WsUserDataEvent) {
+		events <- event
+	}).OnSnapshot(func(snapshot *UserDataSnapshot) {
+		snapshots <- snapshot
+	})
+	s.Require().NoError(stream.Start(ctx))
+	s.Equal(ErrUserDataStreamStarted, stream.Start(ctx))
+	listenKey := stream.ListenKey()
+	s.Len(listenKey, 60)
+
+	snapshot := s.waitSnapshot(snapshots)
+	s.Require().NotNil(snapshot.Account)
+	s.Nil(snapshot.MarginAccount)
+	s.Len(snapshot.OpenOrders, 1)
+	s.createOrder(ctx, "101")
+	s.Equal("NEW", s.waitExecutionReport(events).OrderUpdate.ExecutionType)
+
+	// the listen key is kept once the connection is lost
+	s.srv.DisconnectStreams()
+	snapshot = s.waitSnapshot(snapshots)
+	s.Len(snapshot.OpenOrders, 2)
+	s.Equal(listenKey, stream.ListenKey())
+	s.createOrder(ctx, "102")
+	s.Equal("102.00000000", s.waitExecutionReport(events).OrderUpdate.Price)
+
+	// a new listen key is created once expired
+	s.Equal(1, s.srv.ExpireListenKeys(binancetest.Spot))
+	s.waitSnapshot(snapshots)
+	s.NotEqual(listenKey, stream.ListenKey())
+	s.createOrder(ctx, "103")
+	s.Equal("103.00000000", s.waitExecutionReport(events).OrderUpdate.Price)
+
+	listenKey = stream.ListenKey()
+	stream.Close()
+	select {
+	case <-stream.Done():
+	case <-time.After(time.Second):
+		s.FailNow("stream not stopped")
+	}
+	err := s.client.NewKeepaliveUserStreamService().ListenKey(listenKey).Do(ctx)
+	s.Error(err, "listen key not closed")
+}
+
+func (s *userDataStreamTestSuite) TestStartFailure() {
+	s.client.APIKey = "invalid"
+	stream := s.client.NewUserDataStream()
+	err := stream.Start(context.Background())
+	s.Error(err)
+	s.Empty(stream.ListenKey())
+	stream.Close()
+	<-stream.Done()
+}
+
+func (s *userDataStreamTestSuite) TestNotStarted() {
+	stream := s.client.NewUserDataStream()
+	s.Empty(stream.ListenKey())
+	stream.Close()
+	<-stream.Done()
+}
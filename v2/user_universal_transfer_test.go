@@ -30,7 +30,7 @@ func (s *userUniversalTransferTestSuite) TestUserUniversalTransfer() {
 	toSymbol := "USDT"
 
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"type":       types,
 			"asset":      asset,
 			"amount":     amount,
@@ -1,11 +1,14 @@
 package binance
 
 import (
+	"context"
 	"crypto/tls"
 	"net/http"
+	"net/url"
 	"time"
 
-	"github.com/gorilla/websocket"
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/pooyakn/go-binance/v2/internal/wsconn"
 )
 
 var tlsConfig = &tls.Config{}
@@ -19,6 +22,25 @@ type ErrHandler func(err error)
 // WsConfig webservice configuration
 type WsConfig struct {
 	Endpoint string
+	// TLSConfig is used by the connection, the default configuration is used when nil
+	TLSConfig *tls.Config
+	// Proxy returns the proxy URL of the connection, http.ProxyFromEnvironment is used when nil
+	Proxy func(*http.Request) (*url.URL, error)
+	// Reconnect enables reconnecting the stream after its connection is lost when set
+	Reconnect *common.ReconnectPolicy
+	// Context is used to dial the connection, the stream is stopped once it
+	// is done. context.Background() is used when nil.
+	Context context.Context
+	// Dispatch enables passing the messages to the handler through a bounded
+	// queue, from another goroutine than the reads, when set
+	Dispatch *common.DispatchPolicy
+	// Options override the options of the connection when set
+	Options *common.WsConnOptions
+	// Telemetry records the receive times, the latencies and the rates of the messages when set
+	Telemetry *common.StreamTelemetry
+
+	// clock holds the receive time of the message being handled
+	clock *wsconn.ReceiveClock
 }
 
 // SetTLSConfig sets the tls.Config for the websocket connection
@@ -26,83 +48,57 @@ func SetTLSConfig(config *tls.Config) {
 	tlsConfig = config
 }
 
-func newWsConfig(endpoint string) *WsConfig {
+func (e *Environment) newWsConfig(endpoint string) *WsConfig {
 	return &WsConfig{
-		Endpoint: endpoint,
+		Endpoint:  endpoint,
+		TLSConfig: e.TLSConfig,
+		Proxy:     e.Proxy,
+		Reconnect: e.Reconnect,
+		Context:   e.ctx,
+		Dispatch:  e.Dispatch,
+		Options:   e.WsOptions,
+		Telemetry: e.Telemetry,
+		clock:     new(wsconn.ReceiveClock),
 	}
 }
 
-var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	Dialer := websocket.Dialer{
-		Proxy:             http.ProxyFromEnvironment,
-		HandshakeTimeout:  45 * time.Second,
-		EnableCompression: false,
-		TLSClientConfig:   tlsConfig,
+// connConfig returns the connection settings of cfg, along with the package level settings
+func (cfg *WsConfig) connConfig() *wsconn.Config {
+	return &wsconn.Config{
+		Endpoint:  cfg.Endpoint,
+		TLSConfig: cfg.TLSConfig,
+		Proxy:     cfg.Proxy,
+		Keepalive: WebsocketKeepalive,
+		Timeout:   WebsocketTimeout,
+		Reconnect: cfg.Reconnect,
+		Logger:    WebsocketLogger,
+		Context:   cfg.Context,
+		Dispatch:  cfg.Dispatch,
+		Options:   cfg.Options,
+		Telemetry: cfg.Telemetry,
+		Clock:     cfg.clock,
 	}
-
-	c, _, err := Dialer.Dial(cfg.Endpoint, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-	c.SetReadLimit(655350)
-	doneC = make(chan struct{})
-	stopC = make(chan struct{})
-	go func() {
-		// This function will exit either on error from
-		// websocket.Conn.ReadMessage or when the stopC channel is
-		// closed by the client.
-		defer close(doneC)
-		if WebsocketKeepalive {
-			keepAlive(c, WebsocketTimeout)
-		}
-		// Wait for the stopC channel to be closed.  We do that in a
-		// separate goroutine because ReadMessage is a blocking
-		// operation.
-		silent := false
-		go func() {
-			select {
-			case <-stopC:
-				silent = true
-			case <-doneC:
-			}
-			c.Close()
-		}()
-		for {
-			_, message, err := c.ReadMessage()
-			if err != nil {
-				if !silent {
-					errHandler(err)
-				}
-				return
-			}
-			handler(message)
-		}
-	}()
-	return
 }
 
-func keepAlive(c *websocket.Conn, timeout time.Duration) {
-	ticker := time.NewTicker(timeout)
+// stamp sets the receive time of the events decoded from the message being
+// handled by the stream of cfg
+func (cfg *WsConfig) stamp(event interface{}) {
+	stampEvent(cfg.clock, event)
+}
 
-	lastResponse := time.Now()
-	c.SetPongHandler(func(msg string) error {
-		lastResponse = time.Now()
-		return nil
-	})
+// stampEvent sets the receive time of the events decoded from the message
+// being handled, as held by clock
+func stampEvent(clock *wsconn.ReceiveClock, event interface{}) {
+	var received time.Time
+	if clock != nil {
+		received = clock.Time()
+	}
+	if received.IsZero() {
+		received = time.Now()
+	}
+	common.SetReceivedAt(event, received)
+}
 
-	go func() {
-		defer ticker.Stop()
-		for {
-			deadline := time.Now().Add(10 * time.Second)
-			err := c.WriteControl(websocket.PingMessage, []byte{}, deadline)
-			if err != nil {
-				return
-			}
-			<-ticker.C
-			if time.Since(lastResponse) > timeout {
-				c.Close()
-				return
-			}
-		}
-	}()
+var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsconn.Serve(cfg.connConfig(), handler, errHandler)
 }
@@ -0,0 +1,186 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/pooyakn/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsAPITestSuite struct {
+	suite.Suite
+	srv    *binancetest.Server
+	client *Client
+}
+
+func TestWsAPIClient(t *testing.T) {
+	suite.Run(t, new(wsAPITestSuite))
+}
+
+func (s *wsAPITestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.client = NewClientWithEnvironment(s.srv.APIKey, s.srv.SecretKey, &Environment{
+		APIURL:   s.srv.URL,
+		WsAPIURL: s.srv.WsAPIURL(binancetest.Spot),
+	})
+}
+
+func (s *wsAPITestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *wsAPITestSuite) newWsAPIClient() *WsAPIClient {
+	c, err := s.client.NewWsAPIClient(func(err error) {
+		s.Fail(err.Error())
+	})
+	s.Require().NoError(err)
+	return c
+}
+
+// close closes c and waits for its connection to be closed, before the server
+func (s *wsAPITestSuite) close(c *WsAPIClient) {
+	c.Close()
+	<-c.Done()
+}
+
+func (s *wsAPITestSuite) TestOrders() {
+	ctx := context.Background()
+	c := s.newWsAPIClient()
+	defer s.close(c)
+	s.srv.SetBalance(binancetest.Spot, "USDT", "1000")
+	_, err := s.srv.PlaceOrder(binancetest.Spot, "BTCUSDT", "SELL", "100", "1")
+	s.Require().NoError(err)
+
+	res, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).
+		Quantity("2").Price("101").Do(ctx)
+	s.Require().NoError(err)
+	s.Equal(OrderStatusTypePartiallyFilled, res.Status)
+	s.Equal("1.00000000", res.ExecutedQuantity)
+
+	order, err := c.NewGetOrderService().Symbol("BTCUSDT").OrderID(res.OrderID).Do(ctx)
+	s.Require().NoError(err)
+	s.Equal(res.OrderID, order.OrderID)
+
+	orders, err := c.NewListOpenOrdersService().Symbol("BTCUSDT").Do(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(orders, 1)
+
+	_, err = c.NewCancelOrderService().Symbol("BTCUSDT").OrderID(res.OrderID).Do(ctx)
+	s.Require().NoError(err)
+	_, err = c.NewCancelOrderService().Symbol("BTCUSDT").OrderID(res.OrderID).Do(ctx)
+	s.True(errors.Is(err, common.ErrUnknownOrder), err)
+}
+
+func (s *wsAPITestSuite) TestInvalidSignature() {
+	s.client.SecretKey = "invalid"
+	c := s.newWsAPIClient()
+	defer s.close(c)
+	_, err := c.NewGetAccountService().Do(context.Background())
+	var apiErr *common.APIError
+	s.Require().True(errors.As(err, &apiErr), err)
+	s.Equal(int64(-1022), apiErr.Code)
+	s.Equal("GET", apiErr.Method)
+	s.Equal("/api/v3/account", apiErr.Endpoint)
+}
+
+func (s *wsAPITestSuite) TestUnavailableEndpoint() {
+	c := s.newWsAPIClient()
+	defer s.close(c)
+	_, err := c.c.NewListTradesService().Symbol("BTCUSDT").Do(context.Background())
+	s.Error(err)
+}
+
+func (s *wsAPITestSuite) TestRateLimits() {
+	s.client.RateLimiter = common.NewRateLimiter()
+	c := s.newWsAPIClient()
+	defer s.close(c)
+	for i := 0; i < 2; i++ {
+		s.Require().NoError(c.NewPingService().Do(context.Background()))
+	}
+	s.Equal(int64(2), s.client.RateLimiter.Used(common.RateLimitTypeRequestWeight, time.Minute))
+}
+
+func (s *wsAPITestSuite) TestLogon() {
+	ctx := context.Background()
+	c := s.newWsAPIClient()
+	defer s.close(c)
+	s.Require().NoError(c.Logon(ctx))
+
+	// authenticated requests are neither signed nor sent with the API key
+	c.c.APIKey = "unused"
+	c.c.SecretKey = "unused"
+	_, err := c.NewGetAccountService().Do(ctx)
+	s.Require().NoError(err)
+
+	s.Require().NoError(c.Logout(ctx))
+	_, err = c.NewGetAccountService().Do(ctx)
+	s.Error(err)
+}
+
+func (s *wsAPITestSuite) TestMiddlewares() {
+	var reqs []*common.RequestInfo
+	var ress []*common.ResponseInfo
+	s.client.Middlewares = []common.Middleware{{
+		BeforeSend: func(ctx context.Context, req *common.RequestInfo) (context.Context, error) {
+			reqs = append(reqs, req)
+			return ctx, nil
+		},
+		AfterReceive: func(ctx context.Context, req *common.RequestInfo, res *common.ResponseInfo) {
+			ress = append(ress, res)
+		},
+	}}
+	c := s.newWsAPIClient()
+	defer s.close(c)
+	s.Require().NoError(c.NewPingService().Do(context.Background()))
+	s.Require().Len(reqs, 1)
+	s.Equal("/api/v3/ping", reqs[0].Endpoint)
+	s.Equal(int64(1), reqs[0].Weight)
+	s.Equal(1, reqs[0].Attempt)
+	s.Require().Len(ress, 1)
+	s.Equal(200, ress[0].StatusCode)
+	s.Equal(int64(1), ress[0].UsedWeight)
+	s.NoError(ress[0].Err)
+}
+
+func (s *wsAPITestSuite) TestTimeSyncResync() {
+	var calls int32
+	s.client.TimeSync = common.NewTimeSync(func(ctx context.Context) (int64, error) {
+		// the first sync measures a wrong drift, the resync a right one
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return currentTimestamp() - 60000, nil
+		}
+		return currentTimestamp(), nil
+	})
+	s.client.TimeSync.Samples = 1
+	s.Require().NoError(s.client.TimeSync.Sync(context.Background()))
+	c := s.newWsAPIClient()
+	defer s.close(c)
+	_, err := c.NewGetAccountService().Do(context.Background())
+	s.Require().NoError(err)
+	s.Equal(int32(2), atomic.LoadInt32(&calls))
+}
+
+func (s *wsAPITestSuite) TestRetryPolicy() {
+	s.client.RetryPolicy = common.NewRetryPolicy()
+	s.client.RetryPolicy.InitialBackoff = time.Millisecond
+	var attempts []int
+	s.client.Middlewares = []common.Middleware{{
+		BeforeSend: func(ctx context.Context, req *common.RequestInfo) (context.Context, error) {
+			attempts = append(attempts, req.Attempt)
+			if req.Attempt == 1 {
+				return ctx, &common.APIError{Code: -1021, Message: "Timestamp for this request is outside of the recvWindow."}
+			}
+			return ctx, nil
+		},
+	}}
+	c := s.newWsAPIClient()
+	defer s.close(c)
+	s.Require().NoError(c.NewPingService().Do(context.Background()))
+	s.Equal([]int{1, 2}, attempts)
+}
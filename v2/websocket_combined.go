@@ -0,0 +1,291 @@
+package binance
+
+import (
+	"fmt"
+	"strings"
+
+	stdjson "encoding/json"
+)
+
+// WsMiniMarketsStatHandler handle websocket mini-ticker event of a market
+type WsMiniMarketsStatHandler func(event *WsMiniMarketsStatEvent)
+
+// WsUnknownStreamHandler handle the payload of a combined stream which is not
+// routed to a typed handler
+type WsUnknownStreamHandler func(stream string, data []byte)
+
+// WsCombinedRouter routes the events of a combined stream carrying several
+// types of streams, e.g. trades, depths and klines, to the handler of their
+// type. The type of an event is told by its stream name, or by its "e" field
+// for the stream names it does not know. The events of the all market
+// streams, e.g. "!ticker@arr", are passed one by one.
+//
+// The payloads of the streams of unknown type, or of a type without handler,
+// are passed to the unknown handler, to the error handler of the stream when
+// none is set.
+type WsCombinedRouter struct {
+	trade          WsTradeHandler
+	aggTrade       WsAggTradeHandler
+	depth          WsDepthHandler
+	partialDepth   WsPartialDepthHandler
+	kline          WsKlineHandler
+	bookTicker     WsBookTickerHandler
+	marketStat     WsMarketStatHandler
+	miniMarketStat WsMiniMarketsStatHandler
+	rollingWindow  WsRollingWindowStatHandler
+	avgPrice       WsAvgPriceHandler
+	unknown        WsUnknownStreamHandler
+}
+
+// NewWsCombinedRouter init a router without handlers
+func NewWsCombinedRouter() *WsCombinedRouter {
+	return &WsCombinedRouter{}
+}
+
+// OnTrade set the handler of the "<symbol>@trade" streams
+func (r *WsCombinedRouter) OnTrade(handler WsTradeHandler) *WsCombinedRouter {
+	r.trade = handler
+	return r
+}
+
+// OnAggTrade set the handler of the "<symbol>@aggTrade" streams
+func (r *WsCombinedRouter) OnAggTrade(handler WsAggTradeHandler) *WsCombinedRouter {
+	r.aggTrade = handler
+	return r
+}
+
+// OnDepth set the handler of the "<symbol>@depth" and "<symbol>@depth@100ms" streams
+func (r *WsCombinedRouter) OnDepth(handler WsDepthHandler) *WsCombinedRouter {
+	r.depth = handler
+	return r
+}
+
+// OnPartialDepth set the handler of the "<symbol>@depth<levels>" streams, the
+// symbol of the events is taken from the stream name
+func (r *WsCombinedRouter) OnPartialDepth(handler WsPartialDepthHandler) *WsCombinedRouter {
+	r.partialDepth = handler
+	return r
+}
+
+// OnKline set the handler of the "<symbol>@kline_<interval>" streams, with or
+// without timezone
+func (r *WsCombinedRouter) OnKline(handler WsKlineHandler) *WsCombinedRouter {
+	r.kline = handler
+	return r
+}
+
+// OnBookTicker set the handler of the "<symbol>@bookTicker" and "!bookTicker" streams
+func (r *WsCombinedRouter) OnBookTicker(handler WsBookTickerHandler) *WsCombinedRouter {
+	r.bookTicker = handler
+	return r
+}
+
+// OnMarketStat set the handler of the "<symbol>@ticker" and "!ticker@arr" streams
+func (r *WsCombinedRouter) OnMarketStat(handler WsMarketStatHandler) *WsCombinedRouter {
+	r.marketStat = handler
+	return r
+}
+
+// OnMiniMarketStat set the handler of the "<symbol>@miniTicker" and "!miniTicker@arr" streams
+func (r *WsCombinedRouter) OnMiniMarketStat(handler WsMiniMarketsStatHandler) *WsCombinedRouter {
+	r.miniMarketStat = handler
+	return r
+}
+
+// OnRollingWindowStat set the handler of the "<symbol>@ticker_<window>" and
+// "!ticker_<window>@arr" streams
+func (r *WsCombinedRouter) OnRollingWindowStat(handler WsRollingWindowStatHandler) *WsCombinedRouter {
+	r.rollingWindow = handler
+	return r
+}
+
+// OnAvgPrice set the handler of the "<symbol>@avgPrice" streams
+func (r *WsCombinedRouter) OnAvgPrice(handler WsAvgPriceHandler) *WsCombinedRouter {
+	r.avgPrice = handler
+	return r
+}
+
+// OnUnknown set the handler of the payloads which are not routed to a typed handler
+func (r *WsCombinedRouter) OnUnknown(handler WsUnknownStreamHandler) *WsCombinedRouter {
+	r.unknown = handler
+	return r
+}
+
+// routeEvent decodes data into event and passes it to handler, it returns
+// false when handler is nil
+func routeEvent[E any](cfg *WsConfig, data []byte, event *E, handler func(event *E)) (bool, error) {
+	if handler == nil {
+		return false, nil
+	}
+	if err := json.Unmarshal(data, event); err != nil {
+		return true, err
+	}
+	cfg.stamp(event)
+	handler(event)
+	return true, nil
+}
+
+// routeEvents decodes data into an array of events and passes them to
+// handler one by one, it returns false when handler is nil
+func routeEvents[E any](cfg *WsConfig, data []byte, handler func(event *E)) (bool, error) {
+	if handler == nil {
+		return false, nil
+	}
+	var events []*E
+	if err := json.Unmarshal(data, &events); err != nil {
+		return true, err
+	}
+	cfg.stamp(events)
+	for _, event := range events {
+		handler(event)
+	}
+	return true, nil
+}
+
+// route passes the payload of stream to the handler of its type, it returns
+// false when the payload is not routed
+func (r *WsCombinedRouter) route(cfg *WsConfig, stream string, data []byte) (bool, error) {
+	name, kind, _ := strings.Cut(stream, "@")
+	switch {
+	case name == "!ticker" && kind == "arr":
+		return routeEvents(cfg, data, r.marketStat)
+	case name == "!miniTicker" && kind == "arr":
+		return routeEvents(cfg, data, r.miniMarketStat)
+	case strings.HasPrefix(name, "!ticker_") && kind == "arr":
+		return routeEvents(cfg, data, r.rollingWindow)
+	case name == "!bookTicker":
+		return routeEvent(cfg, data, new(WsBookTickerEvent), r.bookTicker)
+	case kind == "trade":
+		return routeEvent(cfg, data, new(WsTradeEvent), r.trade)
+	case kind == "aggTrade":
+		return routeEvent(cfg, data, new(WsAggTradeEvent), r.aggTrade)
+	case kind == "depth" || kind == "depth@100ms":
+		return routeEvent(cfg, data, new(WsDepthEvent), r.depth)
+	case strings.HasPrefix(kind, "depth"):
+		return routeEvent(cfg, data, &WsPartialDepthEvent{Symbol: strings.ToUpper(name)}, r.partialDepth)
+	case strings.HasPrefix(kind, "kline_"):
+		return routeEvent(cfg, data, new(WsKlineEvent), r.kline)
+	case kind == "bookTicker":
+		return routeEvent(cfg, data, new(WsBookTickerEvent), r.bookTicker)
+	case kind == "ticker":
+		return routeEvent(cfg, data, new(WsMarketStatEvent), r.marketStat)
+	case kind == "miniTicker":
+		return routeEvent(cfg, data, new(WsMiniMarketsStatEvent), r.miniMarketStat)
+	case strings.HasPrefix(kind, "ticker_"):
+		return routeEvent(cfg, data, new(WsRollingWindowStatEvent), r.rollingWindow)
+	case kind == "avgPrice":
+		return routeEvent(cfg, data, new(WsAvgPriceEvent), r.avgPrice)
+	}
+	return r.routeByEventType(cfg, data)
+}
+
+// routeByEventType passes the payload of a stream of unknown name to the
+// handler of its "e" field
+func (r *WsCombinedRouter) routeByEventType(cfg *WsConfig, data []byte) (bool, error) {
+	var event struct {
+		Type string             `json:"e"`
+		Time stdjson.RawMessage `json:"E"`
+	}
+	if len(data) == 0 || data[0] != '{' || json.Unmarshal(data, &event) != nil {
+		return false, nil
+	}
+	switch event.Type {
+	case "trade":
+		return routeEvent(cfg, data, new(WsTradeEvent), r.trade)
+	case "aggTrade":
+		return routeEvent(cfg, data, new(WsAggTradeEvent), r.aggTrade)
+	case "depthUpdate":
+		return routeEvent(cfg, data, new(WsDepthEvent), r.depth)
+	case "kline":
+		return routeEvent(cfg, data, new(WsKlineEvent), r.kline)
+	case "24hrTicker":
+		return routeEvent(cfg, data, new(WsMarketStatEvent), r.marketStat)
+	case "24hrMiniTicker":
+		return routeEvent(cfg, data, new(WsMiniMarketsStatEvent), r.miniMarketStat)
+	case "1hTicker", "4hTicker", "1dTicker":
+		return routeEvent(cfg, data, new(WsRollingWindowStatEvent), r.rollingWindow)
+	case "avgPrice":
+		return routeEvent(cfg, data, new(WsAvgPriceEvent), r.avgPrice)
+	}
+	return false, nil
+}
+
+// combinedStreams returns the lowercase streams "<symbol><suffix>" of symbols
+func combinedStreams(symbols []string, suffix string) []string {
+	streams := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		streams = append(streams, strings.ToLower(s)+suffix)
+	}
+	return streams
+}
+
+// combinedStreamsQuery joins the streams of a combined endpoint, the "+" of
+// the timezones, e.g. "btcusdt@kline_1d@+08:00", is escaped as it would be
+// decoded as a space in the query
+func combinedStreamsQuery(streams []string) string {
+	return strings.ReplaceAll(strings.Join(streams, "/"), "+", "%2B")
+}
+
+// wsCombinedServe serve the combined streams carrying a single type of
+// events, the data of their messages is decoded into events of type E
+func wsCombinedServe[E any](e *Environment, streams []string, handler func(event *E), errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(e.CombinedURL + combinedStreamsQuery(streams))
+	wsHandler := func(message []byte) {
+		var m struct {
+			Stream string             `json:"stream"`
+			Data   stdjson.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(message, &m); err != nil {
+			errHandler(err)
+			return
+		}
+		event := new(E)
+		if err := json.Unmarshal(m.Data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		cfg.stamp(event)
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsCombinedServe serve a combined websocket carrying several types of
+// streams, e.g. "btcusdt@trade" and "ethusdt@depth@100ms", whose events are
+// routed by router
+func WsCombinedServe(streams []string, router *WsCombinedRouter, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedServe(streams, router, errHandler)
+}
+
+// WsCombinedServe serve a combined websocket carrying several types of
+// streams, e.g. "btcusdt@trade" and "ethusdt@depth@100ms", whose events are
+// routed by router
+func (e *Environment) WsCombinedServe(streams []string, router *WsCombinedRouter, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := e.CombinedURL + combinedStreamsQuery(streams)
+	cfg := e.newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		var m struct {
+			Stream string             `json:"stream"`
+			Data   stdjson.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(message, &m); err != nil {
+			errHandler(err)
+			return
+		}
+		if m.Stream == "" || m.Data == nil {
+			errHandler(fmt.Errorf("unrouted stream message: %s", message))
+			return
+		}
+		routed, err := router.route(cfg, m.Stream, m.Data)
+		switch {
+		case err != nil:
+			errHandler(err)
+		case routed:
+		case router.unknown != nil:
+			router.unknown(m.Stream, m.Data)
+		default:
+			errHandler(fmt.Errorf("unrouted stream %s: %s", m.Stream, m.Data))
+		}
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
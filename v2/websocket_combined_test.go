@@ -0,0 +1,209 @@
+package binance
+
+import (
+	stdjson "encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pooyakn/go-binance/v2/binancetest"
+	"github.com/stretchr/testify/suite"
+)
+
+type websocketCombinedTestSuite struct {
+	suite.Suite
+	srv *binancetest.Server
+	env *Environment
+
+	mu     sync.Mutex
+	events []string
+	errs   []error
+}
+
+func TestWebsocketCombined(t *testing.T) {
+	suite.Run(t, new(websocketCombinedTestSuite))
+}
+
+func (s *websocketCombinedTestSuite) SetupTest() {
+	s.srv = binancetest.NewServer()
+	s.env = &Environment{
+		APIURL:      s.srv.URL,
+		WsURL:       s.srv.WsURL(binancetest.Spot),
+		CombinedURL: s.srv.CombinedURL(binancetest.Spot),
+	}
+	s.events, s.errs = nil, nil
+}
+
+func (s *websocketCombinedTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *websocketCombinedTestSuite) received(format string, args ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, fmt.Sprintf(format, args...))
+}
+
+func (s *websocketCombinedTestSuite) router() *WsCombinedRouter {
+	return NewWsCombinedRouter().
+		OnTrade(func(event *WsTradeEvent) {
+			s.received("trade %s %d", event.Symbol, event.TradeID)
+		}).
+		OnAggTrade(func(event *WsAggTradeEvent) {
+			s.received("aggTrade %s %d", event.Symbol, event.AggTradeID)
+		}).
+		OnDepth(func(event *WsDepthEvent) {
+			s.received("depth %s %d %s", event.Symbol, event.LastUpdateID, event.Bids[0].Price)
+		}).
+		OnPartialDepth(func(event *WsPartialDepthEvent) {
+			s.received("partialDepth %s %d %s", event.Symbol, event.LastUpdateID, event.Asks[0].Quantity)
+		}).
+		OnKline(func(event *WsKlineEvent) {
+			s.received("kline %s %s", event.Symbol, event.Kline.Interval)
+		}).
+		OnBookTicker(func(event *WsBookTickerEvent) {
+			s.received("bookTicker %s %s", event.Symbol, event.BestBidPrice)
+		}).
+		OnMarketStat(func(event *WsMarketStatEvent) {
+			s.received("ticker %s %s", event.Symbol, event.LastPrice)
+		})
+}
+
+func (s *websocketCombinedTestSuite) serve(streams []string, router *WsCombinedRouter) (doneC, stopC chan struct{}) {
+	doneC, stopC, err := s.env.WsCombinedServe(streams, router, func(err error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.errs = append(s.errs, err)
+	})
+	s.Require().NoError(err)
+	s.Require().Eventually(func() bool {
+		return s.srv.Subscribers(binancetest.Spot, streams[0]) == 1
+	}, time.Second, 5*time.Millisecond)
+	return doneC, stopC
+}
+
+func (s *websocketCombinedTestSuite) publish(stream string, data string) {
+	_, err := s.srv.Publish(binancetest.Spot, stream, stdjson.RawMessage(data))
+	s.Require().NoError(err)
+}
+
+func (s *websocketCombinedTestSuite) wait(events, errs int) {
+	s.Require().Eventually(func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.events) == events && len(s.errs) == errs
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (s *websocketCombinedTestSuite) TestRoute() {
+	streams := []string{
+		"btcusdt@trade",
+		"btcusdt@aggTrade",
+		"ethusdt@depth@100ms",
+		"bnbusdt@depth5",
+		"btcusdt@kline_1m",
+		"btcusdt@bookTicker",
+		"!ticker@arr",
+		"btcusdt@custom",
+	}
+	_, stopC := s.serve(streams, s.router())
+	defer close(stopC)
+
+	s.publish("btcusdt@trade", `{"e":"trade","E":1,"s":"BTCUSDT","t":12}`)
+	s.publish("btcusdt@aggTrade", `{"e":"aggTrade","E":1,"s":"BTCUSDT","a":34}`)
+	s.publish("ethusdt@depth@100ms", `{"e":"depthUpdate","E":1,"s":"ETHUSDT","u":56,"b":[["0.1","2"]],"a":[]}`)
+	s.publish("bnbusdt@depth5", `{"lastUpdateId":78,"bids":[],"asks":[["0.3","4"]]}`)
+	s.publish("btcusdt@kline_1m", `{"e":"kline","E":1,"s":"BTCUSDT","k":{"i":"1m"}}`)
+	s.publish("btcusdt@bookTicker", `{"u":1,"s":"BTCUSDT","b":"100.1","B":"1","a":"100.2","A":"1"}`)
+	s.publish("!ticker@arr", `[{"e":"24hrTicker","s":"BTCUSDT","c":"100"},{"e":"24hrTicker","s":"ETHUSDT","c":"10"}]`)
+	// a stream of unknown name is routed by its event type
+	s.publish("btcusdt@custom", `{"e":"trade","E":1,"s":"BTCUSDT","t":13}`)
+	s.wait(9, 0)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Equal([]string{
+		"trade BTCUSDT 12",
+		"aggTrade BTCUSDT 34",
+		"depth ETHUSDT 56 0.1",
+		"partialDepth BNBUSDT 78 4",
+		"kline BTCUSDT 1m",
+		"bookTicker BTCUSDT 100.1",
+		"ticker BTCUSDT 100",
+		"ticker ETHUSDT 10",
+		"trade BTCUSDT 13",
+	}, s.events)
+}
+
+func (s *websocketCombinedTestSuite) TestUnknown() {
+	streams := []string{"btcusdt@avgPrice", "btcusdt@miniTicker"}
+	var unknown []string
+	router := s.router().OnUnknown(func(stream string, data []byte) {
+		unknown = append(unknown, stream+" "+string(data))
+		s.received("unknown")
+	})
+	_, stopC := s.serve(streams, router)
+	defer close(stopC)
+
+	s.publish("btcusdt@avgPrice", `{"e":"avgPrice","s":"BTCUSDT"}`)
+	// no mini ticker handler is set
+	s.publish("btcusdt@miniTicker", `{"e":"24hrMiniTicker","s":"BTCUSDT"}`)
+	s.wait(2, 0)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Equal([]string{
+		`btcusdt@avgPrice {"e":"avgPrice","s":"BTCUSDT"}`,
+		`btcusdt@miniTicker {"e":"24hrMiniTicker","s":"BTCUSDT"}`,
+	}, unknown)
+}
+
+func (s *websocketCombinedTestSuite) TestErrors() {
+	_, stopC := s.serve([]string{"btcusdt@avgPrice", "btcusdt@trade"}, s.router())
+	defer close(stopC)
+
+	s.publish("btcusdt@avgPrice", `{"e":"avgPrice","s":"BTCUSDT"}`)
+	s.publish("btcusdt@trade", `{"e":"trade","t":"not a number"}`)
+	s.wait(0, 2)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Contains(s.errs[0].Error(), "unrouted stream btcusdt@avgPrice")
+}
+
+func (s *websocketCombinedTestSuite) TestRouteWindows() {
+	streams := []string{
+		"btcusdt@ticker_1h",
+		"!ticker_4h@arr",
+		"btcusdt@avgPrice",
+		"btcusdt@kline_1d@+08:00",
+		"btcusdt@custom",
+	}
+	router := s.router().
+		OnRollingWindowStat(func(event *WsRollingWindowStatEvent) {
+			s.received("%s %s %s", event.Event, event.Symbol, event.LastPrice)
+		}).
+		OnAvgPrice(func(event *WsAvgPriceEvent) {
+			s.received("avgPrice %s %s", event.Symbol, event.AvgPrice)
+		})
+	_, stopC := s.serve(streams, router)
+	defer close(stopC)
+
+	s.publish("btcusdt@ticker_1h", `{"e":"1hTicker","E":1,"s":"BTCUSDT","c":"100"}`)
+	s.publish("!ticker_4h@arr", `[{"e":"4hTicker","s":"BTCUSDT","c":"101"},{"e":"4hTicker","s":"ETHUSDT","c":"11"}]`)
+	s.publish("btcusdt@avgPrice", `{"e":"avgPrice","E":1,"s":"BTCUSDT","i":"5m","w":"99.5"}`)
+	s.publish("btcusdt@kline_1d@+08:00", `{"e":"kline","E":1,"s":"BTCUSDT","k":{"i":"1d"}}`)
+	// a stream of unknown name is routed by its event type
+	s.publish("btcusdt@custom", `{"e":"1dTicker","E":1,"s":"BTCUSDT","c":"102"}`)
+	s.wait(6, 0)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Equal([]string{
+		"1hTicker BTCUSDT 100",
+		"4hTicker BTCUSDT 101",
+		"4hTicker ETHUSDT 11",
+		"avgPrice BTCUSDT 99.5",
+		"kline BTCUSDT 1d",
+		"1dTicker BTCUSDT 102",
+	}, s.events)
+}
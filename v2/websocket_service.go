@@ -6,6 +6,8 @@ import (
 	"time"
 
 	stdjson "encoding/json"
+
+	"github.com/pooyakn/go-binance/v2/common"
 )
 
 // Endpoints
@@ -21,6 +23,11 @@ var (
 	WebsocketTimeout = time.Second * 60
 	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
 	WebsocketKeepalive = false
+	// WebsocketLogger logs the websocket connection events, listen keys are redacted
+	WebsocketLogger = common.NewNopLogger()
+	// WebsocketReconnect enables reconnecting the websocket streams of the
+	// default environment when set, e.g. to common.NewReconnectPolicy()
+	WebsocketReconnect *common.ReconnectPolicy
 )
 
 // SetWsEndpoints sets the endpoints for the websocket connections
@@ -31,24 +38,10 @@ func SetWsEndpoints(main, testnet, combinedMain, combinedTestnet string) {
 	baseCombinedTestnetURL = combinedTestnet
 }
 
-// getWsEndpoint return the base endpoint of the WS according the UseTestnet flag
-func getWsEndpoint() string {
-	if UseTestnet {
-		return baseWsTestnetURL
-	}
-	return baseWsMainURL
-}
-
-// getCombinedEndpoint return the base endpoint of the combined stream according the UseTestnet flag
-func getCombinedEndpoint() string {
-	if UseTestnet {
-		return baseCombinedTestnetURL
-	}
-	return baseCombinedMainURL
-}
-
 // WsPartialDepthEvent define websocket partial depth book event
 type WsPartialDepthEvent struct {
+	common.WsReceived
+
 	Symbol       string
 	LastUpdateID int64 `json:"lastUpdateId"`
 	Bids         []Bid `json:"bids"`
@@ -60,19 +53,29 @@ type WsPartialDepthHandler func(event *WsPartialDepthEvent)
 
 // WsPartialDepthServe serve websocket partial depth handler with a symbol, using 1sec updates
 func WsPartialDepthServe(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@depth%s", getWsEndpoint(), strings.ToLower(symbol), levels)
-	return wsPartialDepthServe(endpoint, symbol, handler, errHandler)
+	return DefaultEnvironment().WsPartialDepthServe(symbol, levels, handler, errHandler)
+}
+
+// WsPartialDepthServe serve websocket partial depth handler with a symbol, using 1sec updates
+func (e *Environment) WsPartialDepthServe(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@depth%s", e.WsURL, strings.ToLower(symbol), levels)
+	return e.wsPartialDepthServe(endpoint, symbol, handler, errHandler)
 }
 
 // WsPartialDepthServe100Ms serve websocket partial depth handler with a symbol, using 100msec updates
 func WsPartialDepthServe100Ms(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@depth%s@100ms", getWsEndpoint(), strings.ToLower(symbol), levels)
-	return wsPartialDepthServe(endpoint, symbol, handler, errHandler)
+	return DefaultEnvironment().WsPartialDepthServe100Ms(symbol, levels, handler, errHandler)
+}
+
+// WsPartialDepthServe100Ms serve websocket partial depth handler with a symbol, using 100msec updates
+func (e *Environment) WsPartialDepthServe100Ms(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@depth%s@100ms", e.WsURL, strings.ToLower(symbol), levels)
+	return e.wsPartialDepthServe(endpoint, symbol, handler, errHandler)
 }
 
 // WsPartialDepthServe serve websocket partial depth handler with a symbol
-func wsPartialDepthServe(endpoint string, symbol string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	cfg := newWsConfig(endpoint)
+func (e *Environment) wsPartialDepthServe(endpoint string, symbol string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -100,6 +103,7 @@ func wsPartialDepthServe(endpoint string, symbol string, handler WsPartialDepthH
 				Quantity: item.GetIndex(1).MustString(),
 			}
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -107,12 +111,16 @@ func wsPartialDepthServe(endpoint string, symbol string, handler WsPartialDepthH
 
 // WsCombinedPartialDepthServe is similar to WsPartialDepthServe, but it for multiple symbols
 func WsCombinedPartialDepthServe(symbolLevels map[string]string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+	return DefaultEnvironment().WsCombinedPartialDepthServe(symbolLevels, handler, errHandler)
+}
+
+// WsCombinedPartialDepthServe is similar to WsPartialDepthServe, but it for multiple symbols
+func (e *Environment) WsCombinedPartialDepthServe(symbolLevels map[string]string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	streams := make([]string, 0, len(symbolLevels))
 	for s, l := range symbolLevels {
-		endpoint += fmt.Sprintf("%s@depth%s", strings.ToLower(s), l) + "/"
+		streams = append(streams, fmt.Sprintf("%s@depth%s", strings.ToLower(s), l))
 	}
-	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	cfg := e.newWsConfig(e.CombinedURL + combinedStreamsQuery(streams))
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -144,6 +152,7 @@ func WsCombinedPartialDepthServe(symbolLevels map[string]string, handler WsParti
 				Quantity: item[1].(string),
 			}
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -154,19 +163,29 @@ type WsDepthHandler func(event *WsDepthEvent)
 
 // WsDepthServe serve websocket depth handler with a symbol, using 1sec updates
 func WsDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@depth", getWsEndpoint(), strings.ToLower(symbol))
-	return wsDepthServe(endpoint, handler, errHandler)
+	return DefaultEnvironment().WsDepthServe(symbol, handler, errHandler)
+}
+
+// WsDepthServe serve websocket depth handler with a symbol, using 1sec updates
+func (e *Environment) WsDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@depth", e.WsURL, strings.ToLower(symbol))
+	return e.wsDepthServe(endpoint, handler, errHandler)
 }
 
 // WsDepthServe100Ms serve websocket depth handler with a symbol, using 100msec updates
 func WsDepthServe100Ms(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@depth@100ms", getWsEndpoint(), strings.ToLower(symbol))
-	return wsDepthServe(endpoint, handler, errHandler)
+	return DefaultEnvironment().WsDepthServe100Ms(symbol, handler, errHandler)
+}
+
+// WsDepthServe100Ms serve websocket depth handler with a symbol, using 100msec updates
+func (e *Environment) WsDepthServe100Ms(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@depth@100ms", e.WsURL, strings.ToLower(symbol))
+	return e.wsDepthServe(endpoint, handler, errHandler)
 }
 
 // WsDepthServe serve websocket depth handler with an arbitrary endpoint address
-func wsDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	cfg := newWsConfig(endpoint)
+func (e *Environment) wsDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -197,6 +216,7 @@ func wsDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler
 				Quantity: item.GetIndex(1).MustString(),
 			}
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -204,6 +224,8 @@ func wsDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler
 
 // WsDepthEvent define websocket depth event
 type WsDepthEvent struct {
+	common.WsReceived
+
 	Event         string `json:"e"`
 	Time          int64  `json:"E"`
 	Symbol        string `json:"s"`
@@ -215,25 +237,24 @@ type WsDepthEvent struct {
 
 // WsCombinedDepthServe is similar to WsDepthServe, but it for multiple symbols
 func WsCombinedDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
-	for _, s := range symbols {
-		endpoint += fmt.Sprintf("%s@depth", strings.ToLower(s)) + "/"
-	}
-	endpoint = endpoint[:len(endpoint)-1]
-	return wsCombinedDepthServe(endpoint, handler, errHandler)
+	return DefaultEnvironment().WsCombinedDepthServe(symbols, handler, errHandler)
+}
+
+// WsCombinedDepthServe is similar to WsDepthServe, but it for multiple symbols
+func (e *Environment) WsCombinedDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsCombinedDepthServe(combinedStreams(symbols, "@depth"), handler, errHandler)
 }
 
 func WsCombinedDepthServe100Ms(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
-	for _, s := range symbols {
-		endpoint += fmt.Sprintf("%s@depth@100ms", strings.ToLower(s)) + "/"
-	}
-	endpoint = endpoint[:len(endpoint)-1]
-	return wsCombinedDepthServe(endpoint, handler, errHandler)
+	return DefaultEnvironment().WsCombinedDepthServe100Ms(symbols, handler, errHandler)
 }
 
-func wsCombinedDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	cfg := newWsConfig(endpoint)
+func (e *Environment) WsCombinedDepthServe100Ms(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return e.wsCombinedDepthServe(combinedStreams(symbols, "@depth@100ms"), handler, errHandler)
+}
+
+func (e *Environment) wsCombinedDepthServe(streams []string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(e.CombinedURL + combinedStreamsQuery(streams))
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -267,6 +288,7 @@ func wsCombinedDepthServe(endpoint string, handler WsDepthHandler, errHandler Er
 				Quantity: item[1].(string),
 			}
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -277,43 +299,62 @@ type WsKlineHandler func(event *WsKlineEvent)
 
 // WsCombinedKlineServe is similar to WsKlineServe, but it handles multiple symbols with it interval
 func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+	return DefaultEnvironment().WsCombinedKlineServe(symbolIntervalPair, handler, errHandler)
+}
+
+// WsCombinedKlineServe is similar to WsKlineServe, but it handles multiple symbols with it interval
+func (e *Environment) WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	streams := make([]string, 0, len(symbolIntervalPair))
 	for symbol, interval := range symbolIntervalPair {
-		endpoint += fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval) + "/"
+		streams = append(streams, fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval))
 	}
-	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
-	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-
-		stream := j.Get("stream").MustString()
-		data := j.Get("data").MustMap()
-
-		symbol := strings.Split(stream, "@")[0]
-
-		jsonData, _ := json.Marshal(data)
+	return wsCombinedServe(e, streams, handler, errHandler)
+}
 
-		event := new(WsKlineEvent)
-		err = json.Unmarshal(jsonData, event)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-		event.Symbol = strings.ToUpper(symbol)
+// WsCombinedKlineServeWithTimezone is similar to WsCombinedKlineServe, but the
+// klines are opened and closed in timezone, e.g. "+08:00"
+func WsCombinedKlineServeWithTimezone(symbolIntervalPair map[string]string, timezone string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedKlineServeWithTimezone(symbolIntervalPair, timezone, handler, errHandler)
+}
 
-		handler(event)
+// WsCombinedKlineServeWithTimezone is similar to WsCombinedKlineServe, but the
+// klines are opened and closed in timezone, e.g. "+08:00"
+func (e *Environment) WsCombinedKlineServeWithTimezone(symbolIntervalPair map[string]string, timezone string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	streams := make([]string, 0, len(symbolIntervalPair))
+	for symbol, interval := range symbolIntervalPair {
+		streams = append(streams, fmt.Sprintf("%s@kline_%s@%s", strings.ToLower(symbol), interval, timezone))
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return wsCombinedServe(e, streams, handler, errHandler)
 }
 
 // WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
 func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@kline_%s", getWsEndpoint(), strings.ToLower(symbol), interval)
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
+func (e *Environment) WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@kline_%s", e.WsURL, strings.ToLower(symbol), interval)
+	return e.wsKlineServe(endpoint, handler, errHandler)
+}
+
+// WsKlineServeWithTimezone serve websocket kline handler with a symbol and
+// interval like 15m, 30s, the klines are opened and closed in timezone, e.g.
+// "+08:00", instead of UTC
+func WsKlineServeWithTimezone(symbol string, interval string, timezone string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsKlineServeWithTimezone(symbol, interval, timezone, handler, errHandler)
+}
+
+// WsKlineServeWithTimezone serve websocket kline handler with a symbol and
+// interval like 15m, 30s, the klines are opened and closed in timezone, e.g.
+// "+08:00", instead of UTC
+func (e *Environment) WsKlineServeWithTimezone(symbol string, interval string, timezone string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@kline_%s@%s", e.WsURL, strings.ToLower(symbol), interval, timezone)
+	return e.wsKlineServe(endpoint, handler, errHandler)
+}
+
+func (e *Environment) wsKlineServe(endpoint string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsKlineEvent)
 		err := json.Unmarshal(message, event)
@@ -321,6 +362,7 @@ func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHan
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -328,6 +370,8 @@ func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHan
 
 // WsKlineEvent define websocket kline event
 type WsKlineEvent struct {
+	common.WsReceived
+
 	Event  string  `json:"e"`
 	Time   int64   `json:"E"`
 	Symbol string  `json:"s"`
@@ -359,8 +403,13 @@ type WsAggTradeHandler func(event *WsAggTradeEvent)
 
 // WsAggTradeServe serve websocket aggregate handler with a symbol
 func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@aggTrade", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAggTradeServe(symbol, handler, errHandler)
+}
+
+// WsAggTradeServe serve websocket aggregate handler with a symbol
+func (e *Environment) WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@aggTrade", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsAggTradeEvent)
 		err := json.Unmarshal(message, event)
@@ -368,6 +417,7 @@ func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHan
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -375,42 +425,18 @@ func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHan
 
 // WsCombinedAggTradeServe is similar to WsAggTradeServe, but it handles multiple symbolx
 func WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
-	for s := range symbols {
-		endpoint += fmt.Sprintf("%s@aggTrade", strings.ToLower(symbols[s])) + "/"
-	}
-	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
-	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-
-		stream := j.Get("stream").MustString()
-		data := j.Get("data").MustMap()
-
-		symbol := strings.Split(stream, "@")[0]
-
-		jsonData, _ := json.Marshal(data)
-
-		event := new(WsAggTradeEvent)
-		err = json.Unmarshal(jsonData, event)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-
-		event.Symbol = strings.ToUpper(symbol)
+	return DefaultEnvironment().WsCombinedAggTradeServe(symbols, handler, errHandler)
+}
 
-		handler(event)
-	}
-	return wsServe(cfg, wsHandler, errHandler)
+// WsCombinedAggTradeServe is similar to WsAggTradeServe, but it handles multiple symbolx
+func (e *Environment) WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedServe(e, combinedStreams(symbols, "@aggTrade"), handler, errHandler)
 }
 
 // WsAggTradeEvent define websocket aggregate trade event
 type WsAggTradeEvent struct {
+	common.WsReceived
+
 	Event                 string `json:"e"`
 	Time                  int64  `json:"E"`
 	Symbol                string `json:"s"`
@@ -430,8 +456,13 @@ type WsCombinedTradeHandler func(event *WsCombinedTradeEvent)
 
 // WsTradeServe serve websocket handler with a symbol
 func WsTradeServe(symbol string, handler WsTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@trade", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsTradeServe(symbol, handler, errHandler)
+}
+
+// WsTradeServe serve websocket handler with a symbol
+func (e *Environment) WsTradeServe(symbol string, handler WsTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@trade", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsTradeEvent)
 		err := json.Unmarshal(message, event)
@@ -439,18 +470,18 @@ func WsTradeServe(symbol string, handler WsTradeHandler, errHandler ErrHandler)
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
 func WsCombinedTradeServe(symbols []string, handler WsCombinedTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
-	for _, s := range symbols {
-		endpoint += fmt.Sprintf("%s@trade/", strings.ToLower(s))
-	}
-	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsCombinedTradeServe(symbols, handler, errHandler)
+}
+
+func (e *Environment) WsCombinedTradeServe(symbols []string, handler WsCombinedTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(e.CombinedURL + combinedStreamsQuery(combinedStreams(symbols, "@trade")))
 	wsHandler := func(message []byte) {
 		event := new(WsCombinedTradeEvent)
 		err := json.Unmarshal(message, event)
@@ -458,6 +489,7 @@ func WsCombinedTradeServe(symbols []string, handler WsCombinedTradeHandler, errH
 			errHandler(err)
 			return
 		}
+		cfg.stamp(&event.Data)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -465,6 +497,8 @@ func WsCombinedTradeServe(symbols []string, handler WsCombinedTradeHandler, errH
 
 // WsTradeEvent define websocket trade event
 type WsTradeEvent struct {
+	common.WsReceived
+
 	Event         string `json:"e"`
 	Time          int64  `json:"E"`
 	Symbol        string `json:"s"`
@@ -485,6 +519,8 @@ type WsCombinedTradeEvent struct {
 
 // WsUserDataEvent define user data event
 type WsUserDataEvent struct {
+	common.WsReceived
+
 	Event             UserDataEventType `json:"e"`
 	Time              int64             `json:"E"`
 	TransactionTime   int64             `json:"T"`
@@ -574,8 +610,13 @@ type WsUserDataHandler func(event *WsUserDataEvent)
 
 // WsUserDataServe serve user data handler with listen key
 func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsUserDataServe(listenKey, handler, errHandler)
+}
+
+// WsUserDataServe serve user data handler with listen key
+func (e *Environment) WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", e.WsURL, listenKey)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
@@ -624,6 +665,7 @@ func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler Err
 			}
 		}
 
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -634,45 +676,23 @@ type WsMarketStatHandler func(event *WsMarketStatEvent)
 
 // WsCombinedMarketStatServe is similar to WsMarketStatServe, but it handles multiple symbolx
 func WsCombinedMarketStatServe(symbols []string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
-	for s := range symbols {
-		endpoint += fmt.Sprintf("%s@ticker", strings.ToLower(symbols[s])) + "/"
-	}
-	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
-
-	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-
-		stream := j.Get("stream").MustString()
-		data := j.Get("data").MustMap()
-
-		symbol := strings.Split(stream, "@")[0]
-
-		jsonData, _ := json.Marshal(data)
-
-		event := new(WsMarketStatEvent)
-		err = json.Unmarshal(jsonData, event)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-
-		event.Symbol = strings.ToUpper(symbol)
+	return DefaultEnvironment().WsCombinedMarketStatServe(symbols, handler, errHandler)
+}
 
-		handler(event)
-	}
-	return wsServe(cfg, wsHandler, errHandler)
+// WsCombinedMarketStatServe is similar to WsMarketStatServe, but it handles multiple symbolx
+func (e *Environment) WsCombinedMarketStatServe(symbols []string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedServe(e, combinedStreams(symbols, "@ticker"), handler, errHandler)
 }
 
 // WsMarketStatServe serve websocket that push 24hr statistics for single market every second
 func WsMarketStatServe(symbol string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@ticker", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsMarketStatServe(symbol, handler, errHandler)
+}
+
+// WsMarketStatServe serve websocket that push 24hr statistics for single market every second
+func (e *Environment) WsMarketStatServe(symbol string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@ticker", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsMarketStatEvent
 		err := json.Unmarshal(message, &event)
@@ -680,6 +700,7 @@ func WsMarketStatServe(symbol string, handler WsMarketStatHandler, errHandler Er
 			errHandler(err)
 			return
 		}
+		cfg.stamp(&event)
 		handler(&event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -690,8 +711,13 @@ type WsAllMarketsStatHandler func(event WsAllMarketsStatEvent)
 
 // WsAllMarketsStatServe serve websocket that push 24hr statistics for all market every second
 func WsAllMarketsStatServe(handler WsAllMarketsStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!ticker@arr", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAllMarketsStatServe(handler, errHandler)
+}
+
+// WsAllMarketsStatServe serve websocket that push 24hr statistics for all market every second
+func (e *Environment) WsAllMarketsStatServe(handler WsAllMarketsStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!ticker@arr", e.WsURL)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarketsStatEvent
 		err := json.Unmarshal(message, &event)
@@ -699,6 +725,7 @@ func WsAllMarketsStatServe(handler WsAllMarketsStatHandler, errHandler ErrHandle
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -709,6 +736,8 @@ type WsAllMarketsStatEvent []*WsMarketStatEvent
 
 // WsMarketStatEvent define websocket market statistics event
 type WsMarketStatEvent struct {
+	common.WsReceived
+
 	Event              string `json:"e"`
 	Time               int64  `json:"E"`
 	Symbol             string `json:"s"`
@@ -739,8 +768,13 @@ type WsAllMiniMarketsStatServeHandler func(event WsAllMiniMarketsStatEvent)
 
 // WsAllMiniMarketsStatServe serve websocket that push mini version of 24hr statistics for all market every second
 func WsAllMiniMarketsStatServe(handler WsAllMiniMarketsStatServeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!miniTicker@arr", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAllMiniMarketsStatServe(handler, errHandler)
+}
+
+// WsAllMiniMarketsStatServe serve websocket that push mini version of 24hr statistics for all market every second
+func (e *Environment) WsAllMiniMarketsStatServe(handler WsAllMiniMarketsStatServeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!miniTicker@arr", e.WsURL)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMiniMarketsStatEvent
 		err := json.Unmarshal(message, &event)
@@ -748,6 +782,7 @@ func WsAllMiniMarketsStatServe(handler WsAllMiniMarketsStatServeHandler, errHand
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -758,6 +793,8 @@ type WsAllMiniMarketsStatEvent []*WsMiniMarketsStatEvent
 
 // WsMiniMarketsStatEvent define websocket market mini-ticker statistics event
 type WsMiniMarketsStatEvent struct {
+	common.WsReceived
+
 	Event       string `json:"e"`
 	Time        int64  `json:"E"`
 	Symbol      string `json:"s"`
@@ -769,8 +806,174 @@ type WsMiniMarketsStatEvent struct {
 	QuoteVolume string `json:"q"`
 }
 
+// WsRollingWindowStatEvent define websocket rolling window statistics event,
+// its type tells the window, e.g. "1hTicker"
+type WsRollingWindowStatEvent struct {
+	common.WsReceived
+
+	Event              string `json:"e"`
+	Time               int64  `json:"E"`
+	Symbol             string `json:"s"`
+	PriceChange        string `json:"p"`
+	PriceChangePercent string `json:"P"`
+	OpenPrice          string `json:"o"`
+	HighPrice          string `json:"h"`
+	LowPrice           string `json:"l"`
+	LastPrice          string `json:"c"`
+	WeightedAvgPrice   string `json:"w"`
+	BaseVolume         string `json:"v"`
+	QuoteVolume        string `json:"q"`
+	OpenTime           int64  `json:"O"`
+	CloseTime          int64  `json:"C"`
+	FirstID            int64  `json:"F"`
+	LastID             int64  `json:"L"`
+	Count              int64  `json:"n"`
+}
+
+// WsAllRollingWindowStatsEvent define array of websocket rolling window statistics events
+type WsAllRollingWindowStatsEvent []*WsRollingWindowStatEvent
+
+// WsRollingWindowStatHandler handle websocket that push single market rolling window statistics
+type WsRollingWindowStatHandler func(event *WsRollingWindowStatEvent)
+
+// WsAllRollingWindowStatsHandler handle websocket that push all markets rolling window statistics
+type WsAllRollingWindowStatsHandler func(event WsAllRollingWindowStatsEvent)
+
+// rollingWindowStream returns the "ticker_<windowSize>" stream of the window
+//
+// Supported windowSize values:
+//   - 1h
+//   - 4h
+//   - 1d
+func rollingWindowStream(windowSize string) (string, error) {
+	switch windowSize {
+	case "1h", "4h", "1d":
+		return "ticker_" + windowSize, nil
+	}
+	return "", fmt.Errorf("invalid window size %q", windowSize)
+}
+
+// WsRollingWindowStatServe serve websocket that push rolling window statistics
+// for single market every second, windowSize is 1h, 4h or 1d
+func WsRollingWindowStatServe(symbol string, windowSize string, handler WsRollingWindowStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsRollingWindowStatServe(symbol, windowSize, handler, errHandler)
+}
+
+// WsRollingWindowStatServe serve websocket that push rolling window statistics
+// for single market every second, windowSize is 1h, 4h or 1d
+func (e *Environment) WsRollingWindowStatServe(symbol string, windowSize string, handler WsRollingWindowStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	stream, err := rollingWindowStream(windowSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	endpoint := fmt.Sprintf("%s/%s@%s", e.WsURL, strings.ToLower(symbol), stream)
+	cfg := e.newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsRollingWindowStatEvent)
+		err := json.Unmarshal(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		cfg.stamp(event)
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsCombinedRollingWindowStatServe is similar to WsRollingWindowStatServe, but it handles multiple symbols
+func WsCombinedRollingWindowStatServe(symbols []string, windowSize string, handler WsRollingWindowStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedRollingWindowStatServe(symbols, windowSize, handler, errHandler)
+}
+
+// WsCombinedRollingWindowStatServe is similar to WsRollingWindowStatServe, but it handles multiple symbols
+func (e *Environment) WsCombinedRollingWindowStatServe(symbols []string, windowSize string, handler WsRollingWindowStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	stream, err := rollingWindowStream(windowSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	return wsCombinedServe(e, combinedStreams(symbols, "@"+stream), handler, errHandler)
+}
+
+// WsAllRollingWindowStatsServe serve websocket that push rolling window
+// statistics for all market every second, windowSize is 1h, 4h or 1d
+func WsAllRollingWindowStatsServe(windowSize string, handler WsAllRollingWindowStatsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAllRollingWindowStatsServe(windowSize, handler, errHandler)
+}
+
+// WsAllRollingWindowStatsServe serve websocket that push rolling window
+// statistics for all market every second, windowSize is 1h, 4h or 1d
+func (e *Environment) WsAllRollingWindowStatsServe(windowSize string, handler WsAllRollingWindowStatsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	stream, err := rollingWindowStream(windowSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	endpoint := fmt.Sprintf("%s/!%s@arr", e.WsURL, stream)
+	cfg := e.newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		var event WsAllRollingWindowStatsEvent
+		err := json.Unmarshal(message, &event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		cfg.stamp(event)
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsAvgPriceEvent define websocket average price event
+type WsAvgPriceEvent struct {
+	common.WsReceived
+
+	Event            string `json:"e"`
+	Time             int64  `json:"E"`
+	Symbol           string `json:"s"`
+	AvgPriceInterval string `json:"i"`
+	AvgPrice         string `json:"w"`
+	LastTradeTime    int64  `json:"T"`
+}
+
+// WsAvgPriceHandler handle websocket that push the average price of a market
+type WsAvgPriceHandler func(event *WsAvgPriceEvent)
+
+// WsAvgPriceServe serve websocket that push the average price of a market every second
+func WsAvgPriceServe(symbol string, handler WsAvgPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsAvgPriceServe(symbol, handler, errHandler)
+}
+
+// WsAvgPriceServe serve websocket that push the average price of a market every second
+func (e *Environment) WsAvgPriceServe(symbol string, handler WsAvgPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@avgPrice", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsAvgPriceEvent)
+		err := json.Unmarshal(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		cfg.stamp(event)
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsCombinedAvgPriceServe is similar to WsAvgPriceServe, but it handles multiple symbols
+func WsCombinedAvgPriceServe(symbols []string, handler WsAvgPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return DefaultEnvironment().WsCombinedAvgPriceServe(symbols, handler, errHandler)
+}
+
+// WsCombinedAvgPriceServe is similar to WsAvgPriceServe, but it handles multiple symbols
+func (e *Environment) WsCombinedAvgPriceServe(symbols []string, handler WsAvgPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedServe(e, combinedStreams(symbols, "@avgPrice"), handler, errHandler)
+}
+
 // WsBookTickerEvent define websocket best book ticker event.
 type WsBookTickerEvent struct {
+	common.WsReceived
+
 	UpdateID     int64  `json:"u"`
 	Symbol       string `json:"s"`
 	BestBidPrice string `json:"b"`
@@ -789,8 +992,13 @@ type WsBookTickerHandler func(event *WsBookTickerEvent)
 
 // WsBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for a specified symbol.
 func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@bookTicker", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsBookTickerServe(symbol, handler, errHandler)
+}
+
+// WsBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for a specified symbol.
+func (e *Environment) WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@bookTicker", e.WsURL, strings.ToLower(symbol))
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -798,6 +1006,7 @@ func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler Er
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -805,12 +1014,12 @@ func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler Er
 
 // WsCombinedBookTickerServe is similar to WsBookTickerServe, but it is for multiple symbols
 func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := baseCombinedMainURL
-	for _, s := range symbols {
-		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
-	}
-	endpoint = endpoint[:len(endpoint)-1]
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsCombinedBookTickerServe(symbols, handler, errHandler)
+}
+
+// WsCombinedBookTickerServe is similar to WsBookTickerServe, but it is for multiple symbols
+func (e *Environment) WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	cfg := e.newWsConfig(e.CombinedURL + combinedStreamsQuery(combinedStreams(symbols, "@bookTicker")))
 	wsHandler := func(message []byte) {
 		event := new(WsCombinedBookTickerEvent)
 		err := json.Unmarshal(message, event)
@@ -818,6 +1027,7 @@ func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, er
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event.Data)
 		handler(event.Data)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -825,8 +1035,13 @@ func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, er
 
 // WsAllBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for all symbols.
 func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!bookTicker", getWsEndpoint())
-	cfg := newWsConfig(endpoint)
+	return DefaultEnvironment().WsAllBookTickerServe(handler, errHandler)
+}
+
+// WsAllBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for all symbols.
+func (e *Environment) WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!bookTicker", e.WsURL)
+	cfg := e.newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
 		err := json.Unmarshal(message, &event)
@@ -834,6 +1049,7 @@ func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (d
 			errHandler(err)
 			return
 		}
+		cfg.stamp(event)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -1459,3 +1459,185 @@ func (s *websocketServiceTestSuite) assertWsBookTickerEvent(e, a *WsBookTickerEv
 	r.Equal(e.BestAskPrice, a.BestAskPrice, "BestAskPrice")
 	r.Equal(e.BestAskQty, a.BestAskQty, "BestAskQty")
 }
+
+func (s *websocketServiceTestSuite) TestWsRollingWindowStatServe() {
+	data := []byte(`{
+		"e": "1hTicker",
+		"E": 1672515782136,
+		"s": "BNBBTC",
+		"p": "0.0015",
+		"P": "250.00",
+		"o": "0.0010",
+		"h": "0.0025",
+		"l": "0.0010",
+		"c": "0.0025",
+		"w": "0.0018",
+		"v": "10000",
+		"q": "18",
+		"O": 0,
+		"C": 1675216573749,
+		"F": 0,
+		"L": 18150,
+		"n": 18151
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsRollingWindowStatServe("BNBBTC", "1h", func(event *WsRollingWindowStatEvent) {
+		e := &WsRollingWindowStatEvent{
+			Event:              "1hTicker",
+			Time:               1672515782136,
+			Symbol:             "BNBBTC",
+			PriceChange:        "0.0015",
+			PriceChangePercent: "250.00",
+			OpenPrice:          "0.0010",
+			HighPrice:          "0.0025",
+			LowPrice:           "0.0010",
+			LastPrice:          "0.0025",
+			WeightedAvgPrice:   "0.0018",
+			BaseVolume:         "10000",
+			QuoteVolume:        "18",
+			OpenTime:           0,
+			CloseTime:          1675216573749,
+			FirstID:            0,
+			LastID:             18150,
+			Count:              18151,
+		}
+		s.r().False(event.ReceivedAt.IsZero(), "ReceivedAt")
+		e.ReceivedAt = event.ReceivedAt
+		s.r().Equal(e, event)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestWsAllRollingWindowStatsServe() {
+	data := []byte(`[
+		{"e":"4hTicker","E":1672515782136,"s":"BNBBTC","c":"0.0025","n":18151},
+		{"e":"4hTicker","E":1672515782136,"s":"ETHBTC","c":"0.0700","n":42}
+	]`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsAllRollingWindowStatsServe("4h", func(event WsAllRollingWindowStatsEvent) {
+		s.r().Len(event, 2)
+		s.r().Equal("4hTicker", event[0].Event)
+		s.r().Equal("BNBBTC", event[0].Symbol)
+		s.r().Equal("0.0025", event[0].LastPrice)
+		s.r().Equal(int64(18151), event[0].Count)
+		s.r().Equal("ETHBTC", event[1].Symbol)
+		s.r().Equal(int64(42), event[1].Count)
+		s.r().False(event[1].ReceivedAt.IsZero(), "ReceivedAt")
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestWsRollingWindowStatServeInvalidWindow() {
+	s.mockWsServe(nil, nil)
+	defer s.assertWsServe(0)
+
+	_, _, err := WsRollingWindowStatServe("BNBBTC", "2h", func(event *WsRollingWindowStatEvent) {}, func(err error) {})
+	s.r().EqualError(err, `invalid window size "2h"`)
+	_, _, err = WsCombinedRollingWindowStatServe([]string{"BNBBTC"}, "24h", func(event *WsRollingWindowStatEvent) {}, func(err error) {})
+	s.r().EqualError(err, `invalid window size "24h"`)
+	_, _, err = WsAllRollingWindowStatsServe("", func(event WsAllRollingWindowStatsEvent) {}, func(err error) {})
+	s.r().EqualError(err, `invalid window size ""`)
+}
+
+func (s *websocketServiceTestSuite) TestWsAvgPriceServe() {
+	data := []byte(`{
+		"e": "avgPrice",
+		"E": 1693907033000,
+		"s": "BTCUSDT",
+		"i": "5m",
+		"w": "25776.86000000",
+		"T": 1693907032213
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsAvgPriceServe("BTCUSDT", func(event *WsAvgPriceEvent) {
+		e := &WsAvgPriceEvent{
+			Event:            "avgPrice",
+			Time:             1693907033000,
+			Symbol:           "BTCUSDT",
+			AvgPriceInterval: "5m",
+			AvgPrice:         "25776.86000000",
+			LastTradeTime:    1693907032213,
+		}
+		e.ReceivedAt = event.ReceivedAt
+		s.r().Equal(e, event)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestWsCombinedAvgPriceServe() {
+	data := []byte(`{
+		"stream": "ethusdt@avgPrice",
+		"data": {"e":"avgPrice","E":1693907033000,"s":"ETHUSDT","i":"5m","w":"1633.05000000","T":1693907032213}
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsCombinedAvgPriceServe([]string{"ETHUSDT"}, func(event *WsAvgPriceEvent) {
+		s.r().Equal("ETHUSDT", event.Symbol)
+		s.r().Equal("1633.05000000", event.AvgPrice)
+		s.r().False(event.ReceivedAt.IsZero(), "ReceivedAt")
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestWsWindowAndTimezoneEndpoints() {
+	var endpoints []string
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		endpoints = append(endpoints, cfg.Endpoint)
+		return nil, nil, nil
+	}
+	e := &Environment{WsURL: "wss://ws", CombinedURL: "wss://combined?streams="}
+	errHandler := func(err error) {}
+
+	_, _, err := e.WsRollingWindowStatServe("BNBBTC", "1d", func(event *WsRollingWindowStatEvent) {}, errHandler)
+	s.r().NoError(err)
+	_, _, err = e.WsCombinedRollingWindowStatServe([]string{"BNBBTC", "ETHBTC"}, "4h", func(event *WsRollingWindowStatEvent) {}, errHandler)
+	s.r().NoError(err)
+	_, _, err = e.WsAllRollingWindowStatsServe("1h", func(event WsAllRollingWindowStatsEvent) {}, errHandler)
+	s.r().NoError(err)
+	_, _, err = e.WsCombinedAvgPriceServe([]string{"BTCUSDT", "ETHUSDT"}, func(event *WsAvgPriceEvent) {}, errHandler)
+	s.r().NoError(err)
+	_, _, err = e.WsKlineServeWithTimezone("BTCUSDT", "1d", "+08:00", func(event *WsKlineEvent) {}, errHandler)
+	s.r().NoError(err)
+	_, _, err = e.WsCombinedKlineServeWithTimezone(map[string]string{"BTCUSDT": "1h"}, "+08:00", func(event *WsKlineEvent) {}, errHandler)
+	s.r().NoError(err)
+
+	s.r().Equal([]string{
+		"wss://ws/bnbbtc@ticker_1d",
+		"wss://combined?streams=bnbbtc@ticker_4h/ethbtc@ticker_4h",
+		"wss://ws/!ticker_1h@arr",
+		"wss://combined?streams=btcusdt@avgPrice/ethusdt@avgPrice",
+		"wss://ws/btcusdt@kline_1d@+08:00",
+		"wss://combined?streams=btcusdt@kline_1h@%2B08:00",
+	}, endpoints)
+}
@@ -32,7 +32,7 @@ func (s *withdrawServiceTestSuite) TestCreateWithdraw() {
 	transactionFeeFlag := true
 	name := "eth"
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"coin":               coin,
 			"withdrawOrderId":    withdrawOrderID,
 			"network":            network,
@@ -104,7 +104,7 @@ func (s *withdrawServiceTestSuite) TestListWithdraws() {
 	offset := 0
 	limit := 1000
 	s.assertReq(func(r *request) {
-		e := newSignedRequest().setParams(params{
+		e := newSignedRequest().SetParams(params{
 			"coin":      coin,
 			"status":    status,
 			"startTime": startTime,